@@ -11,18 +11,27 @@ package main
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/elastic/go-elasticsearch/v8"
 
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/config"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/logging"
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/media"
+	cacheRepo "github.com/njprem/Fit_city_APP_BackEnd/internal/repository/cache"
+	esRepo "github.com/njprem/Fit_city_APP_BackEnd/internal/repository/elasticsearch"
 	minioRepo "github.com/njprem/Fit_city_APP_BackEnd/internal/repository/minio"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/postgres"
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/service"
 	httpx "github.com/njprem/Fit_city_APP_BackEnd/internal/transport/http"
@@ -84,11 +93,16 @@ func main() {
 	destinationRepo := postgres.NewDestinationRepo(db)
 	destinationChangeRepo := postgres.NewDestinationChangeRepo(db)
 	destinationVersionRepo := postgres.NewDestinationVersionRepo(db)
+	destinationApprovalRepo := postgres.NewDestinationApprovalRepo(db)
+	destinationCommentRepo := postgres.NewDestinationCommentRepo(db)
+	destinationCategoryRepo := postgres.NewDestinationCategoryRepo(db)
 	destinationImportRepo := postgres.NewDestinationImportRepo(db)
 	reviewRepo := postgres.NewReviewRepo(db)
 	reviewMediaRepo := postgres.NewReviewMediaRepo(db)
 	favoriteRepo := postgres.NewFavoriteRepo(db)
 	viewStatsRepo := postgres.NewDestinationViewStatsRepo(db)
+	webhookSubscriptionRepo := postgres.NewWebhookSubscriptionRepo(db)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepo(db)
 
 	var esClient *elasticsearch.Client
 	if cfg.ElasticsearchBaseURL != "" {
@@ -105,6 +119,52 @@ func main() {
 		}
 	}
 
+	// Text searches go to Elasticsearch when a destination index is
+	// configured; everything else keeps reading Postgres.
+	var publicDestinationRepo ports.DestinationRepository = destinationRepo
+	var searchIndex ports.DestinationSearchIndex
+	if esClient != nil && cfg.ElasticsearchDestinationIndex != "" {
+		searchTimeout, err := time.ParseDuration(cfg.ElasticsearchDestinationTimeout)
+		if err != nil {
+			log.Printf("invalid ELASTICSEARCH_DESTINATION_TIMEOUT, fallback to 3s: %v", err)
+			searchTimeout = 3 * time.Second
+		}
+		searchRepo := esRepo.NewDestinationSearchRepo(destinationRepo, esClient, esRepo.DestinationSearchConfig{
+			Index:          cfg.ElasticsearchDestinationIndex,
+			Synonyms:       cfg.ElasticsearchDestinationSynonyms,
+			RequestTimeout: searchTimeout,
+		})
+		publicDestinationRepo = searchRepo
+		searchIndex = searchRepo
+	}
+
+	// Public reads can be cached in memory; approvals invalidate every
+	// replica's cache through Postgres LISTEN/NOTIFY.
+	var destinationCache ports.DestinationCache
+	if cfg.EnableDestinationCache {
+		cacheTTL, err := time.ParseDuration(cfg.DestinationCacheTTL)
+		if err != nil || cacheTTL <= 0 {
+			log.Printf("invalid DESTINATION_CACHE_TTL, fallback to 30s: %v", err)
+			cacheTTL = 30 * time.Second
+		}
+		var cacheBus ports.DestinationInvalidationBus
+		if cfg.DestinationCacheNotify {
+			cacheBus = postgres.NewDestinationCacheBus(db, cfg.DatabaseURL)
+		}
+		readCache := cacheRepo.NewDestinationCacheRepo(publicDestinationRepo, cacheBus, cacheRepo.DestinationCacheConfig{
+			MaxEntries: cfg.DestinationCacheMaxEntries,
+			TTL:        cacheTTL,
+			ListDepth:  cfg.DestinationCacheListDepth,
+		})
+		go func() {
+			if err := readCache.Listen(context.Background()); err != nil {
+				log.Printf("destination cache listener stopped: %v", err)
+			}
+		}()
+		publicDestinationRepo = readCache
+		destinationCache = readCache
+	}
+
 	viewStatsTimeout, err := time.ParseDuration(cfg.DestinationViewStatsTimeout)
 	if err != nil {
 		log.Printf("invalid DEST_VIEW_STATS_TIMEOUT, fallback to 5s: %v", err)
@@ -135,10 +195,26 @@ func main() {
 		reviewPublicBase = strings.Replace(reviewPublicBase, cfg.MinIOBucketProfile, cfg.MinIOBucketReviews, 1)
 	}
 
+	webhookTimeout, err := time.ParseDuration(cfg.WebhookRequestTimeout)
+	if err != nil || webhookTimeout <= 0 {
+		log.Printf("invalid WEBHOOK_REQUEST_TIMEOUT, fallback to 10s: %v", err)
+		webhookTimeout = 10 * time.Second
+	}
+	webhookService := service.NewWebhookService(webhookSubscriptionRepo, webhookDeliveryRepo, service.WebhookServiceConfig{
+		MaxAttempts:    cfg.WebhookMaxAttempts,
+		RequestTimeout: webhookTimeout,
+	})
+
+	actionApprovals := make(map[domain.DestinationChangeAction]int, len(cfg.DestinationActionApprovals))
+	for action, count := range cfg.DestinationActionApprovals {
+		actionApprovals[domain.DestinationChangeAction(action)] = count
+	}
+
 	workflowService := service.NewDestinationWorkflowService(
 		destinationRepo,
 		destinationChangeRepo,
 		destinationVersionRepo,
+		destinationApprovalRepo,
 		objectStorage,
 		service.DestinationWorkflowConfig{
 			Bucket:            cfg.MinIOBucketDestinations,
@@ -149,10 +225,30 @@ func main() {
 			ApprovalRequired:  cfg.DestinationApprovalRequired,
 			HardDeleteAllowed: cfg.DestinationHardDeleteAllowed,
 			ImageProcessor:    imageProcessor,
+			ApprovalsRequired: cfg.DestinationApprovalsRequired,
+			ActionApprovals:   actionApprovals,
+			DefaultLocale:     cfg.DestinationDefaultLocale,
+			SupportedLocales:  cfg.DestinationSupportedLocales,
+			Categories:        destinationCategoryRepo,
+			SearchIndex:       searchIndex,
+			Cache:             destinationCache,
+			Webhooks:          webhookService,
+			Transactor:        postgres.NewTransactor(db),
 		},
 	)
 
-	destinationService := service.NewDestinationService(destinationRepo)
+	var commentNotifier service.ChangeCommentNotifier
+	if cfg.SMTPHost != "" && cfg.SMTPPort != "" && cfg.SMTPFrom != "" {
+		commentNotifier = mail.NewChangeCommentMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom, cfg.SMTPUseTLS, userRepo)
+	}
+	commentService := service.NewDestinationCommentService(destinationChangeRepo, destinationCommentRepo, commentNotifier)
+
+	destinationService := service.NewDestinationService(publicDestinationRepo, service.DestinationServiceConfig{
+		DefaultLocale:    cfg.DestinationDefaultLocale,
+		SupportedLocales: cfg.DestinationSupportedLocales,
+		Categories:       destinationCategoryRepo,
+	})
+	categoryService := service.NewDestinationCategoryService(destinationCategoryRepo)
 	importService := service.NewDestinationImportService(
 		destinationImportRepo,
 		destinationRepo,
@@ -176,6 +272,9 @@ func main() {
 			ImageProcessor:    imageProcessor,
 			ImageMaxDimension: cfg.ImageMaxDimension,
 			PublicBaseURL:     reviewPublicBase,
+			Webhooks:          webhookService,
+			SearchIndex:       searchIndex,
+			Cache:             destinationCache,
 		},
 	)
 	favoriteService := service.NewFavoriteService(favoriteRepo, destinationRepo)
@@ -183,16 +282,18 @@ func main() {
 	router := httpx.NewRouter(cfg.AllowOrigins)
 	httpx.RegisterPages(router, cfg.FrontendBaseURL)
 	httpx.RegisterAuth(router, authService)
-	httpx.RegisterDestinations(router, authService, destinationService, workflowService, httpx.DestinationFeatures{
+	httpx.RegisterDestinations(router, authService, destinationService, workflowService, commentService, httpx.DestinationFeatures{
 		View:   cfg.EnableDestinationView,
 		Create: cfg.EnableDestinationCreate,
 		Update: cfg.EnableDestinationUpdate,
 		Delete: cfg.EnableDestinationDelete,
 	})
+	httpx.RegisterDestinationCategories(router, authService, categoryService)
 	httpx.RegisterDestinationImports(router, authService, importService, cfg.EnableDestinationBulkImport, cfg.DestinationImportMaxFileBytes)
 	httpx.RegisterReviews(router, authService, reviewService)
 	httpx.RegisterFavorites(router, authService, favoriteService)
 	httpx.RegisterDestinationStats(router, authService, destinationService, viewStatsService)
+	httpx.RegisterWebhooks(router, authService, webhookService)
 	httpx.RegisterSwagger(router)
 
 	if cfg.EnableDestinationViewStatsRollup {
@@ -204,5 +305,30 @@ func main() {
 		go viewStatsService.RunRollup(context.Background(), rollupInterval)
 	}
 
-	router.Logger.Fatal(router.Start(":" + cfg.Port))
+	if cfg.EnableWebhookDispatch {
+		dispatchInterval, err := time.ParseDuration(cfg.WebhookDispatchInterval)
+		if err != nil || dispatchInterval <= 0 {
+			log.Printf("invalid WEBHOOK_DISPATCH_INTERVAL, fallback to 5s: %v", err)
+			dispatchInterval = 5 * time.Second
+		}
+		go webhookService.RunDispatcher(context.Background(), dispatchInterval)
+	}
+
+	// On SIGINT or SIGTERM, stop taking requests, let the in-flight ones
+	// finish, then wait for comment notifications still being sent.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		if err := router.Start(":" + cfg.Port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			router.Logger.Fatal(err)
+		}
+	}()
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := router.Shutdown(shutdownCtx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+	commentService.Wait()
 }
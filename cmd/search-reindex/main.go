@@ -0,0 +1,55 @@
+// Command search-reindex rebuilds the Elasticsearch destination index from
+// Postgres and switches the ELASTICSEARCH_DESTINATION_INDEX alias to it. Run
+// it once before enabling the index and again after changing synonyms or the
+// mapping.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/config"
+	esRepo "github.com/njprem/Fit_city_APP_BackEnd/internal/repository/elasticsearch"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/postgres"
+)
+
+func main() {
+	cfg := config.Load()
+	log.SetFlags(0)
+
+	if cfg.ElasticsearchDestinationIndex == "" {
+		log.Fatal("ELASTICSEARCH_DESTINATION_INDEX is not set")
+	}
+
+	db, err := postgres.New(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("connect database: %v", err)
+	}
+	defer db.Close()
+
+	esConfig := elasticsearch.Config{
+		Addresses: []string{cfg.ElasticsearchBaseURL},
+	}
+	if cfg.ElasticsearchUsername != "" || cfg.ElasticsearchPassword != "" {
+		esConfig.Username = cfg.ElasticsearchUsername
+		esConfig.Password = cfg.ElasticsearchPassword
+	}
+	esClient, err := elasticsearch.NewClient(esConfig)
+	if err != nil {
+		log.Fatalf("elasticsearch client: %v", err)
+	}
+
+	index := esRepo.NewDestinationSearchRepo(postgres.NewDestinationRepo(db), esClient, esRepo.DestinationSearchConfig{
+		Index:    cfg.ElasticsearchDestinationIndex,
+		Synonyms: cfg.ElasticsearchDestinationSynonyms,
+	})
+	started := time.Now()
+	count, err := index.Rebuild(context.Background())
+	if err != nil {
+		log.Fatalf("rebuild %s: %v (%d destinations indexed before the failure)", cfg.ElasticsearchDestinationIndex, err, count)
+	}
+	log.Printf("rebuilt %s: %d destinations in %s", cfg.ElasticsearchDestinationIndex, count, time.Since(started).Round(time.Millisecond))
+}
@@ -20,6 +20,9 @@ type Config struct {
 	ElasticsearchLogIndex              string
 	ElasticsearchUsername              string
 	ElasticsearchPassword              string
+	ElasticsearchDestinationIndex      string
+	ElasticsearchDestinationSynonyms   []string
+	ElasticsearchDestinationTimeout    string
 	MinIOEndpoint                      string
 	MinIOAccessKey                     string
 	MinIOSecretKey                     string
@@ -48,6 +51,10 @@ type Config struct {
 	EnableDestinationDelete            bool
 	DestinationHardDeleteAllowed       bool
 	DestinationApprovalRequired        bool
+	DestinationApprovalsRequired       int
+	DestinationActionApprovals         map[string]int
+	DestinationDefaultLocale           string
+	DestinationSupportedLocales        []string
 	FFMPEGPath                         string
 	EnableDestinationBulkImport        bool
 	DestinationImportMaxRows           int
@@ -58,6 +65,15 @@ type Config struct {
 	DestinationViewStatsRollupInterval string
 	DestinationViewStatsMaxRange       string
 	EnableDestinationViewStatsRollup   bool
+	EnableDestinationCache             bool
+	DestinationCacheMaxEntries         int
+	DestinationCacheTTL                string
+	DestinationCacheListDepth          int
+	DestinationCacheNotify             bool
+	EnableWebhookDispatch              bool
+	WebhookDispatchInterval            string
+	WebhookMaxAttempts                 int
+	WebhookRequestTimeout              string
 }
 
 const defaultImageMaxDimension = 3840
@@ -105,6 +121,40 @@ func Load() Config {
 	if v, err := strconv.Atoi(getenv("DESTINATION_IMPORT_MAX_PENDING_IDS", "25")); err == nil && v > 0 {
 		maxPendingIDs = v
 	}
+	approvalsRequired := 1
+	if v, err := strconv.Atoi(getenv("DESTINATION_APPROVALS_REQUIRED", "1")); err == nil && v > 0 {
+		approvalsRequired = v
+	}
+	actionApprovals := make(map[string]int)
+	for action, fallback := range map[string]string{"create": "", "update": "", "delete": "2", "restore": ""} {
+		key := "DESTINATION_" + strings.ToUpper(action) + "_APPROVALS_REQUIRED"
+		if v, err := strconv.Atoi(getenv(key, fallback)); err == nil && v > 0 {
+			actionApprovals[action] = v
+		}
+	}
+
+	cacheEntries := 1000
+	if v, err := strconv.Atoi(getenv("DESTINATION_CACHE_MAX_ENTRIES", "1000")); err == nil && v > 0 {
+		cacheEntries = v
+	}
+	cacheListDepth := 100
+	if v, err := strconv.Atoi(getenv("DESTINATION_CACHE_LIST_DEPTH", "100")); err == nil && v > 0 {
+		cacheListDepth = v
+	}
+
+	webhookAttempts := 8
+	if v, err := strconv.Atoi(getenv("WEBHOOK_MAX_ATTEMPTS", "8")); err == nil && v > 0 {
+		webhookAttempts = v
+	}
+
+	// Synonym groups are separated by semicolons since each group is itself a
+	// comma-separated list, e.g. "wat, temple; market, bazaar".
+	var synonyms []string
+	for _, group := range strings.Split(getenv("ELASTICSEARCH_DESTINATION_SYNONYMS", ""), ";") {
+		if trimmed := strings.TrimSpace(group); trimmed != "" {
+			synonyms = append(synonyms, trimmed)
+		}
+	}
 
 	return Config{
 		Port:                               getenv("PORT", "8080"),
@@ -116,6 +166,9 @@ func Load() Config {
 		ElasticsearchLogIndex:              getenv("ELASTICSEARCH_LOG_INDEX", "app-logs-*"),
 		ElasticsearchUsername:              getenv("ELASTICSEARCH_USERNAME", ""),
 		ElasticsearchPassword:              getenv("ELASTICSEARCH_PASSWORD", ""),
+		ElasticsearchDestinationIndex:      getenv("ELASTICSEARCH_DESTINATION_INDEX", ""),
+		ElasticsearchDestinationSynonyms:   synonyms,
+		ElasticsearchDestinationTimeout:    getenv("ELASTICSEARCH_DESTINATION_TIMEOUT", "3s"),
 		MinIOEndpoint:                      must("MINIO_ENDPOINT"),
 		MinIOAccessKey:                     must("MINIO_ACCESS_KEY"),
 		MinIOSecretKey:                     must("MINIO_SECRET_KEY"),
@@ -145,6 +198,10 @@ func Load() Config {
 		EnableDestinationDelete:            getenv("ENABLE_DESTINATION_DELETE", "true") == "true",
 		DestinationHardDeleteAllowed:       getenv("DESTINATION_HARD_DELETE_ALLOWED", "false") == "true",
 		DestinationApprovalRequired:        getenv("DESTINATION_APPROVAL_REQUIRED", "true") == "true",
+		DestinationApprovalsRequired:       approvalsRequired,
+		DestinationActionApprovals:         actionApprovals,
+		DestinationDefaultLocale:           getenv("DESTINATION_DEFAULT_LOCALE", "en"),
+		DestinationSupportedLocales:        splitAndTrim(getenv("DESTINATION_SUPPORTED_LOCALES", "en,th")),
 		FFMPEGPath:                         getenv("FFMPEG_PATH", "ffmpeg"),
 		EnableDestinationBulkImport:        getenv("ENABLE_DESTINATION_BULK_IMPORT", "false") == "true",
 		DestinationImportMaxRows:           importRows,
@@ -155,6 +212,15 @@ func Load() Config {
 		DestinationViewStatsRollupInterval: getenv("DEST_VIEW_STATS_ROLLUP_INTERVAL", "1h"),
 		DestinationViewStatsMaxRange:       getenv("DEST_VIEW_STATS_MAX_RANGE", "720h"),
 		EnableDestinationViewStatsRollup:   getenv("DEST_VIEW_STATS_ROLLUP_ENABLED", "false") == "true",
+		EnableDestinationCache:             getenv("DESTINATION_CACHE_ENABLED", "false") == "true",
+		DestinationCacheMaxEntries:         cacheEntries,
+		DestinationCacheTTL:                getenv("DESTINATION_CACHE_TTL", "30s"),
+		DestinationCacheListDepth:          cacheListDepth,
+		DestinationCacheNotify:             getenv("DESTINATION_CACHE_NOTIFY", "true") == "true",
+		EnableWebhookDispatch:              getenv("WEBHOOK_DISPATCH_ENABLED", "true") == "true",
+		WebhookDispatchInterval:            getenv("WEBHOOK_DISPATCH_INTERVAL", "5s"),
+		WebhookMaxAttempts:                 webhookAttempts,
+		WebhookRequestTimeout:              getenv("WEBHOOK_REQUEST_TIMEOUT", "10s"),
 	}
 }
 
@@ -58,29 +58,41 @@ func (g *DestinationGallery) Scan(value any) error {
 }
 
 type Destination struct {
-	ID            uuid.UUID          `db:"id" json:"id"`
-	Name          string             `db:"name" json:"name"`
-	Slug          *string            `db:"slug" json:"slug,omitempty"`
-	Status        DestinationStatus  `db:"status" json:"status"`
-	Version       int64              `db:"version" json:"version"`
-	City          *string            `db:"city" json:"city,omitempty"`
-	Country       *string            `db:"country" json:"country,omitempty"`
-	Category      *string            `db:"category" json:"category,omitempty"`
-	Description   *string            `db:"description" json:"description,omitempty"`
-	Latitude      *float64           `db:"latitude" json:"latitude,omitempty"`
-	Longitude     *float64           `db:"longitude" json:"longitude,omitempty"`
-	Contact       *string            `db:"contact" json:"contact,omitempty"`
-	OpeningTime   *string            `db:"opening_time" json:"opening_time,omitempty"`
-	ClosingTime   *string            `db:"closing_time" json:"closing_time,omitempty"`
-	Gallery       DestinationGallery `db:"gallery" json:"gallery,omitempty"`
-	HeroImage     *string            `db:"hero_image_url" json:"hero_image_url,omitempty"`
-	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
-	UpdatedAt     time.Time          `db:"updated_at" json:"updated_at"`
-	UpdatedBy     *uuid.UUID         `db:"updated_by" json:"updated_by,omitempty"`
-	DeletedAt     *time.Time         `db:"deleted_at" json:"deleted_at,omitempty"`
-	AverageRating float64            `db:"average_rating" json:"-"`
-	ReviewCount   int                `db:"review_count" json:"-"`
-	TotalCount    int                `db:"total_count" json:"-"`
+	ID            uuid.UUID               `db:"id" json:"id"`
+	Name          string                  `db:"name" json:"name"`
+	Slug          *string                 `db:"slug" json:"slug,omitempty"`
+	Status        DestinationStatus       `db:"status" json:"status"`
+	Version       int64                   `db:"version" json:"version"`
+	City          *string                 `db:"city" json:"city,omitempty"`
+	Country       *string                 `db:"country" json:"country,omitempty"`
+	Category      *string                 `db:"category" json:"category,omitempty"`
+	Description   *string                 `db:"description" json:"description,omitempty"`
+	Latitude      *float64                `db:"latitude" json:"latitude,omitempty"`
+	Longitude     *float64                `db:"longitude" json:"longitude,omitempty"`
+	Contact       *string                 `db:"contact" json:"contact,omitempty"`
+	OpeningTime   *string                 `db:"opening_time" json:"opening_time,omitempty"`
+	ClosingTime   *string                 `db:"closing_time" json:"closing_time,omitempty"`
+	Timezone      *string                 `db:"timezone" json:"timezone,omitempty"`
+	OpeningHours  *OpeningHours           `db:"opening_hours" json:"opening_hours,omitempty"`
+	Translations  DestinationTranslations `db:"translations" json:"translations,omitempty"`
+	Tags          DestinationTags         `db:"tags" json:"tags,omitempty"`
+	Gallery       DestinationGallery      `db:"gallery" json:"gallery,omitempty"`
+	HeroImage     *string                 `db:"hero_image_url" json:"hero_image_url,omitempty"`
+	CreatedAt     time.Time               `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time               `db:"updated_at" json:"updated_at"`
+	UpdatedBy     *uuid.UUID              `db:"updated_by" json:"updated_by,omitempty"`
+	DeletedAt     *time.Time              `db:"deleted_at" json:"deleted_at,omitempty"`
+	AverageRating float64                 `db:"average_rating" json:"-"`
+	ReviewCount   int                     `db:"review_count" json:"-"`
+	TotalCount    int                     `db:"total_count" json:"-"`
+	DistanceKM    *float64                `db:"distance_km" json:"-"`
+	SearchScore   *float64                `db:"search_score" json:"-"`
+	// SearchHeadline is the description excerpt around the search match,
+	// with matched words between SearchHighlightStart and SearchHighlightStop.
+	SearchHeadline *string `db:"search_headline" json:"-"`
+	// RetiredSlug is set when the destination was found through a slug it
+	// used to have; Slug then holds the canonical one.
+	RetiredSlug *string `db:"retired_slug" json:"-"`
 }
 
 func (d Destination) IsPublished() bool {
@@ -101,6 +113,10 @@ const (
 	DestinationSortNameDesc      DestinationListSort = "name_desc"
 	DestinationSortSimilarity    DestinationListSort = "similarity"
 	DestinationSortDistanceAsc   DestinationListSort = "distance"
+	// DestinationSortRelevance ranks full-text matches, weighting name over
+	// city and country over category over description, with trigram name
+	// similarity added so typos still rank.
+	DestinationSortRelevance DestinationListSort = "relevance"
 )
 
 func (s DestinationListSort) IsValid() bool {
@@ -111,6 +127,7 @@ func (s DestinationListSort) IsValid() bool {
 		DestinationSortNameAsc,
 		DestinationSortNameDesc,
 		DestinationSortSimilarity,
+		DestinationSortRelevance,
 		DestinationSortDistanceAsc:
 		return true
 	default:
@@ -120,6 +137,7 @@ func (s DestinationListSort) IsValid() bool {
 
 type DestinationListFilter struct {
 	Search        string
+	SearchTerms   []DestinationSearchTerm
 	Categories    []string
 	MinRating     *float64
 	MaxRating     *float64
@@ -128,5 +146,15 @@ type DestinationListFilter struct {
 	Latitude      *float64
 	Longitude     *float64
 	MaxDistanceKM *float64
+	BBox          *DestinationBoundingBox
+	OpenAt        *time.Time
+	Tags          []string
+	TagMatch      DestinationTagMatch
 	Sort          DestinationListSort
+	// IDs restricts the listing to these destinations; used to load single
+	// destinations with their rating aggregates.
+	IDs []uuid.UUID
+	// After switches to keyset paging: only destinations sorting after the
+	// cursor are returned and the offset is ignored.
+	After *DestinationListCursor
 }
@@ -0,0 +1,11 @@
+package domain
+
+// DestinationCacheStats are the read cache's counters since start-up.
+type DestinationCacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Evictions     uint64 `json:"evictions"`
+	Invalidations uint64 `json:"invalidations"`
+	Entries       int    `json:"entries"`
+	MaxEntries    int    `json:"max_entries"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// DestinationCatalogVersion summarises everything public destination
+// listings are computed from. Any published edit, review or category change
+// moves at least one of its fields, so two equal versions serve equal
+// listings.
+type DestinationCatalogVersion struct {
+	// UpdatedAt is the latest change to a destination, review or category.
+	UpdatedAt    time.Time `db:"updated_at"`
+	Destinations int       `db:"destinations"`
+	Reviews      int       `db:"reviews"`
+	Categories   int       `db:"categories"`
+}
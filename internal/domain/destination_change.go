@@ -16,6 +16,9 @@ const (
 	DestinationChangeActionCreate DestinationChangeAction = "create"
 	DestinationChangeActionUpdate DestinationChangeAction = "update"
 	DestinationChangeActionDelete DestinationChangeAction = "delete"
+	// DestinationChangeActionRestore returns an archived destination to draft
+	// or published.
+	DestinationChangeActionRestore DestinationChangeAction = "restore"
 )
 
 type DestinationChangeStatus string
@@ -27,24 +30,39 @@ const (
 	DestinationChangeStatusRejected      DestinationChangeStatus = "rejected"
 )
 
+type DestinationApprovalDecision string
+
+const (
+	DestinationApprovalDecisionApproved DestinationApprovalDecision = "approved"
+	DestinationApprovalDecisionRejected DestinationApprovalDecision = "rejected"
+)
+
 type DestinationChangeFields struct {
-	Name               *string             `json:"name,omitempty"`
-	Slug               *string             `json:"slug,omitempty"`
-	City               *string             `json:"city,omitempty"`
-	Country            *string             `json:"country,omitempty"`
-	Category           *string             `json:"category,omitempty"`
-	Description        *string             `json:"description,omitempty"`
-	Latitude           *float64            `json:"latitude,omitempty"`
-	Longitude          *float64            `json:"longitude,omitempty"`
-	Contact            *string             `json:"contact,omitempty"`
-	OpeningTime        *string             `json:"opening_time,omitempty"`
-	ClosingTime        *string             `json:"closing_time,omitempty"`
-	Gallery            *DestinationGallery `json:"gallery,omitempty"`
-	Status             *DestinationStatus  `json:"status,omitempty"`
-	HeroImageUploadID  *string             `json:"hero_image_upload_id,omitempty"`
-	HeroImageURL       *string             `json:"hero_image_url,omitempty"`
-	PublishedHeroImage *string             `json:"published_hero_image,omitempty"`
-	HardDelete         *bool               `json:"hard_delete,omitempty"`
+	Name               *string                  `json:"name,omitempty"`
+	Slug               *string                  `json:"slug,omitempty"`
+	City               *string                  `json:"city,omitempty"`
+	Country            *string                  `json:"country,omitempty"`
+	Category           *string                  `json:"category,omitempty"`
+	Description        *string                  `json:"description,omitempty"`
+	Latitude           *float64                 `json:"latitude,omitempty"`
+	Longitude          *float64                 `json:"longitude,omitempty"`
+	Contact            *string                  `json:"contact,omitempty"`
+	OpeningTime        *string                  `json:"opening_time,omitempty"`
+	ClosingTime        *string                  `json:"closing_time,omitempty"`
+	Timezone           *string                  `json:"timezone,omitempty"`
+	OpeningHours       *OpeningHours            `json:"opening_hours,omitempty"`
+	Translations       *DestinationTranslations `json:"translations,omitempty"`
+	Tags               *DestinationTags         `json:"tags,omitempty"`
+	Gallery            *DestinationGallery      `json:"gallery,omitempty"`
+	Status             *DestinationStatus       `json:"status,omitempty"`
+	HeroImageUploadID  *string                  `json:"hero_image_upload_id,omitempty"`
+	HeroImageURL       *string                  `json:"hero_image_url,omitempty"`
+	PublishedHeroImage *string                  `json:"published_hero_image,omitempty"`
+	HardDelete         *bool                    `json:"hard_delete,omitempty"`
+	SourceVersion      *int64                   `json:"source_version,omitempty"`
+	// RemovedMedia lists gallery URLs taken out by gallery edits. Their
+	// objects are deleted from storage once the change is approved.
+	RemovedMedia []string `json:"removed_media,omitempty"`
 }
 
 func (f DestinationChangeFields) Value() (driver.Value, error) {
@@ -81,6 +99,7 @@ type DestinationChangeRequest struct {
 	ReviewedAt       *time.Time              `db:"reviewed_at" json:"reviewed_at,omitempty"`
 	ReviewMessage    *string                 `db:"review_message" json:"review_message,omitempty"`
 	PublishedVersion *int64                  `db:"published_version" json:"published_version,omitempty"`
+	BaseVersion      *int64                  `db:"base_version" json:"base_version,omitempty"`
 	CreatedAt        time.Time               `db:"created_at" json:"created_at"`
 	UpdatedAt        time.Time               `db:"updated_at" json:"updated_at"`
 	TotalCount       int                     `db:"total_count" json:"-"`
@@ -97,30 +116,36 @@ func (c DestinationChangeRequest) IsPendingReview() bool {
 type DestinationChangeFilter struct {
 	DestinationID *uuid.UUID
 	SubmittedBy   *uuid.UUID
+	ImportJobID   *uuid.UUID
 	Statuses      []DestinationChangeStatus
+	Actions       []DestinationChangeAction
 	Limit         int
 	Offset        int
 }
 
 type DestinationSnapshot struct {
-	ID          uuid.UUID          `json:"id"`
-	Name        string             `json:"name"`
-	Slug        *string            `json:"slug,omitempty"`
-	Status      DestinationStatus  `json:"status"`
-	Version     int64              `json:"version"`
-	City        *string            `json:"city,omitempty"`
-	Country     *string            `json:"country,omitempty"`
-	Category    *string            `json:"category,omitempty"`
-	Description *string            `json:"description,omitempty"`
-	Latitude    *float64           `json:"latitude,omitempty"`
-	Longitude   *float64           `json:"longitude,omitempty"`
-	Contact     *string            `json:"contact,omitempty"`
-	OpeningTime *string            `json:"opening_time,omitempty"`
-	ClosingTime *string            `json:"closing_time,omitempty"`
-	Gallery     DestinationGallery `json:"gallery,omitempty"`
-	HeroImage   *string            `json:"hero_image_url,omitempty"`
-	UpdatedAt   time.Time          `json:"updated_at"`
-	UpdatedBy   *uuid.UUID         `json:"updated_by,omitempty"`
+	ID           uuid.UUID               `json:"id"`
+	Name         string                  `json:"name"`
+	Slug         *string                 `json:"slug,omitempty"`
+	Status       DestinationStatus       `json:"status"`
+	Version      int64                   `json:"version"`
+	City         *string                 `json:"city,omitempty"`
+	Country      *string                 `json:"country,omitempty"`
+	Category     *string                 `json:"category,omitempty"`
+	Description  *string                 `json:"description,omitempty"`
+	Latitude     *float64                `json:"latitude,omitempty"`
+	Longitude    *float64                `json:"longitude,omitempty"`
+	Contact      *string                 `json:"contact,omitempty"`
+	OpeningTime  *string                 `json:"opening_time,omitempty"`
+	ClosingTime  *string                 `json:"closing_time,omitempty"`
+	Timezone     *string                 `json:"timezone,omitempty"`
+	OpeningHours *OpeningHours           `json:"opening_hours,omitempty"`
+	Translations DestinationTranslations `json:"translations,omitempty"`
+	Tags         DestinationTags         `json:"tags,omitempty"`
+	Gallery      DestinationGallery      `json:"gallery,omitempty"`
+	HeroImage    *string                 `json:"hero_image_url,omitempty"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+	UpdatedBy    *uuid.UUID              `json:"updated_by,omitempty"`
 }
 
 func (s DestinationSnapshot) Value() (driver.Value, error) {
@@ -147,7 +172,19 @@ type DestinationVersion struct {
 	DestinationID   uuid.UUID           `db:"destination_id" json:"destination_id"`
 	ChangeRequestID *uuid.UUID          `db:"change_request_id" json:"change_request_id,omitempty"`
 	Version         int64               `db:"version" json:"version"`
+	SourceVersion   *int64              `db:"source_version" json:"source_version,omitempty"`
 	Snapshot        DestinationSnapshot `db:"snapshot" json:"snapshot"`
 	CreatedAt       time.Time           `db:"created_at" json:"created_at"`
 	CreatedBy       uuid.UUID           `db:"created_by" json:"created_by"`
 }
+
+// DestinationChangeApproval records one reviewer's decision on a change request.
+type DestinationChangeApproval struct {
+	ID              uuid.UUID                   `db:"id" json:"id"`
+	ChangeRequestID uuid.UUID                   `db:"change_request_id" json:"change_request_id"`
+	ReviewerID      uuid.UUID                   `db:"reviewer_id" json:"reviewer_id"`
+	Decision        DestinationApprovalDecision `db:"decision" json:"decision"`
+	Comment         *string                     `db:"comment" json:"comment,omitempty"`
+	DraftVersion    int                         `db:"draft_version" json:"draft_version"`
+	CreatedAt       time.Time                   `db:"created_at" json:"created_at"`
+}
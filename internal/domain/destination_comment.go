@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DestinationChangeComment is a discussion entry on a change request. Replies
+// point at the thread's root comment through ParentID; only roots carry the
+// resolved state.
+type DestinationChangeComment struct {
+	ID              uuid.UUID  `db:"id" json:"id"`
+	ChangeRequestID uuid.UUID  `db:"change_request_id" json:"change_request_id"`
+	ParentID        *uuid.UUID `db:"parent_id" json:"parent_id,omitempty"`
+	AuthorID        uuid.UUID  `db:"author_id" json:"author_id"`
+	Body            string     `db:"body" json:"body"`
+	Field           *string    `db:"field" json:"field,omitempty"`
+	ResolvedBy      *uuid.UUID `db:"resolved_by" json:"resolved_by,omitempty"`
+	ResolvedAt      *time.Time `db:"resolved_at" json:"resolved_at,omitempty"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+func (c DestinationChangeComment) Resolved() bool {
+	return c.ResolvedAt != nil
+}
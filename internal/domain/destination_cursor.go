@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DestinationListCursor marks the last destination of a keyset page. It holds
+// every key any sort orders by, plus the id as the final tiebreaker, so the
+// next page starts strictly after that row even if other rows changed.
+type DestinationListCursor struct {
+	Sort      DestinationListSort `json:"s"`
+	ID        uuid.UUID           `json:"id"`
+	UpdatedAt time.Time           `json:"u"`
+	Name      string              `json:"n"`
+	Rating    float64             `json:"r"`
+	Score     *float64            `json:"sc,omitempty"`
+	Distance  *float64            `json:"d,omitempty"`
+}
+
+// CursorAfter returns the cursor positioned on dest for a listing sorted by
+// sort.
+func CursorAfter(sort DestinationListSort, dest Destination) DestinationListCursor {
+	return DestinationListCursor{
+		Sort:      sort,
+		ID:        dest.ID,
+		UpdatedAt: dest.UpdatedAt,
+		Name:      dest.Name,
+		Rating:    dest.AverageRating,
+		Score:     dest.SearchScore,
+		Distance:  dest.DistanceKM,
+	}
+}
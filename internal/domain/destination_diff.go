@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type DestinationGalleryChangeType string
+
+const (
+	DestinationGalleryItemAdded          DestinationGalleryChangeType = "added"
+	DestinationGalleryItemRemoved        DestinationGalleryChangeType = "removed"
+	DestinationGalleryItemReordered      DestinationGalleryChangeType = "reordered"
+	DestinationGalleryItemCaptionChanged DestinationGalleryChangeType = "caption_changed"
+)
+
+type DestinationFieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type DestinationGalleryChange struct {
+	Type        DestinationGalleryChangeType `json:"type"`
+	URL         string                       `json:"url"`
+	OldOrdering *int                         `json:"old_ordering,omitempty"`
+	NewOrdering *int                         `json:"new_ordering,omitempty"`
+	OldCaption  *string                      `json:"old_caption,omitempty"`
+	NewCaption  *string                      `json:"new_caption,omitempty"`
+}
+
+type DestinationFieldConflict struct {
+	Field   string `json:"field"`
+	Base    any    `json:"base"`
+	Current any    `json:"current"`
+	Draft   any    `json:"draft"`
+}
+
+type DestinationDiff struct {
+	DestinationID *uuid.UUID                 `json:"destination_id,omitempty"`
+	FromVersion   *int64                     `json:"from_version,omitempty"`
+	ToVersion     *int64                     `json:"to_version,omitempty"`
+	Fields        []DestinationFieldChange   `json:"fields"`
+	Gallery       []DestinationGalleryChange `json:"gallery"`
+}
+
+func (d DestinationDiff) IsEmpty() bool {
+	return len(d.Fields) == 0 && len(d.Gallery) == 0
+}
+
+func (d DestinationDiff) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *DestinationDiff) Scan(value any) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte for destination diff, got %T", value)
+	}
+	return json.Unmarshal(bytes, d)
+}
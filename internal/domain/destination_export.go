@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DestinationExportFilter selects destinations for the admin catalogue
+// export. Unlike public listings it covers every status.
+type DestinationExportFilter struct {
+	Statuses     []DestinationStatus
+	Categories   []string
+	UpdatedSince *time.Time
+	// AfterID continues an export after the destination with this ID;
+	// exports are ordered by ID.
+	AfterID *uuid.UUID
+}
+
+// DestinationExportLayout is the shape of the destinations an export covers:
+// the largest gallery and every locale with a stored translation, so the CSV
+// header has a column for each value.
+type DestinationExportLayout struct {
+	GallerySlots int
+	Locales      []string
+}
@@ -0,0 +1,34 @@
+package domain
+
+// DestinationFacet is a listing dimension the search UI can show result
+// counts for.
+type DestinationFacet string
+
+const (
+	DestinationFacetCategory DestinationFacet = "category"
+	DestinationFacetCountry  DestinationFacet = "country"
+	DestinationFacetCity     DestinationFacet = "city"
+	// DestinationFacetRating counts destinations per minimum rating band,
+	// the values min_rating accepts: "4" means rated 4 or higher.
+	DestinationFacetRating DestinationFacet = "rating"
+)
+
+// DestinationRatingBands are the rating facet's bands, highest first.
+var DestinationRatingBands = []int{4, 3, 2, 1}
+
+func (f DestinationFacet) IsValid() bool {
+	switch f {
+	case DestinationFacetCategory,
+		DestinationFacetCountry,
+		DestinationFacetCity,
+		DestinationFacetRating:
+		return true
+	default:
+		return false
+	}
+}
+
+type DestinationFacetCount struct {
+	Value string `db:"value" json:"value"`
+	Count int    `db:"count" json:"count"`
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OpeningWeekdays lists the accepted weekday keys, indexed by time.Weekday.
+var OpeningWeekdays = [7]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// OpeningHours describes when a destination is open, in the destination's
+// local timezone. Weekly periods repeat every week; an exception replaces the
+// weekly periods for a single date.
+type OpeningHours struct {
+	Weekly     []OpeningPeriod    `json:"weekly,omitempty"`
+	Exceptions []OpeningException `json:"exceptions,omitempty"`
+}
+
+// OpeningPeriod is one open interval. A close time at or before the open time
+// runs past midnight into the next day; "24:00" closes at the end of the day.
+// Day is only used for weekly periods.
+type OpeningPeriod struct {
+	Day   string `json:"day,omitempty"`
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// OpeningException overrides the weekly hours on Date (YYYY-MM-DD). A closed
+// exception has no periods.
+type OpeningException struct {
+	Date    string          `json:"date"`
+	Closed  bool            `json:"closed,omitempty"`
+	Periods []OpeningPeriod `json:"periods,omitempty"`
+	Note    *string         `json:"note,omitempty"`
+}
+
+func (h OpeningHours) IsEmpty() bool {
+	return len(h.Weekly) == 0 && len(h.Exceptions) == 0
+}
+
+func (h OpeningHours) Value() (driver.Value, error) {
+	if h.IsEmpty() {
+		return nil, nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (h *OpeningHours) Scan(value any) error {
+	if h == nil {
+		return errors.New("opening hours scan on nil receiver")
+	}
+	if value == nil {
+		*h = OpeningHours{}
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("opening hours expected []byte, got %T", value)
+	}
+	return json.Unmarshal(bytes, h)
+}
+
+// IsOpenAt reports whether the destination is open at the given instant,
+// evaluated in loc. Periods that run past midnight are taken from the previous
+// day, including that day's exception if it has one.
+func (h OpeningHours) IsOpenAt(at time.Time, loc *time.Location) bool {
+	if loc == nil {
+		loc = time.UTC
+	}
+	local := at.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	for _, period := range h.PeriodsOn(today) {
+		open, close, ok := period.minutes()
+		if !ok {
+			continue
+		}
+		if close > open {
+			if minute >= open && minute < close {
+				return true
+			}
+		} else if minute >= open {
+			return true
+		}
+	}
+	for _, period := range h.PeriodsOn(today.AddDate(0, 0, -1)) {
+		open, close, ok := period.minutes()
+		if ok && close <= open && minute < close {
+			return true
+		}
+	}
+	return false
+}
+
+// PeriodsOn returns the periods that start on the given local date.
+func (h OpeningHours) PeriodsOn(day time.Time) []OpeningPeriod {
+	date := day.Format("2006-01-02")
+	for _, exception := range h.Exceptions {
+		if exception.Date == date {
+			if exception.Closed {
+				return nil
+			}
+			return exception.Periods
+		}
+	}
+	key := OpeningWeekdays[day.Weekday()]
+	periods := make([]OpeningPeriod, 0, 2)
+	for _, period := range h.Weekly {
+		if strings.EqualFold(period.Day, key) {
+			periods = append(periods, period)
+		}
+	}
+	return periods
+}
+
+func (p OpeningPeriod) minutes() (int, int, bool) {
+	open, ok := ParseClockMinutes(p.Open, false)
+	if !ok {
+		return 0, 0, false
+	}
+	close, ok := ParseClockMinutes(p.Close, true)
+	if !ok {
+		return 0, 0, false
+	}
+	return open, close, true
+}
+
+// ParseClockMinutes converts an HH:MM string to minutes after midnight.
+// "24:00" is only accepted when allowEndOfDay is set.
+func ParseClockMinutes(raw string, allowEndOfDay bool) (int, bool) {
+	if allowEndOfDay && raw == "24:00" {
+		return 24 * 60, true
+	}
+	parsed, err := time.Parse("15:04", raw)
+	if err != nil {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
@@ -52,5 +52,8 @@ type DestinationImportRow struct {
 	ChangeID     *uuid.UUID                 `db:"change_id" json:"change_id,omitempty"`
 	ErrorMessage *string                    `db:"error" json:"error,omitempty"`
 	Payload      DestinationChangeFields    `db:"payload" json:"payload"`
-	CreatedAt    time.Time                  `db:"created_at" json:"created_at"`
+	// Diff compares the row against the live destination when it was
+	// imported; creates are compared against an empty destination.
+	Diff      *DestinationDiff `db:"diff" json:"diff,omitempty"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
 }
@@ -0,0 +1,37 @@
+package domain
+
+import "github.com/google/uuid"
+
+// DestinationBoundingBox is a map viewport. A MinLng greater than MaxLng
+// means the box crosses the antimeridian.
+type DestinationBoundingBox struct {
+	MinLat float64
+	MinLng float64
+	MaxLat float64
+	MaxLng float64
+}
+
+func (b DestinationBoundingBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+func (b DestinationBoundingBox) Contains(lat, lng float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return lng >= b.MinLng || lng <= b.MaxLng
+	}
+	return lng >= b.MinLng && lng <= b.MaxLng
+}
+
+// DestinationMapCluster is a group of destinations shown as one map marker.
+// Clusters of one carry the destination's id, name and slug.
+type DestinationMapCluster struct {
+	Latitude      float64    `db:"latitude" json:"latitude"`
+	Longitude     float64    `db:"longitude" json:"longitude"`
+	Count         int        `db:"count" json:"count"`
+	DestinationID *uuid.UUID `db:"destination_id" json:"destination_id,omitempty"`
+	Name          *string    `db:"name" json:"name,omitempty"`
+	Slug          *string    `db:"slug" json:"slug,omitempty"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+// DestinationSearchTerm is one required term of a search query; the service
+// parses DestinationListFilter.Search into SearchTerms. Several words form a
+// phrase that must match in order; Prefix lets the last word match any word
+// starting with it.
+type DestinationSearchTerm struct {
+	Words  []string
+	Prefix bool
+}
+
+// Destination.SearchHeadline wraps matched words in these private-use
+// characters, which cannot collide with description text markup. Transports
+// escape the snippet and swap them for their own highlight markup.
+const (
+	SearchHighlightStart = "\ue000"
+	SearchHighlightStop  = "\ue001"
+)
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DestinationCategory is an admin-managed category. Categories form a tree
+// through ParentID; siblings are shown in DisplayOrder, then by name.
+type DestinationCategory struct {
+	ID           uuid.UUID             `db:"id" json:"id"`
+	Slug         string                `db:"slug" json:"slug"`
+	Name         string                `db:"name" json:"name"`
+	ParentID     *uuid.UUID            `db:"parent_id" json:"parent_id,omitempty"`
+	Icon         *string               `db:"icon" json:"icon,omitempty"`
+	DisplayOrder int                   `db:"display_order" json:"display_order"`
+	CreatedAt    time.Time             `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time             `db:"updated_at" json:"updated_at"`
+	Children     []DestinationCategory `db:"-" json:"children,omitempty"`
+}
+
+// DestinationTags are free-form labels such as amenities ("parking",
+// "wheelchair-access"). They are stored lowercased and hyphenated.
+type DestinationTags []string
+
+func (t DestinationTags) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (t *DestinationTags) Scan(value any) error {
+	if t == nil {
+		return errors.New("destination tags scan on nil receiver")
+	}
+	if value == nil {
+		*t = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("destination tags expected []byte, got %T", value)
+	}
+	var items []string
+	if err := json.Unmarshal(bytes, &items); err != nil {
+		return err
+	}
+	*t = DestinationTags(items)
+	return nil
+}
+
+type DestinationTagMatch string
+
+const (
+	DestinationTagMatchAny DestinationTagMatch = "any"
+	DestinationTagMatchAll DestinationTagMatch = "all"
+)
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DestinationTranslation holds the localized copy of a destination for one
+// locale. Captions are keyed by gallery media URL so they survive reordering.
+type DestinationTranslation struct {
+	Name        *string           `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Captions    map[string]string `json:"captions,omitempty"`
+}
+
+// DestinationTranslations maps a locale (e.g. "th") to its translation. The
+// destination's own name, description and captions are in the default locale.
+type DestinationTranslations map[string]DestinationTranslation
+
+func (t DestinationTranslations) Value() (driver.Value, error) {
+	if len(t) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (t *DestinationTranslations) Scan(value any) error {
+	if t == nil {
+		return errors.New("destination translations scan on nil receiver")
+	}
+	if value == nil {
+		*t = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("destination translations expected []byte, got %T", value)
+	}
+	var items map[string]DestinationTranslation
+	if err := json.Unmarshal(bytes, &items); err != nil {
+		return err
+	}
+	*t = DestinationTranslations(items)
+	return nil
+}
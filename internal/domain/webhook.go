@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEvent names something partners can subscribe to. Destination events
+// follow public visibility, so changes to unpublished drafts stay silent.
+type WebhookEvent string
+
+const (
+	// WebhookEventDestinationPublished fires when a destination becomes
+	// publicly visible, on creation or restore.
+	WebhookEventDestinationPublished WebhookEvent = "destination.published"
+	// WebhookEventDestinationUpdated fires when a published destination changes
+	// and stays published.
+	WebhookEventDestinationUpdated WebhookEvent = "destination.updated"
+	// WebhookEventDestinationArchived fires when a published destination is
+	// archived or moved back to draft.
+	WebhookEventDestinationArchived WebhookEvent = "destination.archived"
+	// WebhookEventDestinationDeleted fires when a destination is hard deleted,
+	// whatever its status was.
+	WebhookEventDestinationDeleted WebhookEvent = "destination.deleted"
+	WebhookEventReviewPosted       WebhookEvent = "review.posted"
+	WebhookEventReviewDeleted      WebhookEvent = "review.deleted"
+)
+
+var WebhookEvents = []WebhookEvent{
+	WebhookEventDestinationPublished,
+	WebhookEventDestinationUpdated,
+	WebhookEventDestinationArchived,
+	WebhookEventDestinationDeleted,
+	WebhookEventReviewPosted,
+	WebhookEventReviewDeleted,
+}
+
+func (e WebhookEvent) IsValid() bool {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEventList is the set of events a subscription receives, stored as a
+// JSON array.
+type WebhookEventList []WebhookEvent
+
+func (l WebhookEventList) Contains(event WebhookEvent) bool {
+	for _, e := range l {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (l WebhookEventList) Value() (driver.Value, error) {
+	if l == nil {
+		l = WebhookEventList{}
+	}
+	return json.Marshal(l)
+}
+
+func (l *WebhookEventList) Scan(value any) error {
+	if l == nil {
+		return errors.New("webhook event list scan on nil receiver")
+	}
+	if value == nil {
+		*l = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("webhook event list expected []byte, got %T", value)
+	}
+	var events []WebhookEvent
+	if err := json.Unmarshal(bytes, &events); err != nil {
+		return err
+	}
+	*l = WebhookEventList(events)
+	return nil
+}
+
+// WebhookSubscription is a partner endpoint. The secret signs every delivery
+// and is only shown when the subscription is created or its secret rotated.
+type WebhookSubscription struct {
+	ID          uuid.UUID        `db:"id" json:"id"`
+	URL         string           `db:"url" json:"url"`
+	Description *string          `db:"description" json:"description,omitempty"`
+	Events      WebhookEventList `db:"events" json:"events"`
+	Secret      string           `db:"secret" json:"-"`
+	Active      bool             `db:"active" json:"active"`
+	CreatedBy   uuid.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt   time.Time        `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time        `db:"updated_at" json:"updated_at"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to one subscription, kept as the delivery
+// log. Replays are new deliveries pointing at the original through ReplayOf.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `db:"id" json:"id"`
+	SubscriptionID uuid.UUID             `db:"subscription_id" json:"subscription_id"`
+	Event          WebhookEvent          `db:"event" json:"event"`
+	Payload        json.RawMessage       `db:"payload" json:"payload"`
+	Status         WebhookDeliveryStatus `db:"status" json:"status"`
+	Attempts       int                   `db:"attempts" json:"attempts"`
+	NextAttemptAt  *time.Time            `db:"next_attempt_at" json:"next_attempt_at,omitempty"`
+	LastError      *string               `db:"last_error" json:"last_error,omitempty"`
+	ResponseStatus *int                  `db:"response_status" json:"response_status,omitempty"`
+	ReplayOf       *uuid.UUID            `db:"replay_of" json:"replay_of,omitempty"`
+	DeliveredAt    *time.Time            `db:"delivered_at" json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time             `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,281 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+type DestinationCacheConfig struct {
+	// MaxEntries bounds the cache; the least recently used entry goes first.
+	MaxEntries int
+	// TTL bounds how stale a destination entry can get when an invalidation
+	// is lost, such as a failed publish to the other replicas.
+	TTL time.Duration
+	// ListDepth is how many leading rows of a listing are cached. Deeper
+	// pages, cursor pages and opening-hours filters always go to the wrapped
+	// repository.
+	ListDepth int
+}
+
+// DestinationCacheRepository serves published destination lookups and the
+// first pages of listings from a bounded in-memory LRU cache, and leaves
+// everything else to the wrapped repository.
+type DestinationCacheRepository struct {
+	ports.DestinationRepository
+	bus       ports.DestinationInvalidationBus
+	maxSize   int
+	ttl       time.Duration
+	listDepth int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+	// generation counts invalidations. A read that missed before an
+	// invalidation may have loaded stale rows, so its result is not stored.
+	generation uint64
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	evictions     atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+type cacheEntry struct {
+	key     string
+	expires time.Time
+	// destinationID is set on single-destination entries so they can be
+	// invalidated individually; listings are dropped on any invalidation.
+	destinationID uuid.UUID
+	destination   *domain.Destination
+	destinations  []domain.Destination
+	// catalog is the catalogue version read before a listing was loaded.
+	catalog domain.DestinationCatalogVersion
+}
+
+// NewDestinationCacheRepo wraps inner. bus may be nil when a single replica
+// runs; invalidations then stay local.
+func NewDestinationCacheRepo(inner ports.DestinationRepository, bus ports.DestinationInvalidationBus, cfg DestinationCacheConfig) *DestinationCacheRepository {
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = 1000
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	if cfg.ListDepth <= 0 {
+		cfg.ListDepth = 100
+	}
+	return &DestinationCacheRepository{
+		DestinationRepository: inner,
+		bus:                   bus,
+		maxSize:               cfg.MaxEntries,
+		ttl:                   cfg.TTL,
+		listDepth:             cfg.ListDepth,
+		now:                   time.Now,
+		order:                 list.New(),
+		entries:               make(map[string]*list.Element),
+	}
+}
+
+// Listen applies invalidations from other replicas until ctx is done. Run it
+// in its own goroutine when a bus is configured.
+func (r *DestinationCacheRepository) Listen(ctx context.Context) error {
+	if r.bus == nil {
+		return nil
+	}
+	return r.bus.Listen(ctx, r.evict)
+}
+
+func (r *DestinationCacheRepository) FindPublishedByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error) {
+	key := "id:" + id.String()
+	entry, generation := r.get(key, nil)
+	if entry != nil {
+		return cloneDestination(entry.destination), nil
+	}
+	dest, err := r.DestinationRepository.FindPublishedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.put(&cacheEntry{key: key, destinationID: dest.ID, destination: cloneDestination(dest)}, generation)
+	return dest, nil
+}
+
+func (r *DestinationCacheRepository) FindBySlug(ctx context.Context, slug string) (*domain.Destination, error) {
+	key := "slug:" + slug
+	entry, generation := r.get(key, nil)
+	if entry != nil {
+		return cloneDestination(entry.destination), nil
+	}
+	dest, err := r.DestinationRepository.FindBySlug(ctx, slug)
+	if err != nil || dest == nil {
+		return dest, err
+	}
+	r.put(&cacheEntry{key: key, destinationID: dest.ID, destination: cloneDestination(dest)}, generation)
+	return dest, nil
+}
+
+// ListPublished serves a cached listing only while the catalogue version it
+// was loaded at is still current. Listing ETags are built from that version,
+// so a page not yet invalidated after a commit, a review or a lagging NOTIFY
+// is never served under a newer tag.
+func (r *DestinationCacheRepository) ListPublished(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
+	key, ok := r.listKey(limit, offset, filter)
+	if !ok {
+		return r.DestinationRepository.ListPublished(ctx, limit, offset, filter)
+	}
+	catalog, err := r.DestinationRepository.CatalogVersion(ctx)
+	if err != nil {
+		return r.DestinationRepository.ListPublished(ctx, limit, offset, filter)
+	}
+	entry, generation := r.get(key, func(entry *cacheEntry) bool { return entry.catalog != catalog })
+	if entry != nil {
+		return cloneDestinations(entry.destinations), nil
+	}
+	destinations, err := r.DestinationRepository.ListPublished(ctx, limit, offset, filter)
+	if err != nil {
+		return nil, err
+	}
+	r.put(&cacheEntry{key: key, destinations: cloneDestinations(destinations), catalog: catalog}, generation)
+	return destinations, nil
+}
+
+// Invalidate drops the destination's entries and every listing here, then
+// tells the other replicas to do the same.
+func (r *DestinationCacheRepository) Invalidate(ctx context.Context, id uuid.UUID) error {
+	r.evict(id)
+	if r.bus == nil {
+		return nil
+	}
+	return r.bus.Publish(ctx, id)
+}
+
+func (r *DestinationCacheRepository) Stats() domain.DestinationCacheStats {
+	r.mu.Lock()
+	entries := len(r.entries)
+	r.mu.Unlock()
+	return domain.DestinationCacheStats{
+		Hits:          r.hits.Load(),
+		Misses:        r.misses.Load(),
+		Evictions:     r.evictions.Load(),
+		Invalidations: r.invalidations.Load(),
+		Entries:       entries,
+		MaxEntries:    r.maxSize,
+	}
+}
+
+// listKey identifies a cacheable listing request. Cursor pages, pages past
+// the list depth and opening-hours filters, which depend on the clock, are
+// not cached.
+func (r *DestinationCacheRepository) listKey(limit, offset int, filter domain.DestinationListFilter) (string, bool) {
+	if filter.After != nil || filter.OpenAt != nil || limit <= 0 || offset+limit > r.listDepth {
+		return "", false
+	}
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("list:%d:%d:%s", limit, offset, encoded), true
+}
+
+// get returns the live entry for key, or nil and the generation to store the
+// loaded value under. Entries stale reports true for are dropped as misses.
+func (r *DestinationCacheRepository) get(key string, stale func(*cacheEntry) bool) (*cacheEntry, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	element, ok := r.entries[key]
+	if !ok {
+		r.misses.Add(1)
+		return nil, r.generation
+	}
+	entry := element.Value.(*cacheEntry)
+	if r.now().After(entry.expires) {
+		r.remove(element)
+		r.evictions.Add(1)
+		r.misses.Add(1)
+		return nil, r.generation
+	}
+	if stale != nil && stale(entry) {
+		r.remove(element)
+		r.misses.Add(1)
+		return nil, r.generation
+	}
+	r.order.MoveToFront(element)
+	r.hits.Add(1)
+	return entry, r.generation
+}
+
+func (r *DestinationCacheRepository) put(entry *cacheEntry, generation uint64) {
+	entry.expires = r.now().Add(r.ttl)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if generation != r.generation {
+		return
+	}
+	if element, ok := r.entries[entry.key]; ok {
+		element.Value = entry
+		r.order.MoveToFront(element)
+		return
+	}
+	r.entries[entry.key] = r.order.PushFront(entry)
+	for r.order.Len() > r.maxSize {
+		r.remove(r.order.Back())
+		r.evictions.Add(1)
+	}
+}
+
+// evict drops the entries of destination id and all listings, or everything
+// for uuid.Nil.
+func (r *DestinationCacheRepository) evict(id uuid.UUID) {
+	r.invalidations.Add(1)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	if id == uuid.Nil {
+		r.order.Init()
+		r.entries = make(map[string]*list.Element)
+		return
+	}
+	for element := r.order.Front(); element != nil; {
+		next := element.Next()
+		entry := element.Value.(*cacheEntry)
+		if entry.destination == nil || entry.destinationID == id {
+			r.remove(element)
+		}
+		element = next
+	}
+}
+
+func (r *DestinationCacheRepository) remove(element *list.Element) {
+	r.order.Remove(element)
+	delete(r.entries, element.Value.(*cacheEntry).key)
+}
+
+// cloneDestination copies dest so callers never share a cached value. The
+// slices and maps inside are read-only by convention, as for any repository
+// result.
+func cloneDestination(dest *domain.Destination) *domain.Destination {
+	clone := *dest
+	return &clone
+}
+
+func cloneDestinations(destinations []domain.Destination) []domain.Destination {
+	if destinations == nil {
+		return nil
+	}
+	return append(make([]domain.Destination, 0, len(destinations)), destinations...)
+}
+
+var (
+	_ ports.DestinationRepository = (*DestinationCacheRepository)(nil)
+	_ ports.DestinationCache      = (*DestinationCacheRepository)(nil)
+)
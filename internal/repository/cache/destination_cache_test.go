@@ -0,0 +1,220 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+// countingRepo serves fixed destinations and counts the reads that reach it.
+type countingRepo struct {
+	ports.DestinationRepository
+	mu      sync.Mutex
+	byID    map[uuid.UUID]domain.Destination
+	reads   int
+	onRead  func()
+	catalog domain.DestinationCatalogVersion
+}
+
+func (r *countingRepo) CatalogVersion(context.Context) (domain.DestinationCatalogVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.catalog, nil
+}
+
+func (r *countingRepo) FindPublishedByID(_ context.Context, id uuid.UUID) (*domain.Destination, error) {
+	r.mu.Lock()
+	r.reads++
+	dest, ok := r.byID[id]
+	onRead := r.onRead
+	r.mu.Unlock()
+	if onRead != nil {
+		onRead()
+	}
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &dest, nil
+}
+
+func (r *countingRepo) ListPublished(context.Context, int, int, domain.DestinationListFilter) ([]domain.Destination, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	out := make([]domain.Destination, 0, len(r.byID))
+	for _, dest := range r.byID {
+		out = append(out, dest)
+	}
+	return out, nil
+}
+
+func (r *countingRepo) rename(id uuid.UUID, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dest := r.byID[id]
+	dest.Name = name
+	r.byID[id] = dest
+	r.catalog.Destinations++
+}
+
+// localBus delivers every publish to all listeners synchronously, like
+// NOTIFY reaching each replica.
+type localBus struct {
+	mu        sync.Mutex
+	listeners []func(uuid.UUID)
+}
+
+func (b *localBus) Publish(_ context.Context, id uuid.UUID) error {
+	b.mu.Lock()
+	listeners := append([]func(uuid.UUID){}, b.listeners...)
+	b.mu.Unlock()
+	for _, fn := range listeners {
+		fn(id)
+	}
+	return nil
+}
+
+func (b *localBus) Listen(_ context.Context, fn func(uuid.UUID)) error {
+	b.mu.Lock()
+	b.listeners = append(b.listeners, fn)
+	b.mu.Unlock()
+	return nil
+}
+
+func newCountingRepo(names ...string) (*countingRepo, []uuid.UUID) {
+	repo := &countingRepo{byID: make(map[uuid.UUID]domain.Destination)}
+	ids := make([]uuid.UUID, 0, len(names))
+	for _, name := range names {
+		id := uuid.New()
+		repo.byID[id] = domain.Destination{ID: id, Name: name}
+		ids = append(ids, id)
+	}
+	return repo, ids
+}
+
+func TestDestinationCacheRepository_BoundsAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	inner, ids := newCountingRepo("Wat Pho", "Wat Arun", "Lumphini Park")
+	cached := NewDestinationCacheRepo(inner, nil, DestinationCacheConfig{MaxEntries: 2, TTL: time.Minute, ListDepth: 20})
+	now := time.Date(2024, 7, 23, 9, 0, 0, 0, time.UTC)
+	cached.now = func() time.Time { return now }
+
+	for _, id := range ids {
+		if _, err := cached.FindPublishedByID(ctx, id); err != nil {
+			t.Fatalf("FindPublishedByID: %v", err)
+		}
+	}
+	if _, err := cached.FindPublishedByID(ctx, ids[0]); err != nil {
+		t.Fatalf("FindPublishedByID: %v", err)
+	}
+	stats := cached.Stats()
+	if stats.Entries != 2 || stats.Evictions != 2 || stats.Hits != 0 || inner.reads != 4 {
+		t.Fatalf("expected the least recently used entries evicted, got %+v after %d reads", stats, inner.reads)
+	}
+
+	dest, _ := cached.FindPublishedByID(ctx, ids[0])
+	dest.Name = "changed by caller"
+	if again, _ := cached.FindPublishedByID(ctx, ids[0]); again.Name != "Wat Pho" {
+		t.Fatalf("expected callers not to share the cached value, got %q", again.Name)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := cached.FindPublishedByID(ctx, ids[0]); err != nil {
+		t.Fatalf("FindPublishedByID: %v", err)
+	}
+	if inner.reads != 5 {
+		t.Fatalf("expected an expired entry to be reloaded, got %d reads", inner.reads)
+	}
+
+	if _, err := cached.ListPublished(ctx, 10, 20, domain.DestinationListFilter{}); err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if _, err := cached.ListPublished(ctx, 10, 20, domain.DestinationListFilter{}); err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if inner.reads != 7 {
+		t.Fatalf("expected pages past the list depth to bypass the cache, got %d reads", inner.reads)
+	}
+}
+
+func TestDestinationCacheRepository_InvalidatesAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	inner, ids := newCountingRepo("Wat Pho", "Wat Arun")
+	bus := &localBus{}
+	first := NewDestinationCacheRepo(inner, bus, DestinationCacheConfig{})
+	second := NewDestinationCacheRepo(inner, bus, DestinationCacheConfig{})
+	for _, replica := range []*DestinationCacheRepository{first, second} {
+		if err := replica.Listen(ctx); err != nil {
+			t.Fatalf("Listen: %v", err)
+		}
+		for _, id := range ids {
+			if _, err := replica.FindPublishedByID(ctx, id); err != nil {
+				t.Fatalf("FindPublishedByID: %v", err)
+			}
+		}
+		if _, err := replica.ListPublished(ctx, 10, 0, domain.DestinationListFilter{}); err != nil {
+			t.Fatalf("ListPublished: %v", err)
+		}
+	}
+
+	inner.rename(ids[0], "Wat Pho Temple")
+	if err := first.Invalidate(ctx, ids[0]); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	if got, _ := second.FindPublishedByID(ctx, ids[0]); got.Name != "Wat Pho Temple" {
+		t.Fatalf("expected the other replica to drop the entry, got %q", got.Name)
+	}
+	if stats := second.Stats(); stats.Entries != 2 || stats.Invalidations != 1 {
+		t.Fatalf("expected the other destination kept and the listing dropped, got %+v", stats)
+	}
+
+	// A read that loaded rows before an invalidation must not store them.
+	inner.rename(ids[1], "Wat Arun Temple")
+	first.Invalidate(ctx, uuid.Nil)
+	inner.onRead = func() {
+		inner.onRead = nil
+		first.evict(ids[1])
+	}
+	if _, err := first.FindPublishedByID(ctx, ids[1]); err != nil {
+		t.Fatalf("FindPublishedByID: %v", err)
+	}
+	if stats := first.Stats(); stats.Entries != 0 {
+		t.Fatalf("expected the racing read not to be cached, got %+v", stats)
+	}
+}
+
+func TestDestinationCacheRepository_ListingFollowsCatalogVersion(t *testing.T) {
+	ctx := context.Background()
+	inner, ids := newCountingRepo("Wat Pho")
+	cached := NewDestinationCacheRepo(inner, nil, DestinationCacheConfig{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := cached.ListPublished(ctx, 10, 0, domain.DestinationListFilter{}); err != nil {
+			t.Fatalf("ListPublished: %v", err)
+		}
+	}
+	if inner.reads != 1 {
+		t.Fatalf("expected the second listing served from the cache, got %d reads", inner.reads)
+	}
+
+	// A committed change the cache has not been told about yet, such as a
+	// review or a NOTIFY still on its way, moves the catalogue version.
+	inner.rename(ids[0], "Wat Pho Temple")
+	got, err := cached.ListPublished(ctx, 10, 0, domain.DestinationListFilter{})
+	if err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "Wat Pho Temple" || inner.reads != 2 {
+		t.Fatalf("expected the outdated listing reloaded, got %+v after %d reads", got, inner.reads)
+	}
+	if stats := cached.Stats(); stats.Hits != 1 || stats.Misses != 2 || stats.Entries != 1 {
+		t.Fatalf("expected the outdated listing counted as a miss, got %+v", stats)
+	}
+}
@@ -0,0 +1,431 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// searchFields are the text fields a query runs against, with name weighted
+// over city and country over category over description, as in Postgres.
+var searchFields = []string{"name^4", "translated_names^3", "city^2", "country^2", "category^1.5", "description", "translated_descriptions"}
+
+// destinationDocument is what gets indexed: flattened fields to search, filter
+// and sort on, plus the destination itself, stored but not indexed, to build
+// results from.
+type destinationDocument struct {
+	ID                     string             `json:"id"`
+	Name                   string             `json:"name"`
+	TranslatedNames        []string           `json:"translated_names,omitempty"`
+	City                   string             `json:"city,omitempty"`
+	Country                string             `json:"country,omitempty"`
+	Category               string             `json:"category,omitempty"`
+	Description            string             `json:"description,omitempty"`
+	TranslatedDescriptions []string           `json:"translated_descriptions,omitempty"`
+	Tags                   []string           `json:"tags,omitempty"`
+	Location               *geoPoint          `json:"location,omitempty"`
+	AverageRating          float64            `json:"average_rating"`
+	ReviewCount            int                `json:"review_count"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+	Destination            domain.Destination `json:"destination"`
+}
+
+type geoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+func newDestinationDocument(dest domain.Destination) destinationDocument {
+	doc := destinationDocument{
+		ID:            dest.ID.String(),
+		Name:          dest.Name,
+		City:          valueOf(dest.City),
+		Country:       valueOf(dest.Country),
+		Category:      valueOf(dest.Category),
+		Description:   valueOf(dest.Description),
+		Tags:          dest.Tags,
+		AverageRating: dest.AverageRating,
+		ReviewCount:   dest.ReviewCount,
+		UpdatedAt:     dest.UpdatedAt,
+		Destination:   dest,
+	}
+	for _, translation := range dest.Translations {
+		if translation.Name != nil && strings.TrimSpace(*translation.Name) != "" {
+			doc.TranslatedNames = append(doc.TranslatedNames, *translation.Name)
+		}
+		if translation.Description != nil && strings.TrimSpace(*translation.Description) != "" {
+			doc.TranslatedDescriptions = append(doc.TranslatedDescriptions, *translation.Description)
+		}
+	}
+	if dest.Latitude != nil && dest.Longitude != nil {
+		doc.Location = &geoPoint{Lat: *dest.Latitude, Lon: *dest.Longitude}
+	}
+	return doc
+}
+
+// indexSettings is the body that creates a destination index. Text is folded
+// to lowercase ASCII; synonyms expand at search time only, so changing them
+// never requires reindexing documents, just a rebuild to load the new list.
+func indexSettings(synonyms []string) map[string]any {
+	searchFilters := []string{"lowercase", "asciifolding"}
+	filters := map[string]any{}
+	if len(synonyms) > 0 {
+		filters["destination_synonyms"] = map[string]any{"type": "synonym_graph", "synonyms": synonyms, "lenient": true}
+		searchFilters = append(searchFilters, "destination_synonyms")
+	}
+	text := func(extra map[string]any) map[string]any {
+		field := map[string]any{"type": "text", "analyzer": "destination_text", "search_analyzer": "destination_search"}
+		for key, value := range extra {
+			field[key] = value
+		}
+		return field
+	}
+	keyword := map[string]any{"keyword": map[string]any{"type": "keyword", "normalizer": "destination_keyword"}}
+	return map[string]any{
+		"settings": map[string]any{
+			"analysis": map[string]any{
+				"filter": filters,
+				"analyzer": map[string]any{
+					"destination_text":   map[string]any{"tokenizer": "standard", "filter": []string{"lowercase", "asciifolding"}},
+					"destination_search": map[string]any{"tokenizer": "standard", "filter": searchFilters},
+				},
+				"normalizer": map[string]any{
+					"destination_keyword": map[string]any{"type": "custom", "filter": []string{"lowercase", "asciifolding"}},
+				},
+			},
+		},
+		"mappings": map[string]any{
+			"dynamic": false,
+			"properties": map[string]any{
+				"id": map[string]any{"type": "keyword"},
+				"name": text(map[string]any{"fields": map[string]any{
+					"raw":     map[string]any{"type": "keyword"},
+					"suggest": map[string]any{"type": "search_as_you_type", "analyzer": "destination_text"},
+				}}),
+				"translated_names":        text(map[string]any{"fields": map[string]any{"suggest": map[string]any{"type": "search_as_you_type", "analyzer": "destination_text"}}}),
+				"city":                    text(map[string]any{"fields": keyword}),
+				"country":                 text(map[string]any{"fields": keyword}),
+				"category":                text(map[string]any{"fields": keyword}),
+				"description":             text(nil),
+				"translated_descriptions": text(nil),
+				"tags":                    map[string]any{"type": "keyword"},
+				"location":                map[string]any{"type": "geo_point"},
+				"average_rating":          map[string]any{"type": "double"},
+				"review_count":            map[string]any{"type": "integer"},
+				"updated_at":              map[string]any{"type": "date"},
+				"destination":             map[string]any{"type": "object", "enabled": false},
+			},
+		},
+	}
+}
+
+// searchBody translates a listing filter into a search request. Every
+// search term must match; single words match fuzzily, phrases in order, and
+// prefixes as phrase prefixes.
+func searchBody(filter domain.DestinationListFilter, limit, offset int) map[string]any {
+	must := make([]any, 0, len(filter.SearchTerms)+1)
+	for _, term := range filter.SearchTerms {
+		query := strings.Join(term.Words, " ")
+		match := map[string]any{"query": query, "fields": searchFields}
+		switch {
+		case term.Prefix:
+			match["type"] = "phrase_prefix"
+		case len(term.Words) > 1:
+			match["type"] = "phrase"
+		default:
+			match["fuzziness"] = "AUTO"
+		}
+		must = append(must, map[string]any{"multi_match": match})
+	}
+	if len(must) == 0 {
+		must = append(must, map[string]any{"multi_match": map[string]any{
+			"query": strings.TrimSpace(filter.Search), "fields": searchFields, "fuzziness": "AUTO", "operator": "and",
+		}})
+	}
+
+	filters := make([]any, 0, 8)
+	if len(filter.Categories) > 0 {
+		categories := make([]string, 0, len(filter.Categories))
+		for _, category := range filter.Categories {
+			if trimmed := strings.TrimSpace(category); trimmed != "" {
+				categories = append(categories, strings.ToLower(trimmed))
+			}
+		}
+		filters = append(filters, map[string]any{"terms": map[string]any{"category.keyword": categories}})
+	}
+	if len(filter.Tags) > 0 {
+		if filter.TagMatch == domain.DestinationTagMatchAll {
+			for _, tag := range filter.Tags {
+				filters = append(filters, map[string]any{"term": map[string]any{"tags": tag}})
+			}
+		} else {
+			filters = append(filters, map[string]any{"terms": map[string]any{"tags": filter.Tags}})
+		}
+	}
+	for field, value := range map[string]*string{"city.keyword": filter.City, "country.keyword": filter.Country} {
+		if value != nil && strings.TrimSpace(*value) != "" {
+			filters = append(filters, map[string]any{"wildcard": map[string]any{field: map[string]any{
+				"value": "*" + escapeWildcard(strings.ToLower(strings.TrimSpace(*value))) + "*",
+			}}})
+		}
+	}
+	if filter.MinRating != nil || filter.MaxRating != nil {
+		bounds := map[string]any{}
+		if filter.MinRating != nil {
+			bounds["gte"] = *filter.MinRating
+		}
+		if filter.MaxRating != nil {
+			bounds["lte"] = *filter.MaxRating
+		}
+		filters = append(filters, map[string]any{"range": map[string]any{"average_rating": bounds}})
+	}
+	origin := originOf(filter)
+	if origin != nil && filter.MaxDistanceKM != nil {
+		filters = append(filters, map[string]any{"geo_distance": map[string]any{
+			"distance": fmt.Sprintf("%gkm", *filter.MaxDistanceKM),
+			"location": origin,
+		}})
+	}
+	if box := filter.BBox; box != nil {
+		// A left edge east of the right edge crosses the antimeridian, which
+		// geo_bounding_box handles itself.
+		filters = append(filters, map[string]any{"geo_bounding_box": map[string]any{"location": map[string]any{
+			"top_left":     geoPoint{Lat: box.MaxLat, Lon: box.MinLng},
+			"bottom_right": geoPoint{Lat: box.MinLat, Lon: box.MaxLng},
+		}}})
+	}
+	if len(filter.IDs) > 0 {
+		ids := make([]string, 0, len(filter.IDs))
+		for _, id := range filter.IDs {
+			ids = append(ids, id.String())
+		}
+		filters = append(filters, map[string]any{"terms": map[string]any{"id": ids}})
+	}
+
+	keys := sortKeys(filter, origin)
+	body := map[string]any{
+		"size":  limit,
+		"query": map[string]any{"bool": map[string]any{"must": must, "filter": filters}},
+		"sort":  keys.clauses,
+		"highlight": map[string]any{
+			"pre_tags":  []string{domain.SearchHighlightStart},
+			"post_tags": []string{domain.SearchHighlightStop},
+			"fields": map[string]any{
+				"description": map[string]any{"fragment_size": 160, "number_of_fragments": 2},
+			},
+		},
+	}
+	if filter.After != nil {
+		body["search_after"] = keys.after(filter.After)
+	} else {
+		body["from"] = offset
+		body["track_total_hits"] = true
+	}
+	return body
+}
+
+// sortOrder holds the sort clauses for a listing and how to read a cursor's
+// position in them for search_after. Like Postgres, every order ends in the
+// id so it is total.
+type sortOrder struct {
+	clauses []any
+	after   func(cursor *domain.DestinationListCursor) []any
+	// distance marks orders whose first sort value is the distance in km.
+	distance bool
+}
+
+func sortKeys(filter domain.DestinationListFilter, origin *geoPoint) sortOrder {
+	byName := func(direction string) []any {
+		return []any{
+			map[string]any{"name.raw": direction},
+			map[string]any{"id": direction},
+		}
+	}
+	nameAndID := func(c *domain.DestinationListCursor) []any { return []any{c.Name, c.ID.String()} }
+	switch filter.Sort {
+	case domain.DestinationSortRatingAsc, domain.DestinationSortRatingDesc:
+		direction := "desc"
+		if filter.Sort == domain.DestinationSortRatingAsc {
+			direction = "asc"
+		}
+		return sortOrder{
+			clauses: append([]any{map[string]any{"average_rating": direction}}, byName("asc")...),
+			after:   func(c *domain.DestinationListCursor) []any { return append([]any{c.Rating}, nameAndID(c)...) },
+		}
+	case domain.DestinationSortNameAsc:
+		return sortOrder{clauses: byName("asc"), after: nameAndID}
+	case domain.DestinationSortNameDesc:
+		return sortOrder{clauses: byName("desc"), after: nameAndID}
+	case domain.DestinationSortSimilarity, domain.DestinationSortRelevance:
+		return sortOrder{
+			clauses: append([]any{"_score"}, byName("asc")...),
+			after: func(c *domain.DestinationListCursor) []any {
+				score := 0.0
+				if c.Score != nil {
+					score = *c.Score
+				}
+				return append([]any{score}, nameAndID(c)...)
+			},
+		}
+	case domain.DestinationSortDistanceAsc:
+		if origin != nil {
+			return sortOrder{
+				clauses: append([]any{map[string]any{"_geo_distance": map[string]any{
+					"location": origin, "order": "asc", "unit": "km", "ignore_unmapped": true,
+				}}}, byName("asc")...),
+				after: func(c *domain.DestinationListCursor) []any {
+					// Destinations without coordinates sort last at infinity.
+					var distance any = "Infinity"
+					if c.Distance != nil {
+						distance = *c.Distance
+					}
+					return append([]any{distance}, nameAndID(c)...)
+				},
+				distance: true,
+			}
+		}
+	}
+	return sortOrder{
+		clauses: append([]any{map[string]any{"updated_at": "desc"}}, byName("asc")...),
+		after: func(c *domain.DestinationListCursor) []any {
+			return append([]any{c.UpdatedAt.UnixMilli()}, nameAndID(c)...)
+		},
+	}
+}
+
+// autocompleteBody matches names and translated names as the user types,
+// tolerating typos in the words already completed.
+func autocompleteBody(query string, limit int) map[string]any {
+	return map[string]any{
+		"size":    limit,
+		"_source": []string{"name", "translated_names"},
+		"query": map[string]any{"multi_match": map[string]any{
+			"query":     query,
+			"type":      "bool_prefix",
+			"fuzziness": "AUTO",
+			"fields": []string{
+				"name.suggest^2", "name.suggest._2gram^2", "name.suggest._3gram^2",
+				"translated_names.suggest", "translated_names.suggest._2gram", "translated_names.suggest._3gram",
+			},
+		}},
+	}
+}
+
+type searchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
+		Hits []searchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+type searchHit struct {
+	Score     *float64            `json:"_score"`
+	Source    destinationDocument `json:"_source"`
+	Sort      []json.RawMessage   `json:"sort"`
+	Highlight map[string][]string `json:"highlight"`
+}
+
+// destinations rebuilds the listing rows from the hits, filling in the same
+// computed fields Postgres returns.
+func (r searchResponse) destinations(filter domain.DestinationListFilter) []domain.Destination {
+	origin := originOf(filter)
+	order := sortKeys(filter, origin)
+	out := make([]domain.Destination, 0, len(r.Hits.Hits))
+	for _, hit := range r.Hits.Hits {
+		dest := hit.Source.Destination
+		if id, err := uuid.Parse(hit.Source.ID); err == nil {
+			dest.ID = id
+		}
+		dest.AverageRating = hit.Source.AverageRating
+		dest.ReviewCount = hit.Source.ReviewCount
+		dest.TotalCount = r.Hits.Total.Value
+		if filter.Sort == domain.DestinationSortSimilarity || filter.Sort == domain.DestinationSortRelevance {
+			if len(hit.Sort) > 0 {
+				var score float64
+				if err := json.Unmarshal(hit.Sort[0], &score); err == nil {
+					dest.SearchScore = &score
+				}
+			} else {
+				dest.SearchScore = hit.Score
+			}
+		}
+		if origin != nil && dest.Latitude != nil && dest.Longitude != nil {
+			distance := haversineKM(origin.Lat, origin.Lon, *dest.Latitude, *dest.Longitude)
+			if order.distance && len(hit.Sort) > 0 {
+				// Keep the engine's own value so search_after resumes exactly.
+				_ = json.Unmarshal(hit.Sort[0], &distance)
+			}
+			dest.DistanceKM = &distance
+		}
+		if fragments := hit.Highlight["description"]; len(fragments) > 0 {
+			headline := strings.Join(fragments, " … ")
+			dest.SearchHeadline = &headline
+		}
+		out = append(out, dest)
+	}
+	return out
+}
+
+// suggestions lists the matched names, preferring the translated name that
+// starts like the query, without duplicates.
+func (r searchResponse) suggestions(query string, limit int) []string {
+	needle := strings.ToLower(strings.Fields(query)[0])
+	seen := make(map[string]struct{})
+	names := make([]string, 0, limit)
+	for _, hit := range r.Hits.Hits {
+		name := hit.Source.Name
+		if !strings.Contains(strings.ToLower(name), needle) {
+			for _, translated := range hit.Source.TranslatedNames {
+				if strings.Contains(strings.ToLower(translated), needle) {
+					name = translated
+					break
+				}
+			}
+		}
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok || name == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		names = append(names, name)
+		if len(names) == limit {
+			break
+		}
+	}
+	return names
+}
+
+func originOf(filter domain.DestinationListFilter) *geoPoint {
+	if filter.Latitude == nil || filter.Longitude == nil {
+		return nil
+	}
+	return &geoPoint{Lat: *filter.Latitude, Lon: *filter.Longitude}
+}
+
+func escapeWildcard(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`).Replace(value)
+}
+
+func haversineKM(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKM = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(a))
+}
+
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
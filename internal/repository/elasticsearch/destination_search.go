@@ -0,0 +1,289 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	elasticsearch "github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+const rebuildBatchSize = 500
+
+type DestinationSearchConfig struct {
+	// Index is the alias searches and updates go through. Rebuild creates a
+	// timestamped index behind it.
+	Index string
+	// Synonyms are comma-separated groups of equivalent words, e.g.
+	// "wat, temple". They are applied when a search runs; Rebuild picks up
+	// changes.
+	Synonyms       []string
+	RequestTimeout time.Duration
+}
+
+// DestinationSearchRepository serves text searches over published
+// destinations from Elasticsearch and leaves everything else, including
+// listings without a query, to the wrapped repository. Searches fall back to
+// the wrapped repository when Elasticsearch fails or a filter it cannot
+// evaluate (opening hours) is set.
+type DestinationSearchRepository struct {
+	ports.DestinationRepository
+	es       *elasticsearch.Client
+	index    string
+	synonyms []string
+	timeout  time.Duration
+}
+
+func NewDestinationSearchRepo(fallback ports.DestinationRepository, es *elasticsearch.Client, cfg DestinationSearchConfig) *DestinationSearchRepository {
+	timeout := cfg.RequestTimeout
+	if timeout <= 0 {
+		timeout = 3 * time.Second
+	}
+	return &DestinationSearchRepository{
+		DestinationRepository: fallback,
+		es:                    es,
+		index:                 cfg.Index,
+		synonyms:              cfg.Synonyms,
+		timeout:               timeout,
+	}
+}
+
+func (r *DestinationSearchRepository) ListPublished(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
+	if strings.TrimSpace(filter.Search) == "" || filter.OpenAt != nil {
+		return r.DestinationRepository.ListPublished(ctx, limit, offset, filter)
+	}
+	destinations, err := r.search(ctx, limit, offset, filter)
+	if err != nil {
+		log.Printf("destination search: elasticsearch failed, using postgres: %v", err)
+		return r.DestinationRepository.ListPublished(ctx, limit, offset, filter)
+	}
+	return destinations, nil
+}
+
+func (r *DestinationSearchRepository) Autocomplete(ctx context.Context, query string, limit int) ([]string, error) {
+	names, err := r.autocomplete(ctx, query, limit)
+	if err != nil {
+		log.Printf("destination autocomplete: elasticsearch failed, using postgres: %v", err)
+		return r.DestinationRepository.Autocomplete(ctx, query, limit)
+	}
+	return names, nil
+}
+
+// Reindex loads the destination through the wrapped repository, so the
+// document carries its current rating, and writes or removes it.
+func (r *DestinationSearchRepository) Reindex(ctx context.Context, id uuid.UUID) error {
+	found, err := r.DestinationRepository.ListPublished(ctx, 1, 0, domain.DestinationListFilter{IDs: []uuid.UUID{id}})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	if len(found) == 0 {
+		err := r.do(r.es.Delete(r.index, id.String(), r.es.Delete.WithContext(ctx)))
+		if errors.Is(err, errNotFound) {
+			return nil
+		}
+		return err
+	}
+	body, err := json.Marshal(newDestinationDocument(found[0]))
+	if err != nil {
+		return err
+	}
+	return r.do(r.es.Index(r.index, bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(id.String()),
+		r.es.Index.WithRequireAlias(true),
+	))
+}
+
+// Rebuild indexes every published destination into a new index, points the
+// alias at it and drops the indexes it replaced. Searches keep using the old
+// index until the switch.
+func (r *DestinationSearchRepository) Rebuild(ctx context.Context) (int, error) {
+	name := fmt.Sprintf("%s-%s", r.index, time.Now().UTC().Format("20060102150405"))
+	settings, err := json.Marshal(indexSettings(r.synonyms))
+	if err != nil {
+		return 0, err
+	}
+	if err := r.do(r.es.Indices.Create(name, r.es.Indices.Create.WithContext(ctx), r.es.Indices.Create.WithBody(bytes.NewReader(settings)))); err != nil {
+		return 0, fmt.Errorf("create index %s: %w", name, err)
+	}
+	switched := false
+	defer func() {
+		if !switched {
+			// Leave no half-built index behind when the rebuild fails.
+			if err := r.do(r.es.Indices.Delete([]string{name})); err != nil {
+				log.Printf("destination search: delete unfinished index %s: %v", name, err)
+			}
+		}
+	}()
+
+	indexed := 0
+	filter := domain.DestinationListFilter{Sort: domain.DestinationSortUpdatedAtDesc}
+	for {
+		batch, err := r.DestinationRepository.ListPublished(ctx, rebuildBatchSize, 0, filter)
+		if err != nil {
+			return indexed, err
+		}
+		if len(batch) > 0 {
+			if err := r.bulkIndex(ctx, name, batch); err != nil {
+				return indexed, err
+			}
+			indexed += len(batch)
+		}
+		if len(batch) < rebuildBatchSize {
+			break
+		}
+		after := domain.CursorAfter(filter.Sort, batch[len(batch)-1])
+		filter.After = &after
+	}
+
+	var current map[string]json.RawMessage
+	res, err := r.es.Indices.GetAlias(r.es.Indices.GetAlias.WithContext(ctx), r.es.Indices.GetAlias.WithName(r.index))
+	if err := decode(res, err, &current); err != nil && !errors.Is(err, errNotFound) {
+		return indexed, err
+	}
+	actions := []any{map[string]any{"add": map[string]any{"index": name, "alias": r.index}}}
+	previous := make([]string, 0, len(current))
+	for old := range current {
+		actions = append(actions, map[string]any{"remove": map[string]any{"index": old, "alias": r.index}})
+		previous = append(previous, old)
+	}
+	swap, err := json.Marshal(map[string]any{"actions": actions})
+	if err != nil {
+		return indexed, err
+	}
+	if err := r.do(r.es.Indices.UpdateAliases(bytes.NewReader(swap), r.es.Indices.UpdateAliases.WithContext(ctx))); err != nil {
+		return indexed, fmt.Errorf("switch alias %s: %w", r.index, err)
+	}
+	switched = true
+	if len(previous) > 0 {
+		if err := r.do(r.es.Indices.Delete(previous, r.es.Indices.Delete.WithContext(ctx))); err != nil {
+			log.Printf("destination search: delete replaced indexes %v: %v", previous, err)
+		}
+	}
+	return indexed, nil
+}
+
+func (r *DestinationSearchRepository) bulkIndex(ctx context.Context, index string, batch []domain.Destination) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, dest := range batch {
+		if err := encoder.Encode(map[string]any{"index": map[string]any{"_index": index, "_id": dest.ID.String()}}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(newDestinationDocument(dest)); err != nil {
+			return err
+		}
+	}
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID    string          `json:"_id"`
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	res, err := r.es.Bulk(&body, r.es.Bulk.WithContext(ctx))
+	if err := decode(res, err, &result); err != nil {
+		return err
+	}
+	if result.Errors {
+		for _, item := range result.Items {
+			for _, op := range item {
+				if len(op.Error) > 0 {
+					return fmt.Errorf("index destination %s: %s", op.ID, op.Error)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *DestinationSearchRepository) search(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
+	body, err := json.Marshal(searchBody(filter, limit, offset))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.index),
+		r.es.Search.WithBody(bytes.NewReader(body)),
+	)
+	var result searchResponse
+	if err := decode(res, err, &result); err != nil {
+		return nil, err
+	}
+	return result.destinations(filter), nil
+}
+
+func (r *DestinationSearchRepository) autocomplete(ctx context.Context, query string, limit int) ([]string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []string{}, nil
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	body, err := json.Marshal(autocompleteBody(query, limit))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.index),
+		r.es.Search.WithBody(bytes.NewReader(body)),
+	)
+	var result searchResponse
+	if err := decode(res, err, &result); err != nil {
+		return nil, err
+	}
+	return result.suggestions(query, limit), nil
+}
+
+var errNotFound = errors.New("not found")
+
+func (r *DestinationSearchRepository) do(res *esapi.Response, err error) error {
+	return decode(res, err, nil)
+}
+
+// decode closes the response and decodes its body into out, if not nil. A
+// 404 is reported as errNotFound.
+func decode(res *esapi.Response, err error, out any) error {
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return errNotFound
+	}
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch: %s", res.String())
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+var (
+	_ ports.DestinationRepository  = (*DestinationSearchRepository)(nil)
+	_ ports.DestinationSearchIndex = (*DestinationSearchRepository)(nil)
+)
@@ -0,0 +1,218 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	elasticsearch "github.com/elastic/go-elasticsearch/v8"
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+// stubCluster answers Elasticsearch requests from a handler and records them.
+type stubCluster struct {
+	mu       sync.Mutex
+	requests []stubRequest
+	respond  func(r stubRequest) (int, any)
+}
+
+type stubRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+func (s *stubCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	raw, _ := io.ReadAll(r.Body)
+	req := stubRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+	_ = json.Unmarshal(raw, &req.Body)
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	status, body := s.respond(req)
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *stubCluster) recorded() []stubRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]stubRequest(nil), s.requests...)
+}
+
+// fallbackRepo is the Postgres stand-in; only the methods the search
+// repository delegates to are implemented.
+type fallbackRepo struct {
+	ports.DestinationRepository
+	published []domain.Destination
+	calls     int
+}
+
+func (f *fallbackRepo) ListPublished(_ context.Context, limit, _ int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
+	f.calls++
+	out := make([]domain.Destination, 0, len(f.published))
+	for _, dest := range f.published {
+		if len(filter.IDs) > 0 && dest.ID != filter.IDs[0] {
+			continue
+		}
+		out = append(out, dest)
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (f *fallbackRepo) Autocomplete(context.Context, string, int) ([]string, error) {
+	f.calls++
+	return []string{"from postgres"}, nil
+}
+
+func newStubRepo(t *testing.T, fallback *fallbackRepo, respond func(stubRequest) (int, any)) (*DestinationSearchRepository, *stubCluster) {
+	t.Helper()
+	cluster := &stubCluster{respond: respond}
+	server := httptest.NewServer(cluster)
+	t.Cleanup(server.Close)
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}, DisableRetry: true})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	repo := NewDestinationSearchRepo(fallback, client, DestinationSearchConfig{Index: "destinations", RequestTimeout: time.Second})
+	return repo, cluster
+}
+
+func TestDestinationSearchRepository_ListPublished(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	lat, lng := 13.7563, 100.5018
+	city := "Bangkok"
+	hit := map[string]any{
+		"_score": 7.5,
+		"_source": newDestinationDocument(domain.Destination{
+			ID: id, Name: "Wat Arun", City: &city, Latitude: &lat, Longitude: &lng, AverageRating: 4.5, ReviewCount: 12,
+		}),
+		"sort":      []any{7.5, "Wat Arun", id.String()},
+		"highlight": map[string]any{"description": []string{"a " + domain.SearchHighlightStart + "temple" + domain.SearchHighlightStop}},
+	}
+	repo, cluster := newStubRepo(t, &fallbackRepo{}, func(stubRequest) (int, any) {
+		return http.StatusOK, map[string]any{"hits": map[string]any{"total": map[string]any{"value": 1}, "hits": []any{hit}}}
+	})
+
+	originLat, originLng := 13.75, 100.49
+	filter := domain.DestinationListFilter{
+		Search:      "temple \"wat arun\"",
+		SearchTerms: []domain.DestinationSearchTerm{{Words: []string{"temple"}}, {Words: []string{"wat", "arun"}}},
+		Sort:        domain.DestinationSortRelevance,
+		Categories:  []string{"Temple"},
+		Latitude:    &originLat,
+		Longitude:   &originLng,
+	}
+	got, err := repo.ListPublished(ctx, 10, 0, filter)
+	if err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != id || got[0].TotalCount != 1 || got[0].AverageRating != 4.5 {
+		t.Fatalf("unexpected destinations: %+v", got)
+	}
+	if got[0].SearchScore == nil || *got[0].SearchScore != 7.5 {
+		t.Fatalf("expected score from sort value, got %v", got[0].SearchScore)
+	}
+	if got[0].DistanceKM == nil || *got[0].DistanceKM < 0.5 || *got[0].DistanceKM > 2 {
+		t.Fatalf("expected distance around 1km, got %v", got[0].DistanceKM)
+	}
+	if got[0].SearchHeadline == nil || !strings.Contains(*got[0].SearchHeadline, "temple") {
+		t.Fatalf("expected headline, got %v", got[0].SearchHeadline)
+	}
+
+	requests := cluster.recorded()
+	if len(requests) != 1 || requests[0].Path != "/destinations/_search" {
+		t.Fatalf("unexpected requests: %+v", requests)
+	}
+	query, _ := json.Marshal(requests[0].Body["query"])
+	for _, want := range []string{`"fuzziness":"AUTO"`, `"type":"phrase"`, `"category.keyword":["temple"]`} {
+		if !strings.Contains(string(query), want) {
+			t.Fatalf("expected %s in query %s", want, query)
+		}
+	}
+	if requests[0].Body["track_total_hits"] != true {
+		t.Fatalf("expected total hits tracked without a cursor")
+	}
+}
+
+func TestDestinationSearchRepository_FallsBack(t *testing.T) {
+	ctx := context.Background()
+	fallback := &fallbackRepo{published: []domain.Destination{{ID: uuid.New(), Name: "Lumphini Park"}}}
+	repo, cluster := newStubRepo(t, fallback, func(stubRequest) (int, any) {
+		return http.StatusServiceUnavailable, map[string]any{"error": "unavailable"}
+	})
+
+	if _, err := repo.ListPublished(ctx, 10, 0, domain.DestinationListFilter{}); err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	openAt := time.Now()
+	if _, err := repo.ListPublished(ctx, 10, 0, domain.DestinationListFilter{Search: "park", OpenAt: &openAt}); err != nil {
+		t.Fatalf("ListPublished open_at: %v", err)
+	}
+	if n := len(cluster.recorded()); n != 0 {
+		t.Fatalf("expected browsing and open_at to skip elasticsearch, got %d requests", n)
+	}
+
+	got, err := repo.ListPublished(ctx, 10, 0, domain.DestinationListFilter{Search: "park"})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("expected fallback results, got %v, %v", got, err)
+	}
+	names, err := repo.Autocomplete(ctx, "lum", 5)
+	if err != nil || len(names) != 1 || names[0] != "from postgres" {
+		t.Fatalf("expected autocomplete fallback, got %v, %v", names, err)
+	}
+	if fallback.calls != 4 {
+		t.Fatalf("expected 4 fallback calls, got %d", fallback.calls)
+	}
+}
+
+func TestDestinationSearchRepository_Reindex(t *testing.T) {
+	ctx := context.Background()
+	published := domain.Destination{ID: uuid.New(), Name: "Wat Pho", AverageRating: 4}
+	fallback := &fallbackRepo{published: []domain.Destination{published}}
+	repo, cluster := newStubRepo(t, fallback, func(req stubRequest) (int, any) {
+		if req.Method == http.MethodDelete {
+			return http.StatusNotFound, map[string]any{"result": "not_found"}
+		}
+		return http.StatusOK, map[string]any{"result": "created"}
+	})
+
+	if err := repo.Reindex(ctx, published.ID); err != nil {
+		t.Fatalf("Reindex published: %v", err)
+	}
+	if err := repo.Reindex(ctx, uuid.New()); err != nil {
+		t.Fatalf("expected a missing document to be ignored, got %v", err)
+	}
+
+	requests := cluster.recorded()
+	if len(requests) != 2 {
+		t.Fatalf("expected two requests, got %+v", requests)
+	}
+	put := requests[0]
+	if put.Method != http.MethodPut || put.Path != "/destinations/_doc/"+published.ID.String() || !strings.Contains(put.Query, "require_alias=true") {
+		t.Fatalf("unexpected index request: %+v", put)
+	}
+	if put.Body["name"] != "Wat Pho" || put.Body["average_rating"] != 4.0 {
+		t.Fatalf("unexpected document: %v", put.Body)
+	}
+	if requests[1].Method != http.MethodDelete {
+		t.Fatalf("expected unpublished destination removed, got %+v", requests[1])
+	}
+}
@@ -48,3 +48,7 @@ func (s *Storage) Upload(ctx context.Context, bucket, objectName, contentType st
 	}
 	return fmt.Sprintf("%s/%s", bucket, objectName), nil
 }
+
+func (s *Storage) Delete(ctx context.Context, bucket, objectName string) error {
+	return s.client.RemoveObject(ctx, bucket, objectName, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+type DestinationApprovalRepository interface {
+	Create(ctx context.Context, approval *domain.DestinationChangeApproval) (*domain.DestinationChangeApproval, error)
+	ListByChange(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeApproval, error)
+}
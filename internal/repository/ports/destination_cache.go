@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// DestinationCache holds published destination reads in memory.
+type DestinationCache interface {
+	// Invalidate drops everything cached for the destination, on this replica
+	// and, through the invalidation bus, on every other. uuid.Nil drops
+	// everything.
+	Invalidate(ctx context.Context, id uuid.UUID) error
+	Stats() domain.DestinationCacheStats
+}
+
+// DestinationInvalidationBus carries cache invalidations between replicas.
+type DestinationInvalidationBus interface {
+	Publish(ctx context.Context, id uuid.UUID) error
+	// Listen calls fn for every invalidation published by any replica until
+	// ctx is done. fn receives uuid.Nil whenever invalidations may have been
+	// missed, such as after reconnecting.
+	Listen(ctx context.Context, fn func(id uuid.UUID)) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+type DestinationCategoryRepository interface {
+	Create(ctx context.Context, category *domain.DestinationCategory) (*domain.DestinationCategory, error)
+	Update(ctx context.Context, category *domain.DestinationCategory) (*domain.DestinationCategory, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.DestinationCategory, error)
+	List(ctx context.Context) ([]domain.DestinationCategory, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+type DestinationCommentRepository interface {
+	Create(ctx context.Context, comment *domain.DestinationChangeComment) (*domain.DestinationChangeComment, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.DestinationChangeComment, error)
+	ListByChange(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeComment, error)
+	SetResolved(ctx context.Context, id uuid.UUID, resolvedBy *uuid.UUID, resolvedAt *time.Time) (*domain.DestinationChangeComment, error)
+}
@@ -12,10 +12,25 @@ type DestinationRepository interface {
 	Create(ctx context.Context, fields domain.DestinationChangeFields, createdBy uuid.UUID, status domain.DestinationStatus, heroImageURL *string) (*domain.Destination, error)
 	Update(ctx context.Context, id uuid.UUID, fields domain.DestinationChangeFields, updatedBy uuid.UUID, statusOverride *domain.DestinationStatus, heroImageURL *string) (*domain.Destination, error)
 	Archive(ctx context.Context, id uuid.UUID, updatedBy uuid.UUID) (*domain.Destination, error)
+	Restore(ctx context.Context, id uuid.UUID, updatedBy uuid.UUID, status domain.DestinationStatus, slug *string) (*domain.Destination, error)
 	HardDelete(ctx context.Context, id uuid.UUID) error
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error)
 	FindPublishedByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error)
 	FindBySlug(ctx context.Context, slug string) (*domain.Destination, error)
+	// FindSlugOwner returns the destination that holds slug, as its current
+	// slug or a retired one, whatever its status. sql.ErrNoRows means the
+	// slug is free.
+	FindSlugOwner(ctx context.Context, slug string) (uuid.UUID, error)
 	ListPublished(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error)
+	ClusterPublished(ctx context.Context, filter domain.DestinationListFilter, cellDeg float64, limit int) ([]domain.DestinationMapCluster, error)
+	CountPublishedFacet(ctx context.Context, filter domain.DestinationListFilter, facet domain.DestinationFacet, limit int) ([]domain.DestinationFacetCount, error)
+	ListSimilarCandidates(ctx context.Context, dest *domain.Destination, radiusKM float64, limit int) ([]domain.Destination, error)
+	CatalogVersion(ctx context.Context) (domain.DestinationCatalogVersion, error)
 	Autocomplete(ctx context.Context, query string, limit int) ([]string, error)
+	// ListForExport returns up to limit destinations of any status matching
+	// filter, ordered by ID.
+	ListForExport(ctx context.Context, filter domain.DestinationExportFilter, limit int) ([]domain.Destination, error)
+	// ExportLayout describes the destinations matching filter, ignoring
+	// AfterID.
+	ExportLayout(ctx context.Context, filter domain.DestinationExportFilter) (domain.DestinationExportLayout, error)
 }
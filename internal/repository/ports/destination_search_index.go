@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DestinationSearchIndex keeps an external search index in step with the
+// published destinations.
+type DestinationSearchIndex interface {
+	// Reindex writes the destination's current document, or removes it when
+	// the destination is no longer published.
+	Reindex(ctx context.Context, id uuid.UUID) error
+	// Rebuild indexes every published destination into a fresh index and
+	// switches searches over to it, returning how many were indexed.
+	Rebuild(ctx context.Context) (int, error)
+}
@@ -11,4 +11,5 @@ import (
 type DestinationVersionRepository interface {
 	Create(ctx context.Context, version *domain.DestinationVersion) (*domain.DestinationVersion, error)
 	ListByDestination(ctx context.Context, destinationID uuid.UUID, limit int) ([]domain.DestinationVersion, error)
+	FindByVersion(ctx context.Context, destinationID uuid.UUID, version int64) (*domain.DestinationVersion, error)
 }
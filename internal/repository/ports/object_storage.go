@@ -7,4 +7,5 @@ import (
 
 type ObjectStorage interface {
 	Upload(ctx context.Context, bucket, objectName, contentType string, reader io.Reader, size int64) (string, error)
+	Delete(ctx context.Context, bucket, objectName string) error
 }
@@ -0,0 +1,9 @@
+package ports
+
+import "context"
+
+// Transactor runs fn in a database transaction. Repository calls made with the
+// context fn receives take part in it; an error from fn rolls them all back.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error)
+	List(ctx context.Context) ([]domain.WebhookSubscription, error)
+	ListActiveForEvent(ctx context.Context, event domain.WebhookEvent) ([]domain.WebhookSubscription, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error)
+	// ClaimDue returns up to limit pending deliveries due at now and pushes
+	// their next attempt out by lease, so concurrent dispatchers on other
+	// replicas skip them while they are in flight.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	// Update stores the outcome of an attempt.
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]domain.WebhookDelivery, error)
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+type DestinationApprovalRepository struct {
+	db *sqlx.DB
+}
+
+func NewDestinationApprovalRepo(db *sqlx.DB) *DestinationApprovalRepository {
+	return &DestinationApprovalRepository{db: db}
+}
+
+func (r *DestinationApprovalRepository) Create(ctx context.Context, approval *domain.DestinationChangeApproval) (*domain.DestinationChangeApproval, error) {
+	const query = `
+		INSERT INTO destination_change_approval (change_request_id, reviewer_id, decision, comment, draft_version, created_at)
+		VALUES (:change_request_id, :reviewer_id, :decision, :comment, :draft_version, NOW())
+		RETURNING id, change_request_id, reviewer_id, decision, comment, draft_version, created_at
+	`
+
+	args := map[string]any{
+		"change_request_id": approval.ChangeRequestID,
+		"reviewer_id":       approval.ReviewerID,
+		"decision":          approval.Decision,
+		"comment":           approval.Comment,
+		"draft_version":     approval.DraftVersion,
+	}
+
+	rows, err := sqlx.NamedQueryContext(ctx, conn(ctx, r.db), query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var inserted domain.DestinationChangeApproval
+		if err := rows.StructScan(&inserted); err != nil {
+			return nil, err
+		}
+		return &inserted, nil
+	}
+	return nil, nil
+}
+
+func (r *DestinationApprovalRepository) ListByChange(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeApproval, error) {
+	const query = `
+		SELECT id, change_request_id, reviewer_id, decision, comment, draft_version, created_at
+		FROM destination_change_approval
+		WHERE change_request_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+	approvals := make([]domain.DestinationChangeApproval, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &approvals, query, changeID); err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
+
+var _ ports.DestinationApprovalRepository = (*DestinationApprovalRepository)(nil)
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+const (
+	destinationCacheChannel    = "destination_cache"
+	destinationCacheMaxBackoff = 30 * time.Second
+)
+
+// DestinationCacheBus fans destination cache invalidations out to every
+// replica through Postgres LISTEN/NOTIFY. Notifications are only delivered to
+// connected listeners, so a listener flushes its cache whenever it
+// (re)connects.
+type DestinationCacheBus struct {
+	db  *sqlx.DB
+	dsn string
+}
+
+// NewDestinationCacheBus publishes through db and listens on a dedicated
+// connection opened from dsn.
+func NewDestinationCacheBus(db *sqlx.DB, dsn string) *DestinationCacheBus {
+	return &DestinationCacheBus{db: db, dsn: dsn}
+}
+
+func (b *DestinationCacheBus) Publish(ctx context.Context, id uuid.UUID) error {
+	_, err := b.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, destinationCacheChannel, id.String())
+	return err
+}
+
+// Listen reconnects with backoff until ctx is done.
+func (b *DestinationCacheBus) Listen(ctx context.Context, fn func(id uuid.UUID)) error {
+	backoff := time.Second
+	for {
+		connected, err := b.listen(ctx, fn)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if connected {
+			backoff = time.Second
+		}
+		log.Printf("destination cache: listen: %v; retrying in %s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, destinationCacheMaxBackoff)
+	}
+}
+
+// listen serves one connection and reports whether it got as far as
+// listening.
+func (b *DestinationCacheBus) listen(ctx context.Context, fn func(id uuid.UUID)) (bool, error) {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+destinationCacheChannel); err != nil {
+		return false, err
+	}
+	fn(uuid.Nil)
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		id, err := uuid.Parse(notification.Payload)
+		if err != nil {
+			log.Printf("destination cache: ignoring notification %q: %v", notification.Payload, err)
+			continue
+		}
+		fn(id)
+	}
+}
+
+var _ ports.DestinationInvalidationBus = (*DestinationCacheBus)(nil)
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+const destinationCategoryColumns = `id, slug, name, parent_id, icon, display_order, created_at, updated_at`
+
+type DestinationCategoryRepository struct {
+	db *sqlx.DB
+}
+
+func NewDestinationCategoryRepo(db *sqlx.DB) *DestinationCategoryRepository {
+	return &DestinationCategoryRepository{db: db}
+}
+
+func (r *DestinationCategoryRepository) Create(ctx context.Context, category *domain.DestinationCategory) (*domain.DestinationCategory, error) {
+	query := `
+		INSERT INTO destination_category (slug, name, parent_id, icon, display_order, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING ` + destinationCategoryColumns
+	var inserted domain.DestinationCategory
+	if err := r.db.GetContext(ctx, &inserted, query, category.Slug, category.Name, nullableUUID(category.ParentID), nullString(category.Icon), category.DisplayOrder); err != nil {
+		return nil, err
+	}
+	return &inserted, nil
+}
+
+func (r *DestinationCategoryRepository) Update(ctx context.Context, category *domain.DestinationCategory) (*domain.DestinationCategory, error) {
+	query := `
+		UPDATE destination_category
+		SET slug = $2, name = $3, parent_id = $4, icon = $5, display_order = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + destinationCategoryColumns
+	var updated domain.DestinationCategory
+	if err := r.db.GetContext(ctx, &updated, query, category.ID, category.Slug, category.Name, nullableUUID(category.ParentID), nullString(category.Icon), category.DisplayOrder); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *DestinationCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM destination_category WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *DestinationCategoryRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.DestinationCategory, error) {
+	query := `SELECT ` + destinationCategoryColumns + ` FROM destination_category WHERE id = $1`
+	var category domain.DestinationCategory
+	if err := r.db.GetContext(ctx, &category, query, id); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *DestinationCategoryRepository) List(ctx context.Context) ([]domain.DestinationCategory, error) {
+	query := `
+		SELECT ` + destinationCategoryColumns + `
+		FROM destination_category
+		ORDER BY display_order ASC, name ASC
+	`
+	categories := make([]domain.DestinationCategory, 0)
+	if err := r.db.SelectContext(ctx, &categories, query); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+var _ ports.DestinationCategoryRepository = (*DestinationCategoryRepository)(nil)
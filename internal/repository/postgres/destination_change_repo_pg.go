@@ -26,14 +26,14 @@ func (r *DestinationChangeRepository) Create(ctx context.Context, change *domain
 	const query = `
 		INSERT INTO destination_change_request (
 			destination_id, action, payload, hero_image_temp_key, status,
-			draft_version, submitted_by, created_at, updated_at
+			draft_version, submitted_by, base_version, created_at, updated_at
 		) VALUES (
 			:destination_id, :action, :payload, :hero_image_temp_key, :status,
-			:draft_version, :submitted_by, NOW(), NOW()
+			:draft_version, :submitted_by, :base_version, NOW(), NOW()
 		)
 		RETURNING id, destination_id, action, payload, hero_image_temp_key, status,
 		          draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
-		          review_message, published_version, created_at, updated_at
+		          review_message, published_version, base_version, created_at, updated_at
 	`
 
 	args := map[string]any{
@@ -44,9 +44,10 @@ func (r *DestinationChangeRepository) Create(ctx context.Context, change *domain
 		"status":              change.Status,
 		"draft_version":       change.DraftVersion,
 		"submitted_by":        change.SubmittedBy,
+		"base_version":        change.BaseVersion,
 	}
 
-	rows, err := r.db.NamedQueryContext(ctx, query, args)
+	rows, err := sqlx.NamedQueryContext(ctx, conn(ctx, r.db), query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -79,11 +80,12 @@ func (r *DestinationChangeRepository) Update(ctx context.Context, change *domain
 		    reviewed_by = $8,
 		    review_message = $9,
 		    published_version = $10,
+		    base_version = $11,
 		    updated_at = NOW()
 		WHERE id = $1 %s
 		RETURNING id, destination_id, action, payload, hero_image_temp_key, status,
 		          draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
-		          review_message, published_version, created_at, updated_at
+		          review_message, published_version, base_version, created_at, updated_at
 	`
 
 	where := ""
@@ -98,17 +100,18 @@ func (r *DestinationChangeRepository) Update(ctx context.Context, change *domain
 		nullableUUID(change.ReviewedBy),
 		nullString(change.ReviewMessage),
 		change.PublishedVersion,
+		change.BaseVersion,
 	}
 
 	if prevVersion > 0 {
-		where = "AND draft_version = $11"
+		where = "AND draft_version = $12"
 		args = append(args, prevVersion)
 	}
 
 	query := fmt.Sprintf(queryTemplate, where)
 
 	var updated domain.DestinationChangeRequest
-	if err := r.db.GetContext(ctx, &updated, query, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &updated, query, args...); err != nil {
 		return nil, err
 	}
 	return &updated, nil
@@ -118,15 +121,16 @@ func (r *DestinationChangeRepository) MarkSubmitted(ctx context.Context, id uuid
 	const query = `
 		UPDATE destination_change_request
 		SET status = 'pending_review',
+		    draft_version = CASE WHEN status = 'rejected' THEN draft_version + 1 ELSE draft_version END,
 		    submitted_at = $2,
 		    updated_at = NOW()
 		WHERE id = $1 AND status IN ('draft', 'rejected')
 		RETURNING id, destination_id, action, payload, hero_image_temp_key, status,
 		          draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
-		          review_message, published_version, created_at, updated_at
+		          review_message, published_version, base_version, created_at, updated_at
 	`
 	var updated domain.DestinationChangeRequest
-	if err := r.db.GetContext(ctx, &updated, query, id, submittedAt); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &updated, query, id, submittedAt); err != nil {
 		return nil, err
 	}
 	return &updated, nil
@@ -136,13 +140,31 @@ func (r *DestinationChangeRepository) FindByID(ctx context.Context, id uuid.UUID
 	const query = `
 		SELECT id, destination_id, action, payload, hero_image_temp_key, status,
 		       draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
-		       review_message, published_version, created_at, updated_at,
+		       review_message, published_version, base_version, created_at, updated_at,
 		       COUNT(*) OVER() AS total_count
 		FROM destination_change_request
 		WHERE id = $1
 	`
 	var change domain.DestinationChangeRequest
-	if err := r.db.GetContext(ctx, &change, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &change, query, id); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// FindByIDForUpdate is FindByID that also locks the row until the surrounding
+// transaction ends, so concurrent reviews of one change run one at a time.
+func (r *DestinationChangeRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.DestinationChangeRequest, error) {
+	const query = `
+		SELECT id, destination_id, action, payload, hero_image_temp_key, status,
+		       draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
+		       review_message, published_version, base_version, created_at, updated_at
+		FROM destination_change_request
+		WHERE id = $1
+		FOR UPDATE
+	`
+	var change domain.DestinationChangeRequest
+	if err := conn(ctx, r.db).GetContext(ctx, &change, query, id); err != nil {
 		return nil, err
 	}
 	return &change, nil
@@ -174,6 +196,20 @@ func (r *DestinationChangeRepository) List(ctx context.Context, filter domain.De
 		}
 		parts = append(parts, fmt.Sprintf("status IN (%s)", strings.Join(in, ",")))
 	}
+	if len(filter.Actions) > 0 {
+		in := make([]string, 0, len(filter.Actions))
+		for _, action := range filter.Actions {
+			in = append(in, fmt.Sprintf("$%d", idx))
+			args = append(args, action)
+			idx++
+		}
+		parts = append(parts, fmt.Sprintf("action IN (%s)", strings.Join(in, ",")))
+	}
+	if filter.ImportJobID != nil {
+		parts = append(parts, fmt.Sprintf("id IN (SELECT change_id FROM destination_import_row WHERE job_id = $%d AND change_id IS NOT NULL)", idx))
+		args = append(args, *filter.ImportJobID)
+		idx++
+	}
 
 	where := ""
 	if len(parts) > 0 {
@@ -183,7 +219,7 @@ func (r *DestinationChangeRepository) List(ctx context.Context, filter domain.De
 	query := fmt.Sprintf(`
 		SELECT id, destination_id, action, payload, hero_image_temp_key, status,
 		       draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
-		       review_message, published_version, created_at, updated_at
+		       review_message, published_version, base_version, created_at, updated_at
 		FROM destination_change_request
 		%s
 		ORDER BY created_at DESC
@@ -193,7 +229,7 @@ func (r *DestinationChangeRepository) List(ctx context.Context, filter domain.De
 	args = append(args, filter.Limit, filter.Offset)
 
 	changes := make([]domain.DestinationChangeRequest, 0)
-	if err := r.db.SelectContext(ctx, &changes, query, args...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &changes, query, args...); err != nil {
 		return nil, err
 	}
 	return changes, nil
@@ -211,17 +247,17 @@ func (r *DestinationChangeRepository) SetStatus(ctx context.Context, id uuid.UUI
 		WHERE id = $1
 		RETURNING id, destination_id, action, payload, hero_image_temp_key, status,
 		          draft_version, submitted_by, reviewed_by, submitted_at, reviewed_at,
-		          review_message, published_version, created_at, updated_at
+		          review_message, published_version, base_version, created_at, updated_at
 	`
 	var change domain.DestinationChangeRequest
-	if err := r.db.GetContext(ctx, &change, query, id, status, nullableUUID(reviewerID), nullString(reviewMessage), publishedVersion); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &change, query, id, status, nullableUUID(reviewerID), nullString(reviewMessage), publishedVersion); err != nil {
 		return nil, err
 	}
 	return &change, nil
 }
 
 func (r *DestinationChangeRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.ExecContext(ctx, `DELETE FROM destination_change_request WHERE id = $1`, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM destination_change_request WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
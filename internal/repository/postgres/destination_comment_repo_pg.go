@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+const destinationCommentColumns = `id, change_request_id, parent_id, author_id, body, field, resolved_by, resolved_at, created_at, updated_at`
+
+type DestinationCommentRepository struct {
+	db *sqlx.DB
+}
+
+func NewDestinationCommentRepo(db *sqlx.DB) *DestinationCommentRepository {
+	return &DestinationCommentRepository{db: db}
+}
+
+func (r *DestinationCommentRepository) Create(ctx context.Context, comment *domain.DestinationChangeComment) (*domain.DestinationChangeComment, error) {
+	query := `
+		INSERT INTO destination_change_comment (change_request_id, parent_id, author_id, body, field, created_at, updated_at)
+		VALUES (:change_request_id, :parent_id, :author_id, :body, :field, NOW(), NOW())
+		RETURNING ` + destinationCommentColumns
+
+	args := map[string]any{
+		"change_request_id": comment.ChangeRequestID,
+		"parent_id":         nullableUUID(comment.ParentID),
+		"author_id":         comment.AuthorID,
+		"body":              comment.Body,
+		"field":             comment.Field,
+	}
+
+	rows, err := r.db.NamedQueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var inserted domain.DestinationChangeComment
+		if err := rows.StructScan(&inserted); err != nil {
+			return nil, err
+		}
+		return &inserted, nil
+	}
+	return nil, nil
+}
+
+func (r *DestinationCommentRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.DestinationChangeComment, error) {
+	query := `SELECT ` + destinationCommentColumns + ` FROM destination_change_comment WHERE id = $1`
+	var comment domain.DestinationChangeComment
+	if err := r.db.GetContext(ctx, &comment, query, id); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *DestinationCommentRepository) ListByChange(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeComment, error) {
+	query := `
+		SELECT ` + destinationCommentColumns + `
+		FROM destination_change_comment
+		WHERE change_request_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+	comments := make([]domain.DestinationChangeComment, 0)
+	if err := r.db.SelectContext(ctx, &comments, query, changeID); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *DestinationCommentRepository) SetResolved(ctx context.Context, id uuid.UUID, resolvedBy *uuid.UUID, resolvedAt *time.Time) (*domain.DestinationChangeComment, error) {
+	query := `
+		UPDATE destination_change_comment
+		SET resolved_by = $2, resolved_at = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + destinationCommentColumns
+	var comment domain.DestinationChangeComment
+	if err := r.db.GetContext(ctx, &comment, query, id, nullableUUID(resolvedBy), nullTime(resolvedAt)); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+var _ ports.DestinationCommentRepository = (*DestinationCommentRepository)(nil)
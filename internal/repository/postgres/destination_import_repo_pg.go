@@ -116,11 +116,11 @@ func (r *DestinationImportRepository) FindJobByID(ctx context.Context, id uuid.U
 func (r *DestinationImportRepository) InsertRow(ctx context.Context, row *domain.DestinationImportRow) (*domain.DestinationImportRow, error) {
 	const query = `
 		INSERT INTO destination_import_row (
-			job_id, row_number, status, action, change_id, error, payload
+			job_id, row_number, status, action, change_id, error, payload, diff
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7
+			$1, $2, $3, $4, $5, $6, $7, $8
 		)
-		RETURNING id, job_id, row_number, status, action, change_id, error, payload, created_at
+		RETURNING id, job_id, row_number, status, action, change_id, error, payload, diff, created_at
 	`
 
 	var inserted domain.DestinationImportRow
@@ -132,6 +132,7 @@ func (r *DestinationImportRepository) InsertRow(ctx context.Context, row *domain
 		uuidPtrOrNil(row.ChangeID),
 		nullStringPtr(row.ErrorMessage),
 		row.Payload,
+		row.Diff,
 	); err != nil {
 		return nil, err
 	}
@@ -140,7 +141,7 @@ func (r *DestinationImportRepository) InsertRow(ctx context.Context, row *domain
 
 func (r *DestinationImportRepository) ListRowsByJob(ctx context.Context, jobID uuid.UUID) ([]domain.DestinationImportRow, error) {
 	const query = `
-		SELECT id, job_id, row_number, status, action, change_id, error, payload, created_at
+		SELECT id, job_id, row_number, status, action, change_id, error, payload, diff, created_at
 		FROM destination_import_row
 		WHERE job_id = $1
 		ORDER BY row_number ASC
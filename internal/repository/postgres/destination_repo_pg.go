@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -31,14 +32,14 @@ func (r *DestinationRepository) Create(ctx context.Context, fields domain.Destin
 		INSERT INTO travel_destination (
 			name, slug, city, country, category, description,
 			latitude, longitude, contact, opening_time, closing_time,
-			gallery, hero_image_url, status, version, updated_by
+			timezone, opening_hours, translations, tags, gallery, hero_image_url, status, version, updated_by
 		) VALUES (
 			:name, :slug, :city, :country, :category, :description,
 			:latitude, :longitude, :contact, :opening_time, :closing_time,
-			:gallery, :hero_image_url, :status, 1, :updated_by
+			:timezone, :opening_hours, :translations, :tags, :gallery, :hero_image_url, :status, 1, :updated_by
 		)
 		RETURNING id, name, slug, status, version, city, country, category, description,
-		          latitude, longitude, contact, opening_time, closing_time, gallery,
+		          latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
 		          hero_image_url, created_at, updated_at, updated_by, deleted_at
 	`
 
@@ -54,13 +55,17 @@ func (r *DestinationRepository) Create(ctx context.Context, fields domain.Destin
 		"contact":        nullString(fields.Contact),
 		"opening_time":   nullString(fields.OpeningTime),
 		"closing_time":   nullString(fields.ClosingTime),
+		"timezone":       nullString(fields.Timezone),
+		"opening_hours":  openingHoursValue(fields.OpeningHours),
+		"translations":   translationsValue(fields.Translations),
+		"tags":           tagsValue(fields.Tags),
 		"gallery":        galleryValue(fields.Gallery),
 		"hero_image_url": nullStringOr(heroImageURL),
 		"status":         status,
 		"updated_by":     createdBy,
 	}
 
-	rows, err := r.db.NamedQueryContext(ctx, query, args)
+	rows, err := sqlx.NamedQueryContext(ctx, conn(ctx, r.db), query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -126,6 +131,26 @@ func (r *DestinationRepository) Update(ctx context.Context, id uuid.UUID, fields
 		args = append(args, nullString(fields.ClosingTime))
 		idx++
 	}
+	if fields.Timezone != nil {
+		setParts = append(setParts, fmt.Sprintf("timezone = $%d", idx))
+		args = append(args, nullString(fields.Timezone))
+		idx++
+	}
+	if fields.OpeningHours != nil {
+		setParts = append(setParts, fmt.Sprintf("opening_hours = $%d", idx))
+		args = append(args, openingHoursValue(fields.OpeningHours))
+		idx++
+	}
+	if fields.Translations != nil {
+		setParts = append(setParts, fmt.Sprintf("translations = $%d", idx))
+		args = append(args, translationsValue(fields.Translations))
+		idx++
+	}
+	if fields.Tags != nil {
+		setParts = append(setParts, fmt.Sprintf("tags = $%d", idx))
+		args = append(args, tagsValue(fields.Tags))
+		idx++
+	}
 	if fields.Latitude != nil {
 		setParts = append(setParts, fmt.Sprintf("latitude = $%d", idx))
 		args = append(args, nullFloat(fields.Latitude))
@@ -159,14 +184,14 @@ func (r *DestinationRepository) Update(ctx context.Context, id uuid.UUID, fields
 		SET %s
 		WHERE id = $%d
 		RETURNING id, name, slug, status, version, city, country, category, description,
-		          latitude, longitude, contact, opening_time, closing_time, gallery,
+		          latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
 		          hero_image_url, created_at, updated_at, updated_by, deleted_at
 	`, strings.Join(setParts, ", "), idx)
 
 	args = append(args, id)
 
 	var dest domain.Destination
-	if err := r.db.GetContext(ctx, &dest, query, args...); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &dest, query, args...); err != nil {
 		return nil, err
 	}
 	return &dest, nil
@@ -182,18 +207,41 @@ func (r *DestinationRepository) Archive(ctx context.Context, id uuid.UUID, updat
 		    version = version + 1
 		WHERE id = $1
 		RETURNING id, name, slug, status, version, city, country, category, description,
-		          latitude, longitude, contact, opening_time, closing_time, gallery,
+		          latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
 		          hero_image_url, created_at, updated_at, updated_by, deleted_at
 	`
 	var dest domain.Destination
-	if err := r.db.GetContext(ctx, &dest, query, id, updatedBy); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &dest, query, id, updatedBy); err != nil {
+		return nil, err
+	}
+	return &dest, nil
+}
+
+// Restore brings an archived destination back with the given status. A nil
+// slug keeps the stored one.
+func (r *DestinationRepository) Restore(ctx context.Context, id uuid.UUID, updatedBy uuid.UUID, status domain.DestinationStatus, slug *string) (*domain.Destination, error) {
+	const query = `
+		UPDATE travel_destination
+		SET status = $2,
+		    slug = COALESCE($3, slug),
+		    deleted_at = NULL,
+		    updated_at = NOW(),
+		    updated_by = $4,
+		    version = version + 1
+		WHERE id = $1 AND status = 'archived'
+		RETURNING id, name, slug, status, version, city, country, category, description,
+		          latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
+		          hero_image_url, created_at, updated_at, updated_by, deleted_at
+	`
+	var dest domain.Destination
+	if err := conn(ctx, r.db).GetContext(ctx, &dest, query, id, status, nullString(slug), updatedBy); err != nil {
 		return nil, err
 	}
 	return &dest, nil
 }
 
 func (r *DestinationRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.ExecContext(ctx, `DELETE FROM travel_destination WHERE id = $1`, id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM travel_destination WHERE id = $1`, id)
 	if err != nil {
 		return err
 	}
@@ -210,13 +258,13 @@ func (r *DestinationRepository) HardDelete(ctx context.Context, id uuid.UUID) er
 func (r *DestinationRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error) {
 	const query = `
 		SELECT id, name, slug, status, version, city, country, category, description,
-		       latitude, longitude, contact, opening_time, closing_time, gallery,
+		       latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
 		       hero_image_url, created_at, updated_at, updated_by, deleted_at
 		FROM travel_destination
 		WHERE id = $1
 	`
 	var dest domain.Destination
-	if err := r.db.GetContext(ctx, &dest, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &dest, query, id); err != nil {
 		return nil, err
 	}
 	return &dest, nil
@@ -225,35 +273,311 @@ func (r *DestinationRepository) FindByID(ctx context.Context, id uuid.UUID) (*do
 func (r *DestinationRepository) FindPublishedByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error) {
 	const query = `
 		SELECT id, name, slug, status, version, city, country, category, description,
-		       latitude, longitude, contact, opening_time, closing_time, gallery,
+		       latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
 		       hero_image_url, created_at, updated_at, updated_by, deleted_at
 		FROM travel_destination
 		WHERE id = $1 AND status = 'published' AND deleted_at IS NULL
 	`
 	var dest domain.Destination
-	if err := r.db.GetContext(ctx, &dest, query, id); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &dest, query, id); err != nil {
 		return nil, err
 	}
 	return &dest, nil
 }
 
+// FindBySlug matches the current slug first and falls back to retired slugs,
+// in which case RetiredSlug is set on the result.
 func (r *DestinationRepository) FindBySlug(ctx context.Context, slug string) (*domain.Destination, error) {
 	const query = `
-		SELECT id, name, slug, status, version, city, country, category, description,
-		       latitude, longitude, contact, opening_time, closing_time, gallery,
-		       hero_image_url, created_at, updated_at, updated_by, deleted_at
-		FROM travel_destination
-		WHERE slug = $1 AND deleted_at IS NULL
+		SELECT * FROM (
+			SELECT id, name, slug, status, version, city, country, category, description,
+			       latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
+			       hero_image_url, created_at, updated_at, updated_by, deleted_at, NULL::text AS retired_slug
+			FROM travel_destination
+			WHERE slug = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT d.id, d.name, d.slug, d.status, d.version, d.city, d.country, d.category, d.description,
+			       d.latitude, d.longitude, d.contact, d.opening_time, d.closing_time, d.timezone, d.opening_hours, d.translations, d.tags, d.gallery,
+			       d.hero_image_url, d.created_at, d.updated_at, d.updated_by, d.deleted_at, h.slug AS retired_slug
+			FROM destination_slug_history h
+			JOIN travel_destination d ON d.id = h.destination_id
+			WHERE h.slug = $1 AND d.deleted_at IS NULL
+		) matches
+		ORDER BY retired_slug IS NOT NULL
+		LIMIT 1
 	`
 	var dest domain.Destination
-	if err := r.db.GetContext(ctx, &dest, query, slug); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &dest, query, slug); err != nil {
 		return nil, err
 	}
 	return &dest, nil
 }
 
+func (r *DestinationRepository) FindSlugOwner(ctx context.Context, slug string) (uuid.UUID, error) {
+	const query = `
+		SELECT id FROM travel_destination WHERE slug = $1
+		UNION ALL
+		SELECT destination_id FROM destination_slug_history WHERE slug = $1
+		LIMIT 1
+	`
+	var id uuid.UUID
+	if err := conn(ctx, r.db).GetContext(ctx, &id, query, slug); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
 func (r *DestinationRepository) ListPublished(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
-	const base = `
+	builder, params, keys := r.publishedQuery(filter)
+
+	order := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := " ASC"
+		if key.desc {
+			direction = " DESC"
+		}
+		order = append(order, key.expr+direction)
+	}
+	builder.WriteString("\n\tORDER BY " + strings.Join(order, ", "))
+
+	limitPlaceholder := fmt.Sprintf("$%d", len(params)+1)
+	builder.WriteString("\n\tLIMIT " + limitPlaceholder)
+	params = append(params, limit)
+	if filter.After == nil {
+		offsetPlaceholder := fmt.Sprintf("$%d", len(params)+1)
+		builder.WriteString(" OFFSET " + offsetPlaceholder)
+		params = append(params, offset)
+	}
+
+	destinations := make([]domain.Destination, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &destinations, builder.String(), params...); err != nil {
+		return nil, err
+	}
+	return destinations, nil
+}
+
+// ClusterPublished groups the published destinations matching filter into
+// square grid cells of cellDeg degrees and returns one marker per cell, the
+// largest first.
+func (r *DestinationRepository) ClusterPublished(ctx context.Context, filter domain.DestinationListFilter, cellDeg float64, limit int) ([]domain.DestinationMapCluster, error) {
+	matches, params, _ := r.publishedQuery(filter)
+	cell := fmt.Sprintf("$%d", len(params)+1)
+	limitPlaceholder := fmt.Sprintf("$%d", len(params)+2)
+	params = append(params, cellDeg, limit)
+
+	query := `
+		WITH matches AS (` + matches.String() + `)
+		SELECT
+			COUNT(*)::int AS count,
+			AVG(latitude)::float8 AS latitude,
+			AVG(longitude)::float8 AS longitude,
+			CASE WHEN COUNT(*) = 1 THEN MIN(id::text)::uuid END AS destination_id,
+			CASE WHEN COUNT(*) = 1 THEN MIN(name) END AS name,
+			CASE WHEN COUNT(*) = 1 THEN MIN(slug) END AS slug
+		FROM matches
+		WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+		GROUP BY floor(latitude / ` + cell + `), floor(longitude / ` + cell + `)
+		ORDER BY count DESC, latitude, longitude
+		LIMIT ` + limitPlaceholder
+
+	clusters := make([]domain.DestinationMapCluster, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &clusters, query, params...); err != nil {
+		return nil, err
+	}
+	return clusters, nil
+}
+
+// CountPublishedFacet counts the published destinations matching filter per
+// value of facet, at most limit values, the most common first. Rating counts
+// every band in domain.DestinationRatingBands, including empty ones.
+func (r *DestinationRepository) CountPublishedFacet(ctx context.Context, filter domain.DestinationListFilter, facet domain.DestinationFacet, limit int) ([]domain.DestinationFacetCount, error) {
+	filter.After = nil
+	matches, params, _ := r.publishedQuery(filter)
+
+	var query string
+	switch facet {
+	case domain.DestinationFacetRating:
+		bands := fmt.Sprintf("$%d", len(params)+1)
+		params = append(params, pq.Array(domain.DestinationRatingBands))
+		query = `
+		WITH matches AS (` + matches.String() + `)
+		SELECT band::text AS value, COUNT(m.id)::int AS count
+		FROM unnest(` + bands + `::int[]) AS band
+		LEFT JOIN matches m ON m.average_rating >= band
+		GROUP BY band
+		ORDER BY band DESC`
+	case domain.DestinationFacetCategory, domain.DestinationFacetCountry, domain.DestinationFacetCity:
+		column := string(facet)
+		limitPlaceholder := fmt.Sprintf("$%d", len(params)+1)
+		params = append(params, limit)
+		query = `
+		WITH matches AS (` + matches.String() + `)
+		SELECT ` + column + ` AS value, COUNT(*)::int AS count
+		FROM matches
+		WHERE NULLIF(btrim(` + column + `), '') IS NOT NULL
+		GROUP BY ` + column + `
+		ORDER BY count DESC, value
+		LIMIT ` + limitPlaceholder
+	default:
+		return nil, fmt.Errorf("unknown facet %q", facet)
+	}
+
+	counts := make([]domain.DestinationFacetCount, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &counts, query, params...); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// CatalogVersion reads the published catalogue's version from row counts and
+// the latest timestamps. Counts catch hard deletes, and timestamps cover
+// destinations in any state, so unpublishing moves the version too.
+func (r *DestinationRepository) CatalogVersion(ctx context.Context) (domain.DestinationCatalogVersion, error) {
+	const query = `
+		SELECT GREATEST(d.updated_at, rv.updated_at, c.updated_at) AS updated_at,
+		       d.published AS destinations,
+		       rv.total AS reviews,
+		       c.total AS categories
+		FROM (
+			SELECT COALESCE(MAX(updated_at), 'epoch'::timestamptz) AS updated_at,
+			       COUNT(*) FILTER (WHERE status = 'published' AND deleted_at IS NULL)::int AS published
+			FROM travel_destination
+		) d,
+		(
+			SELECT COALESCE(MAX(GREATEST(updated_at, deleted_at)), 'epoch'::timestamptz) AS updated_at,
+			       COUNT(*) FILTER (WHERE deleted_at IS NULL)::int AS total
+			FROM review
+		) rv,
+		(
+			SELECT COALESCE(MAX(updated_at), 'epoch'::timestamptz) AS updated_at, COUNT(*)::int AS total
+			FROM destination_category
+		) c
+	`
+	var version domain.DestinationCatalogVersion
+	if err := conn(ctx, r.db).GetContext(ctx, &version, query); err != nil {
+		return domain.DestinationCatalogVersion{}, err
+	}
+	return version, nil
+}
+
+func (r *DestinationRepository) ListForExport(ctx context.Context, filter domain.DestinationExportFilter, limit int) ([]domain.Destination, error) {
+	conditions, params := exportConditions(filter, true)
+	params = append(params, limit)
+	query := `
+		SELECT id, name, slug, status, version, city, country, category, description,
+		       latitude, longitude, contact, opening_time, closing_time, timezone, opening_hours, translations, tags, gallery,
+		       hero_image_url, created_at, updated_at, updated_by, deleted_at
+		FROM travel_destination
+		WHERE ` + conditions + `
+		ORDER BY id ASC
+		LIMIT $` + fmt.Sprint(len(params))
+	destinations := make([]domain.Destination, 0, limit)
+	if err := conn(ctx, r.db).SelectContext(ctx, &destinations, query, params...); err != nil {
+		return nil, err
+	}
+	return destinations, nil
+}
+
+func (r *DestinationRepository) ExportLayout(ctx context.Context, filter domain.DestinationExportFilter) (domain.DestinationExportLayout, error) {
+	conditions, params := exportConditions(filter, false)
+	query := `
+		WITH matched AS (
+			SELECT gallery, translations
+			FROM travel_destination
+			WHERE ` + conditions + `
+		)
+		SELECT
+			(SELECT COALESCE(MAX(jsonb_array_length(COALESCE(gallery, '[]'::jsonb))), 0) FROM matched) AS gallery_slots,
+			(SELECT COALESCE(array_agg(DISTINCT locale ORDER BY locale), '{}')
+			 FROM matched, jsonb_object_keys(COALESCE(translations, '{}'::jsonb)) AS locale) AS locales`
+	var row struct {
+		GallerySlots int            `db:"gallery_slots"`
+		Locales      pq.StringArray `db:"locales"`
+	}
+	if err := conn(ctx, r.db).GetContext(ctx, &row, query, params...); err != nil {
+		return domain.DestinationExportLayout{}, err
+	}
+	return domain.DestinationExportLayout{GallerySlots: row.GallerySlots, Locales: []string(row.Locales)}, nil
+}
+
+// exportConditions builds the WHERE clause for an export filter, with the
+// AfterID keyset only when withCursor is set.
+func exportConditions(filter domain.DestinationExportFilter, withCursor bool) (string, []any) {
+	conditions := make([]string, 0, 5)
+	params := make([]any, 0, 4)
+	// Archiving soft-deletes, so archived rows are only left in when asked for.
+	if !slices.Contains(filter.Statuses, domain.DestinationStatusArchived) {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		params = append(params, pq.StringArray(statuses))
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(params)))
+	}
+	if len(filter.Categories) > 0 {
+		categories := make([]string, 0, len(filter.Categories))
+		for _, category := range filter.Categories {
+			if trimmed := strings.TrimSpace(category); trimmed != "" {
+				categories = append(categories, strings.ToLower(trimmed))
+			}
+		}
+		if len(categories) > 0 {
+			params = append(params, pq.StringArray(categories))
+			conditions = append(conditions, fmt.Sprintf("lower(category) = ANY($%d)", len(params)))
+		}
+	}
+	if filter.UpdatedSince != nil {
+		params = append(params, *filter.UpdatedSince)
+		conditions = append(conditions, fmt.Sprintf("updated_at >= $%d", len(params)))
+	}
+	if withCursor && filter.AfterID != nil {
+		params = append(params, *filter.AfterID)
+		conditions = append(conditions, fmt.Sprintf("id > $%d", len(params)))
+	}
+	return strings.Join(conditions, " AND "), params
+}
+
+// publishedQuery builds the filtered, rating-aggregated select over published
+// destinations shared by listing and map clustering, along with the sort keys
+// for filter.Sort. Callers append ordering and paging; params continue from
+// the returned slice. With filter.After set the query is restricted to rows
+// after the cursor and the total_count window is left out.
+func (r *DestinationRepository) publishedQuery(filter domain.DestinationListFilter) (*strings.Builder, []any, []sortKey) {
+	params := make([]any, 0, 6)
+	distance := "NULL::float8"
+	if filter.Latitude != nil && filter.Longitude != nil {
+		distance = haversineKM("$1", "$2")
+		params = append(params, *filter.Latitude, *filter.Longitude)
+	}
+	search := strings.TrimSpace(filter.Search)
+	searchPlaceholder := ""
+	tsQuery := ""
+	score := ""
+	headline := "NULL::text"
+	if search != "" {
+		searchPlaceholder = fmt.Sprintf("$%d", len(params)+1)
+		params = append(params, search)
+		tsQuery = "plainto_tsquery('simple', " + searchPlaceholder + ")"
+		if len(filter.SearchTerms) > 0 {
+			tsQuery = fmt.Sprintf("to_tsquery('simple', $%d)", len(params)+1)
+			params = append(params, searchTSQuery(filter.SearchTerms))
+		}
+		headline = "ts_headline('simple', d.description, " + tsQuery + ", '" + searchHeadlineOptions + "')"
+		score = searchScore(filter.Sort, tsQuery, searchPlaceholder, r.trigramAvailable)
+	}
+	keys := publishedSortKeys(filter, distance, score)
+	if score == "" {
+		score = "NULL::float8"
+	}
+	totalCount := "COUNT(*) OVER() AS total_count,"
+	if filter.After != nil {
+		totalCount = ""
+	}
+
+	base := `
 		SELECT
 			d.id,
 			d.name,
@@ -269,6 +593,10 @@ func (r *DestinationRepository) ListPublished(ctx context.Context, limit, offset
 			d.contact,
 			d.opening_time,
 			d.closing_time,
+			d.timezone,
+			d.opening_hours,
+			d.translations,
+			d.tags,
 			d.gallery,
 			d.hero_image_url,
 			d.created_at,
@@ -277,52 +605,62 @@ func (r *DestinationRepository) ListPublished(ctx context.Context, limit, offset
 			d.deleted_at,
 			COALESCE(AVG(r.rating)::float8, 0) AS average_rating,
 			COUNT(r.id)::int AS review_count,
-			COUNT(*) OVER() AS total_count
+			` + totalCount + `
+			` + score + ` AS search_score,
+			` + headline + ` AS search_headline,
+			` + distance + ` AS distance_km
 		FROM travel_destination d
 		LEFT JOIN review r ON r.destination_id = d.id AND r.deleted_at IS NULL
 		WHERE d.status = 'published' AND d.deleted_at IS NULL
 	`
 
-	params := make([]any, 0, 6)
 	var builder strings.Builder
 	builder.WriteString(base)
 
-	if trimmed := strings.TrimSpace(filter.Search); trimmed != "" {
-		placeholder := fmt.Sprintf("$%d", len(params)+1)
+	if search != "" {
+		placeholder := searchPlaceholder
 		builder.WriteString(`
 		AND (
-			to_tsvector(
-				'simple',
-				COALESCE(d.name, '') || ' ' ||
-				COALESCE(d.city, '') || ' ' ||
-				COALESCE(d.country, '') || ' ' ||
-				COALESCE(d.category, '') || ' ' ||
-				COALESCE(d.description, '')
-			) @@ plainto_tsquery('simple', ` + placeholder + `)`)
+			d.search_vector @@ ` + tsQuery)
+		builder.WriteString(`
+			OR EXISTS (
+				SELECT 1 FROM jsonb_each(COALESCE(d.translations, '{}'::jsonb)) AS t
+				WHERE t.value->>'name' ILIKE ` + likeContains(placeholder) + `
+				   OR t.value->>'description' ILIKE ` + likeContains(placeholder) + `
+			)`)
 		if r.trigramAvailable {
 			builder.WriteString(`
 			OR similarity(d.name, ` + placeholder + `) > 0.2`)
 		}
 		builder.WriteString("\n\t)")
-		params = append(params, trimmed)
 	}
 
 	if len(filter.Categories) > 0 {
 		categories := make([]string, 0, len(filter.Categories))
 		for _, category := range filter.Categories {
 			if trimmed := strings.TrimSpace(category); trimmed != "" {
-				categories = append(categories, trimmed)
+				categories = append(categories, strings.ToLower(trimmed))
 			}
 		}
 		if len(categories) > 0 {
 			placeholder := fmt.Sprintf("$%d", len(params)+1)
 			builder.WriteString(`
-		AND d.category = ANY(` + placeholder + `)
+		AND lower(d.category) = ANY(` + placeholder + `)
 			`)
 			params = append(params, pq.StringArray(categories))
 		}
 	}
 
+	if len(filter.Tags) > 0 {
+		operator := "?|"
+		if filter.TagMatch == domain.DestinationTagMatchAll {
+			operator = "?&"
+		}
+		placeholder := fmt.Sprintf("$%d", len(params)+1)
+		builder.WriteString("\n\tAND d.tags " + operator + " " + placeholder)
+		params = append(params, pq.StringArray(filter.Tags))
+	}
+
 	if filter.City != nil {
 		city := strings.TrimSpace(*filter.City)
 		if city != "" {
@@ -342,26 +680,47 @@ func (r *DestinationRepository) ListPublished(ctx context.Context, limit, offset
 	}
 
 	if filter.MaxDistanceKM != nil && filter.Latitude != nil && filter.Longitude != nil {
-		latPlaceholder := fmt.Sprintf("$%d", len(params)+1)
-		lngPlaceholder := fmt.Sprintf("$%d", len(params)+2)
-		distPlaceholder := fmt.Sprintf("$%d", len(params)+3)
-
+		placeholder := fmt.Sprintf("$%d", len(params)+1)
 		builder.WriteString(`
 			AND d.latitude IS NOT NULL AND d.longitude IS NOT NULL
-			AND (6371 * acos(
-				cos(radians(` + latPlaceholder + `)) * cos(radians(d.latitude)) *
-				cos(radians(d.longitude) - radians(` + lngPlaceholder + `)) +
-				sin(radians(` + latPlaceholder + `)) * sin(radians(d.latitude)) 
-			)) <= ` + distPlaceholder + `
+			AND ` + distance + ` <= ` + placeholder + `
 		`)
-		params = append(params, *filter.Latitude, *filter.Longitude, *filter.MaxDistanceKM)
+		params = append(params, *filter.MaxDistanceKM)
+	}
+
+	if filter.BBox != nil {
+		builder.WriteString("\n\tAND " + bboxCondition(*filter.BBox, &params))
+	}
+
+	if len(filter.IDs) > 0 {
+		ids := make([]string, 0, len(filter.IDs))
+		for _, id := range filter.IDs {
+			ids = append(ids, id.String())
+		}
+		placeholder := fmt.Sprintf("$%d", len(params)+1)
+		builder.WriteString("\n\tAND d.id = ANY(" + placeholder + "::uuid[])")
+		params = append(params, pq.StringArray(ids))
+	}
+
+	if filter.OpenAt != nil {
+		placeholder := fmt.Sprintf("$%d", len(params)+1)
+		builder.WriteString("\n\tAND destination_is_open(d.opening_hours, d.timezone, " + placeholder + ")")
+		params = append(params, *filter.OpenAt)
+	}
+
+	keysetAggregate := false
+	for _, key := range keys {
+		keysetAggregate = keysetAggregate || key.aggregate
+	}
+	if filter.After != nil && !keysetAggregate {
+		builder.WriteString("\n\tAND " + keysetCondition(keys, filter.After, &params))
 	}
 
 	builder.WriteString(`
 		GROUP BY d.id
 	`)
 
-	havingClauses := make([]string, 0, 2)
+	havingClauses := make([]string, 0, 3)
 	if filter.MinRating != nil {
 		placeholder := fmt.Sprintf("$%d", len(params)+1)
 		havingClauses = append(havingClauses, "COALESCE(AVG(r.rating)::float8, 0) >= "+placeholder)
@@ -372,55 +731,213 @@ func (r *DestinationRepository) ListPublished(ctx context.Context, limit, offset
 		havingClauses = append(havingClauses, "COALESCE(AVG(r.rating)::float8, 0) <= "+placeholder)
 		params = append(params, *filter.MaxRating)
 	}
+	if filter.After != nil && keysetAggregate {
+		havingClauses = append(havingClauses, keysetCondition(keys, filter.After, &params))
+	}
 	if len(havingClauses) > 0 {
 		builder.WriteString("\n\tHAVING " + strings.Join(havingClauses, " AND "))
 	}
+	return &builder, params, keys
+}
+
+// searchHeadlineOptions configures ts_headline for description snippets.
+const searchHeadlineOptions = `StartSel="` + domain.SearchHighlightStart + `", StopSel="` + domain.SearchHighlightStop + `", MaxFragments=2, MaxWords=30, MinWords=12, FragmentDelimiter=" … "`
+
+// searchScore is the score expression the similarity and relevance sorts
+// order by, or "" for other sorts and when similarity needs the missing
+// pg_trgm. Relevance ranks the search_vector from migration 0023, whose
+// weights put name over city and country over category over description,
+// and adds half the trigram name similarity so near-miss spellings that only
+// match by trigram still rank.
+func searchScore(order domain.DestinationListSort, tsQuery, raw string, trigram bool) string {
+	similarity := "similarity(d.name, " + raw + ")"
+	switch order {
+	case domain.DestinationSortSimilarity:
+		if trigram {
+			return similarity + "::float8"
+		}
+	case domain.DestinationSortRelevance:
+		rank := "ts_rank(d.search_vector, " + tsQuery + ")"
+		if trigram {
+			return "(" + rank + " + 0.5 * " + similarity + ")::float8"
+		}
+		return rank + "::float8"
+	}
+	return ""
+}
+
+// searchTSQuery renders parsed search terms as to_tsquery input: the words
+// of a phrase are joined with <->, prefixes end in :* and terms are ANDed.
+// The service's parser leaves only letters, digits and marks in words, and
+// each word is quoted as a lexeme on top of that.
+func searchTSQuery(terms []domain.DestinationSearchTerm) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		words := make([]string, 0, len(term.Words))
+		for _, word := range term.Words {
+			words = append(words, "'"+strings.ReplaceAll(word, "'", "''")+"'")
+		}
+		if len(words) == 0 {
+			continue
+		}
+		if term.Prefix {
+			words[len(words)-1] += ":*"
+		}
+		part := strings.Join(words, " <-> ")
+		if len(words) > 1 {
+			part = "(" + part + ")"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " & ")
+}
+
+// sortKey is one ORDER BY term of the published listing. value reads the
+// matching position from a cursor; nil stands for NULL, which only nullable
+// keys hold and which sorts last.
+type sortKey struct {
+	expr      string
+	desc      bool
+	aggregate bool
+	nullable  bool
+	value     func(cursor *domain.DestinationListCursor) any
+}
+
+var (
+	sortKeyName      = sortKey{expr: "d.name", value: func(c *domain.DestinationListCursor) any { return c.Name }}
+	sortKeyID        = sortKey{expr: "d.id", value: func(c *domain.DestinationListCursor) any { return c.ID }}
+	sortKeyUpdatedAt = sortKey{expr: "d.updated_at", desc: true, value: func(c *domain.DestinationListCursor) any { return c.UpdatedAt }}
+)
 
-	builder.WriteString("\n\tORDER BY ")
+// publishedSortKeys lists the ORDER BY terms for filter.Sort. score is the
+// searchScore expression, empty when the sort cannot use one. Every sort
+// ends in d.id so the order is total, which keyset paging depends on.
+func publishedSortKeys(filter domain.DestinationListFilter, distance, score string) []sortKey {
+	rating := sortKey{
+		expr:      "COALESCE(AVG(r.rating)::float8, 0)",
+		aggregate: true,
+		value:     func(c *domain.DestinationListCursor) any { return c.Rating },
+	}
 	switch filter.Sort {
 	case domain.DestinationSortRatingAsc:
-		builder.WriteString("average_rating ASC, d.name ASC")
+		return []sortKey{rating, sortKeyName, sortKeyID}
 	case domain.DestinationSortRatingDesc:
-		builder.WriteString("average_rating DESC, d.name ASC")
+		rating.desc = true
+		return []sortKey{rating, sortKeyName, sortKeyID}
 	case domain.DestinationSortNameAsc:
-		builder.WriteString("d.name ASC")
+		return []sortKey{sortKeyName, sortKeyID}
 	case domain.DestinationSortNameDesc:
-		builder.WriteString("d.name DESC")
-	case domain.DestinationSortSimilarity:
-		trimmed := strings.TrimSpace(filter.Search)
-		if trimmed != "" && r.trigramAvailable {
-			placeholder := fmt.Sprintf("$%d", len(params)+1)
-			builder.WriteString("similarity(d.name, " + placeholder + ") DESC, d.name ASC")
-			params = append(params, trimmed)
-		} else {
-			builder.WriteString("d.updated_at DESC")
+		name, id := sortKeyName, sortKeyID
+		name.desc, id.desc = true, true
+		return []sortKey{name, id}
+	case domain.DestinationSortSimilarity, domain.DestinationSortRelevance:
+		if score != "" {
+			similarity := sortKey{expr: score, desc: true, value: func(c *domain.DestinationListCursor) any {
+				if c.Score == nil {
+					return 0.0
+				}
+				return *c.Score
+			}}
+			return []sortKey{similarity, sortKeyName, sortKeyID}
 		}
 	case domain.DestinationSortDistanceAsc:
 		if filter.Latitude != nil && filter.Longitude != nil {
-			latPlaceholder := fmt.Sprintf("$%d", len(params)+1)
-			lngPlaceholder := fmt.Sprintf("$%d", len(params)+2)
+			nearest := sortKey{expr: distance, nullable: true, value: func(c *domain.DestinationListCursor) any {
+				if c.Distance == nil {
+					return nil
+				}
+				return *c.Distance
+			}}
+			return []sortKey{nearest, sortKeyName, sortKeyID}
+		}
+	}
+	return []sortKey{sortKeyUpdatedAt, sortKeyName, sortKeyID}
+}
 
-			builder.WriteString(`
-				(6371 * acos(
-					cos(radians(` + latPlaceholder + `)) * cos(radians(d.latitude)) *
-					cos(radians(d.longitude) - radians(` + lngPlaceholder + `)) + 
-					sin(radians(` + latPlaceholder + `)) * sin(radians(d.latitude))
-				)) ASc, d.name ASC
-			`)
+// keysetCondition matches the rows that sort strictly after cursor, spelled
+// out term by term because the keys mix directions:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func keysetCondition(keys []sortKey, cursor *domain.DestinationListCursor, params *[]any) string {
+	equal := make([]string, 0, len(keys))
+	terms := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := key.value(cursor)
+		if value == nil {
+			// Nothing sorts after NULL within a NULLS LAST key; ties
+			// continue on the next key.
+			equal = append(equal, key.expr+" IS NULL")
+			continue
 		}
-	default:
-		builder.WriteString("d.updated_at DESC")
+		placeholder := fmt.Sprintf("$%d", len(*params)+1)
+		*params = append(*params, value)
+		operator := " > "
+		if key.desc {
+			operator = " < "
+		}
+		after := key.expr + operator + placeholder
+		if key.nullable {
+			after = "(" + after + " OR " + key.expr + " IS NULL)"
+		}
+		terms = append(terms, "("+strings.Join(append(slices.Clone(equal), after), " AND ")+")")
+		equal = append(equal, key.expr+" = "+placeholder)
+	}
+	if len(terms) == 0 {
+		return "FALSE"
 	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// bboxCondition matches destinations inside box using the same
+// point(longitude, latitude) expression as the GiST index from migration 0022.
+// A box crossing the antimeridian is split in two.
+func bboxCondition(box domain.DestinationBoundingBox, params *[]any) string {
+	within := func(minLng, maxLng float64) string {
+		start := len(*params) + 1
+		*params = append(*params, minLng, box.MinLat, maxLng, box.MaxLat)
+		return fmt.Sprintf("point(d.longitude, d.latitude) <@ box(point($%d, $%d), point($%d, $%d))", start, start+1, start+2, start+3)
+	}
+	if box.CrossesAntimeridian() {
+		return "(" + within(box.MinLng, 180) + " OR " + within(-180, box.MaxLng) + ")"
+	}
+	return within(box.MinLng, box.MaxLng)
+}
 
+// ListSimilarCandidates returns published destinations, other than dest, that
+// share its category or a tag or lie within radiusKM of it. The caller ranks
+// them; candidates come back best-rated first.
+func (r *DestinationRepository) ListSimilarCandidates(ctx context.Context, dest *domain.Destination, radiusKM float64, limit int) ([]domain.Destination, error) {
+	params := []any{dest.ID, nullString(dest.Category), pq.StringArray(dest.Tags)}
+	distance := "NULL::float8"
+	nearby := "FALSE"
+	if dest.Latitude != nil && dest.Longitude != nil {
+		distance = haversineKM("$4", "$5")
+		nearby = "d.latitude IS NOT NULL AND d.longitude IS NOT NULL AND " + distance + " <= $6"
+		params = append(params, *dest.Latitude, *dest.Longitude, radiusKM)
+	}
 	limitPlaceholder := fmt.Sprintf("$%d", len(params)+1)
-	offsetPlaceholder := fmt.Sprintf("$%d", len(params)+2)
-	builder.WriteString(`
-		LIMIT ` + limitPlaceholder + ` OFFSET ` + offsetPlaceholder + `
-	`)
-	params = append(params, limit, offset)
+	params = append(params, limit)
+
+	query := `
+		SELECT d.id, d.name, d.slug, d.status, d.version, d.city, d.country, d.category, d.description,
+		       d.latitude, d.longitude, d.contact, d.opening_time, d.closing_time, d.timezone, d.opening_hours,
+		       d.translations, d.tags, d.gallery, d.hero_image_url, d.created_at, d.updated_at, d.updated_by, d.deleted_at,
+		       COALESCE(AVG(r.rating)::float8, 0) AS average_rating,
+		       COUNT(r.id)::int AS review_count,
+		       ` + distance + ` AS distance_km
+		FROM travel_destination d
+		LEFT JOIN review r ON r.destination_id = d.id AND r.deleted_at IS NULL
+		WHERE d.status = 'published' AND d.deleted_at IS NULL AND d.id <> $1
+		  AND (
+			lower(d.category) = lower($2)
+			OR d.tags ?| $3
+			OR (` + nearby + `)
+		  )
+		GROUP BY d.id
+		ORDER BY average_rating DESC, d.updated_at DESC
+		LIMIT ` + limitPlaceholder
 
 	destinations := make([]domain.Destination, 0)
-	if err := r.db.SelectContext(ctx, &destinations, builder.String(), params...); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &destinations, query, params...); err != nil {
 		return nil, err
 	}
 	return destinations, nil
@@ -452,30 +969,44 @@ func (r *DestinationRepository) Autocomplete(ctx context.Context, query string,
 				similarity(country, $1) AS score
 			FROM travel_destination
 			WHERE status = 'published' AND country IS NOT NULL
+
+			UNION
+			SELECT
+				t.value->>'name' AS suggestion,
+				similarity(t.value->>'name', $1) AS score
+			FROM travel_destination, jsonb_each(COALESCE(translations, '{}'::jsonb)) AS t
+			WHERE status = 'published' AND t.value->>'name' IS NOT NULL
 		) AS s
-		WHERE suggestion ILIKE '%' || $1 || '%'
+		WHERE suggestion ILIKE ` + likeContains("$1") + `
 		ORDER BY score DESC
 		LIMIT $2;
 	`
 
 	var results []string
 	if r.trigramAvailable {
-		if err := r.db.SelectContext(ctx, &results, sql, args...); err != nil {
+		if err := conn(ctx, r.db).SelectContext(ctx, &results, sql, args...); err != nil {
 			return nil, err
 		}
 	}
 
 	if len(results) == 0 {
 		sql = `
-			SELECT name
-			FROM travel_destination
-			WHERE status = 'published'
-				AND name ILIKE '%' || $1 || '%'
-			ORDER BY name ASC
+			SELECT suggestion
+			FROM (
+				SELECT name AS suggestion
+				FROM travel_destination
+				WHERE status = 'published'
+				UNION
+				SELECT t.value->>'name'
+				FROM travel_destination, jsonb_each(COALESCE(translations, '{}'::jsonb)) AS t
+				WHERE status = 'published'
+			) AS s
+			WHERE suggestion ILIKE ` + likeContains("$1") + `
+			ORDER BY suggestion ASC
 			LIMIT $2
 		`
 
-		if err := r.db.SelectContext(ctx, &results, sql, args...); err != nil {
+		if err := conn(ctx, r.db).SelectContext(ctx, &results, sql, args...); err != nil {
 			return nil, err
 		}
 	}
@@ -526,9 +1057,41 @@ func galleryValue(ptr *domain.DestinationGallery) domain.DestinationGallery {
 	return append(domain.DestinationGallery(nil), (*ptr)...)
 }
 
+func openingHoursValue(ptr *domain.OpeningHours) domain.OpeningHours {
+	if ptr == nil {
+		return domain.OpeningHours{}
+	}
+	return *ptr
+}
+
+func translationsValue(ptr *domain.DestinationTranslations) domain.DestinationTranslations {
+	if ptr == nil {
+		return nil
+	}
+	return *ptr
+}
+
+// haversineKM is the great-circle distance in kilometres between the given
+// coordinates and a destination. The cosine is clamped so identical points
+// do not fall outside acos's domain through rounding.
+func haversineKM(latPlaceholder, lngPlaceholder string) string {
+	return `(6371 * acos(LEAST(1, GREATEST(-1,
+				cos(radians(` + latPlaceholder + `)) * cos(radians(d.latitude)) *
+				cos(radians(d.longitude) - radians(` + lngPlaceholder + `)) +
+				sin(radians(` + latPlaceholder + `)) * sin(radians(d.latitude))
+			))))`
+}
+
+func tagsValue(ptr *domain.DestinationTags) domain.DestinationTags {
+	if ptr == nil {
+		return nil
+	}
+	return *ptr
+}
+
 func (r *DestinationRepository) checkTrigramSupport(ctx context.Context) bool {
 	var available bool
-	if err := r.db.GetContext(ctx, &available, `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')`); err != nil {
+	if err := conn(ctx, r.db).GetContext(ctx, &available, `SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')`); err != nil {
 		log.Printf("destination repository: check pg_trgm support: %v", err)
 		return false
 	}
@@ -538,4 +1101,10 @@ func (r *DestinationRepository) checkTrigramSupport(ctx context.Context) bool {
 	return available
 }
 
+// likeContains builds an ILIKE pattern matching the text in param anywhere.
+// Wildcards and backslashes in the text are escaped so they match literally.
+func likeContains(param string) string {
+	return `'%' || replace(replace(replace(` + param + `, '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\'`
+}
+
 var _ ports.DestinationRepository = (*DestinationRepository)(nil)
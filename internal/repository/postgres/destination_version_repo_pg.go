@@ -20,20 +20,21 @@ func NewDestinationVersionRepo(db *sqlx.DB) *DestinationVersionRepository {
 
 func (r *DestinationVersionRepository) Create(ctx context.Context, version *domain.DestinationVersion) (*domain.DestinationVersion, error) {
 	const query = `
-		INSERT INTO destination_version (destination_id, change_request_id, version, snapshot, created_at, created_by)
-		VALUES (:destination_id, :change_request_id, :version, :snapshot, NOW(), :created_by)
-		RETURNING id, destination_id, change_request_id, version, snapshot, created_at, created_by
+		INSERT INTO destination_version (destination_id, change_request_id, version, source_version, snapshot, created_at, created_by)
+		VALUES (:destination_id, :change_request_id, :version, :source_version, :snapshot, NOW(), :created_by)
+		RETURNING id, destination_id, change_request_id, version, source_version, snapshot, created_at, created_by
 	`
 
 	args := map[string]any{
 		"destination_id":    version.DestinationID,
 		"change_request_id": nullableUUID(version.ChangeRequestID),
 		"version":           version.Version,
+		"source_version":    version.SourceVersion,
 		"snapshot":          version.Snapshot,
 		"created_by":        version.CreatedBy,
 	}
 
-	rows, err := r.db.NamedQueryContext(ctx, query, args)
+	rows, err := sqlx.NamedQueryContext(ctx, conn(ctx, r.db), query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -51,17 +52,30 @@ func (r *DestinationVersionRepository) Create(ctx context.Context, version *doma
 
 func (r *DestinationVersionRepository) ListByDestination(ctx context.Context, destinationID uuid.UUID, limit int) ([]domain.DestinationVersion, error) {
 	const query = `
-		SELECT id, destination_id, change_request_id, version, snapshot, created_at, created_by
+		SELECT id, destination_id, change_request_id, version, source_version, snapshot, created_at, created_by
 		FROM destination_version
 		WHERE destination_id = $1
 		ORDER BY version DESC
 		LIMIT $2
 	`
 	versions := make([]domain.DestinationVersion, 0)
-	if err := r.db.SelectContext(ctx, &versions, query, destinationID, limit); err != nil {
+	if err := conn(ctx, r.db).SelectContext(ctx, &versions, query, destinationID, limit); err != nil {
 		return nil, err
 	}
 	return versions, nil
 }
 
+func (r *DestinationVersionRepository) FindByVersion(ctx context.Context, destinationID uuid.UUID, version int64) (*domain.DestinationVersion, error) {
+	const query = `
+		SELECT id, destination_id, change_request_id, version, source_version, snapshot, created_at, created_by
+		FROM destination_version
+		WHERE destination_id = $1 AND version = $2
+	`
+	var record domain.DestinationVersion
+	if err := conn(ctx, r.db).GetContext(ctx, &record, query, destinationID, version); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
 var _ ports.DestinationVersionRepository = (*DestinationVersionRepository)(nil)
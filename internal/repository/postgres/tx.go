@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+type txKey struct{}
+
+// querier is what repositories need from either the pool or a transaction.
+type querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+// conn returns the transaction carried by ctx, or db when there is none, so a
+// repository call joins whatever transaction its caller opened.
+func conn(ctx context.Context, db *sqlx.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+type Transactor struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTx runs fn in a transaction, committing when it returns nil and rolling
+// back otherwise. Calls nested inside an open transaction reuse it.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	err = tx.Commit()
+	return err
+}
+
+var _ ports.Transactor = (*Transactor)(nil)
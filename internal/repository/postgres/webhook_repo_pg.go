@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+const (
+	webhookSubscriptionColumns = `id, url, description, events, secret, active, created_by, created_at, updated_at`
+	webhookDeliveryColumns     = `id, subscription_id, event, payload, status, attempts, next_attempt_at, last_error, response_status, replay_of, delivered_at, created_at, updated_at`
+)
+
+type WebhookSubscriptionRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookSubscriptionRepo(db *sqlx.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	query := `
+		INSERT INTO webhook_subscription (url, description, events, secret, active, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING ` + webhookSubscriptionColumns
+	var created domain.WebhookSubscription
+	if err := conn(ctx, r.db).GetContext(ctx, &created, query, subscription.URL, nullString(subscription.Description), subscription.Events, subscription.Secret, subscription.Active, subscription.CreatedBy); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	query := `
+		UPDATE webhook_subscription
+		SET url = $2, description = $3, events = $4, secret = $5, active = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + webhookSubscriptionColumns
+	var updated domain.WebhookSubscription
+	if err := conn(ctx, r.db).GetContext(ctx, &updated, query, subscription.ID, subscription.URL, nullString(subscription.Description), subscription.Events, subscription.Secret, subscription.Active); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM webhook_subscription WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription WHERE id = $1`
+	var subscription domain.WebhookSubscription
+	if err := conn(ctx, r.db).GetContext(ctx, &subscription, query, id); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription ORDER BY created_at ASC, id ASC`
+	subscriptions := make([]domain.WebhookSubscription, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &subscriptions, query); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookSubscriptionRepository) ListActiveForEvent(ctx context.Context, event domain.WebhookEvent) ([]domain.WebhookSubscription, error) {
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscription
+		WHERE active AND events @> jsonb_build_array($1::text)
+		ORDER BY created_at ASC, id ASC
+	`
+	subscriptions := make([]domain.WebhookSubscription, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &subscriptions, query, string(event)); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+type WebhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+func NewWebhookDeliveryRepo(db *sqlx.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	query := `
+		INSERT INTO webhook_delivery (subscription_id, event, payload, status, attempts, next_attempt_at, replay_of, created_at, updated_at)
+		VALUES ($1, $2, $3::jsonb, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING ` + webhookDeliveryColumns
+	var created domain.WebhookDelivery
+	if err := conn(ctx, r.db).GetContext(ctx, &created, query,
+		delivery.SubscriptionID,
+		string(delivery.Event),
+		string(delivery.Payload),
+		string(delivery.Status),
+		delivery.Attempts,
+		nullTime(delivery.NextAttemptAt),
+		nullableUUID(delivery.ReplayOf),
+	); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_delivery
+		SET next_attempt_at = $2, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM webhook_delivery
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+	deliveries := make([]domain.WebhookDelivery, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &deliveries, query, now, now.Add(lease), limit); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_delivery
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, response_status = $6, delivered_at = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + webhookDeliveryColumns
+	var responseStatus any
+	if delivery.ResponseStatus != nil {
+		responseStatus = *delivery.ResponseStatus
+	}
+	var updated domain.WebhookDelivery
+	if err := conn(ctx, r.db).GetContext(ctx, &updated, query,
+		delivery.ID,
+		string(delivery.Status),
+		delivery.Attempts,
+		nullTime(delivery.NextAttemptAt),
+		nullString(delivery.LastError),
+		responseStatus,
+		nullTime(delivery.DeliveredAt),
+	); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery WHERE id = $1`
+	var delivery domain.WebhookDelivery
+	if err := conn(ctx, r.db).GetContext(ctx, &delivery, query, id); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_delivery
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+	deliveries := make([]domain.WebhookDelivery, 0)
+	if err := conn(ctx, r.db).SelectContext(ctx, &deliveries, query, subscriptionID, limit, offset); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+var (
+	_ ports.WebhookSubscriptionRepository = (*WebhookSubscriptionRepository)(nil)
+	_ ports.WebhookDeliveryRepository     = (*WebhookDeliveryRepository)(nil)
+)
@@ -255,6 +255,10 @@ func (f *fakeStorage) Upload(ctx context.Context, bucket, objectName, contentTyp
 	return "https://storage/" + objectName, nil
 }
 
+func (f *fakeStorage) Delete(ctx context.Context, bucket, objectName string) error {
+	return nil
+}
+
 type fakePasswordResetRepo struct {
 	consumeCalls []uuid.UUID
 	consumeErr   error
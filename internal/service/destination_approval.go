@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// RequiredApprovals returns how many distinct reviewers must approve a change
+// with the given action before it is applied.
+func (s *DestinationWorkflowService) RequiredApprovals(action domain.DestinationChangeAction) int {
+	if !s.approvalRequired {
+		return 1
+	}
+	if count, ok := s.actionApprovals[action]; ok {
+		return count
+	}
+	return s.approvalsRequired
+}
+
+// ListApprovals returns every reviewer decision recorded for a change, oldest
+// first.
+func (s *DestinationWorkflowService) ListApprovals(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeApproval, error) {
+	if _, err := s.changes.FindByID(ctx, changeID); err != nil {
+		return nil, ErrDestinationChangeNotFound
+	}
+	return s.approvals.ListByChange(ctx, changeID)
+}
+
+// currentApprovals returns the approvals that count towards the change's
+// current submission: those recorded against its draft version since the last
+// rejection.
+func (s *DestinationWorkflowService) currentApprovals(ctx context.Context, change *domain.DestinationChangeRequest) ([]domain.DestinationChangeApproval, error) {
+	records, err := s.approvals.ListByChange(ctx, change.ID)
+	if err != nil {
+		return nil, err
+	}
+	round := make([]domain.DestinationChangeApproval, 0, len(records))
+	for _, record := range records {
+		if record.Decision == domain.DestinationApprovalDecisionRejected {
+			round = round[:0]
+			continue
+		}
+		if record.DraftVersion == change.DraftVersion {
+			round = append(round, record)
+		}
+	}
+	return round, nil
+}
+
+// recordDecision stores a reviewer's decision on the change's current draft
+// version. A second decision by the same reviewer on that version is
+// ErrAlreadyReviewed.
+func (s *DestinationWorkflowService) recordDecision(ctx context.Context, change *domain.DestinationChangeRequest, reviewerID uuid.UUID, decision domain.DestinationApprovalDecision, comment string) (*domain.DestinationChangeApproval, error) {
+	record, err := s.approvals.Create(ctx, &domain.DestinationChangeApproval{
+		ChangeRequestID: change.ID,
+		ReviewerID:      reviewerID,
+		Decision:        decision,
+		Comment:         stringPtr(strings.TrimSpace(comment)),
+		DraftVersion:    change.DraftVersion,
+		CreatedAt:       s.now(),
+	})
+	if isUniqueViolation(err) {
+		return nil, ErrAlreadyReviewed
+	}
+	return record, err
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// maxBulkReviewItems caps how many change requests one bulk review touches.
+const maxBulkReviewItems = 200
+
+type BulkReviewDecision string
+
+const (
+	BulkReviewApprove BulkReviewDecision = "approve"
+	BulkReviewReject  BulkReviewDecision = "reject"
+)
+
+// BulkReviewInput selects change requests either by ID or by filter. When
+// ChangeIDs is empty the filter is used and limited to pending_review changes.
+// EnabledActions, when set, restricts which actions may be reviewed.
+type BulkReviewInput struct {
+	ChangeIDs      []uuid.UUID
+	Filter         domain.DestinationChangeFilter
+	Decision       BulkReviewDecision
+	Message        string
+	EnabledActions []domain.DestinationChangeAction
+}
+
+type BulkReviewItem struct {
+	ChangeID      uuid.UUID                      `json:"change_id"`
+	Success       bool                           `json:"success"`
+	Status        domain.DestinationChangeStatus `json:"status,omitempty"`
+	DestinationID *uuid.UUID                     `json:"destination_id,omitempty"`
+	Error         string                         `json:"error,omitempty"`
+}
+
+// BulkReviewResult reports each reviewed change. Truncated is set when a
+// filter matched more than maxBulkReviewItems changes; Remaining counts the
+// matches left for a later call.
+type BulkReviewResult struct {
+	Items        []BulkReviewItem `json:"items"`
+	Succeeded    int              `json:"succeeded"`
+	Failed       int              `json:"failed"`
+	AllSucceeded bool             `json:"all_succeeded"`
+	Truncated    bool             `json:"truncated"`
+	Remaining    int              `json:"remaining"`
+}
+
+// BulkReview approves or rejects many change requests. Each change is handled
+// on its own, so one failure does not stop or undo the others.
+func (s *DestinationWorkflowService) BulkReview(ctx context.Context, reviewerID uuid.UUID, input BulkReviewInput) (*BulkReviewResult, error) {
+	if input.Decision != BulkReviewApprove && input.Decision != BulkReviewReject {
+		return nil, fmt.Errorf("%w: decision must be approve or reject", ErrDestinationChangeValidation)
+	}
+
+	ids, remaining, err := s.bulkReviewTargets(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[domain.DestinationChangeAction]struct{}, len(input.EnabledActions))
+	for _, action := range input.EnabledActions {
+		enabled[action] = struct{}{}
+	}
+
+	result := &BulkReviewResult{
+		Items:     make([]BulkReviewItem, 0, len(ids)),
+		Truncated: remaining > 0,
+		Remaining: remaining,
+	}
+	for _, id := range ids {
+		item := s.bulkReviewOne(ctx, reviewerID, id, input, enabled)
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+		result.Items = append(result.Items, item)
+	}
+	result.AllSucceeded = result.Failed == 0
+	return result, nil
+}
+
+// bulkReviewTargets resolves the changes to review and, for filters, how many
+// further matches were left out by the per-call cap.
+func (s *DestinationWorkflowService) bulkReviewTargets(ctx context.Context, input BulkReviewInput) ([]uuid.UUID, int, error) {
+	if len(input.ChangeIDs) > 0 {
+		if len(input.ChangeIDs) > maxBulkReviewItems {
+			return nil, 0, fmt.Errorf("%w: at most %d change ids per request", ErrDestinationChangeValidation, maxBulkReviewItems)
+		}
+		seen := make(map[uuid.UUID]struct{}, len(input.ChangeIDs))
+		ids := make([]uuid.UUID, 0, len(input.ChangeIDs))
+		for _, id := range input.ChangeIDs {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+		return ids, 0, nil
+	}
+
+	filter := input.Filter
+	if filter.ImportJobID == nil && filter.SubmittedBy == nil && len(filter.Actions) == 0 {
+		return nil, 0, fmt.Errorf("%w: change_ids or a filter is required", ErrDestinationChangeValidation)
+	}
+	filter.Statuses = []domain.DestinationChangeStatus{domain.DestinationChangeStatusPendingReview}
+	filter.Limit = maxBulkReviewItems
+	filter.Offset = 0
+	changes, err := s.changes.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]uuid.UUID, 0, len(changes))
+	for _, change := range changes {
+		ids = append(ids, change.ID)
+	}
+	remaining := 0
+	if len(changes) > 0 {
+		remaining = max(changes[0].TotalCount-len(changes), 0)
+	}
+	return ids, remaining, nil
+}
+
+func (s *DestinationWorkflowService) bulkReviewOne(ctx context.Context, reviewerID, changeID uuid.UUID, input BulkReviewInput, enabled map[domain.DestinationChangeAction]struct{}) BulkReviewItem {
+	item := BulkReviewItem{ChangeID: changeID}
+	fail := func(err error) BulkReviewItem {
+		item.Error = err.Error()
+		return item
+	}
+
+	change, err := s.changes.FindByID(ctx, changeID)
+	if err != nil {
+		return fail(ErrDestinationChangeNotFound)
+	}
+	if len(enabled) > 0 {
+		if _, ok := enabled[change.Action]; !ok {
+			return fail(fmt.Errorf("%w: %s changes are disabled", ErrForbidden, change.Action))
+		}
+	}
+
+	switch input.Decision {
+	case BulkReviewApprove:
+		var dest *domain.Destination
+		change, dest, err = s.Approve(ctx, changeID, reviewerID, strings.TrimSpace(input.Message))
+		if err != nil {
+			return fail(err)
+		}
+		if dest != nil {
+			item.DestinationID = &dest.ID
+		}
+	case BulkReviewReject:
+		change, err = s.Reject(ctx, changeID, reviewerID, input.Message)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	item.Success = true
+	item.Status = change.Status
+	return item
+}
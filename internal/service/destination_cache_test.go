@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/cache"
+)
+
+func TestDestinationWorkflowService_InvalidatesCachedReads(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 23, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	cached := cache.NewDestinationCacheRepo(destRepo, nil, cache.DestinationCacheConfig{MaxEntries: 10})
+	workflow := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		Cache: cached,
+	})
+	workflow.SetClock(func() time.Time { return now })
+	reads := NewDestinationService(cached, DestinationServiceConfig{})
+	admin := uuid.New()
+	approve := func(input DestinationDraftInput) *domain.Destination {
+		t.Helper()
+		change, err := workflow.CreateDraft(ctx, admin, input)
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		if _, err = workflow.SubmitDraft(ctx, change.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		_, dest, err := workflow.Approve(ctx, change.ID, uuid.New(), "")
+		if err != nil {
+			t.Fatalf("Approve: %v", err)
+		}
+		return dest
+	}
+
+	dest := approve(DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Wat Pho")},
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := reads.GetPublishedByID(ctx, dest.ID); err != nil {
+			t.Fatalf("GetPublishedByID: %v", err)
+		}
+		if _, err := reads.ListPublished(ctx, 20, 0, domain.DestinationListFilter{}); err != nil {
+			t.Fatalf("ListPublished: %v", err)
+		}
+	}
+	if stats := cached.Stats(); stats.Hits != 2 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Fatalf("expected the second reads to hit, got %+v", stats)
+	}
+
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &dest.ID,
+		Fields:        domain.DestinationChangeFields{Name: strPtr("Wat Pho Temple")},
+	})
+	got, err := reads.GetPublishedByID(ctx, dest.ID)
+	if err != nil || got.Name != "Wat Pho Temple" {
+		t.Fatalf("expected the approved name after invalidation, got %v, %v", got, err)
+	}
+	listed, err := reads.ListPublished(ctx, 20, 0, domain.DestinationListFilter{})
+	if err != nil || len(listed) != 1 || listed[0].Name != "Wat Pho Temple" {
+		t.Fatalf("expected listings invalidated, got %v, %v", destinationNames(listed), err)
+	}
+
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionDelete,
+		DestinationID: &dest.ID,
+	})
+	if _, err := reads.GetPublishedByID(ctx, dest.ID); err == nil {
+		t.Fatalf("expected a deleted destination to stop being served")
+	}
+}
@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime"
 	"path/filepath"
 	"regexp"
@@ -34,6 +36,10 @@ var (
 	ErrGalleryImageTooLarge        = errors.New("gallery image exceeds maximum size")
 	ErrGalleryImageUnsupportedType = errors.New("unsupported gallery image content type")
 	ErrChangeAlreadyProcessed      = errors.New("change request already processed")
+	ErrDestinationVersionNotFound  = errors.New("destination version not found")
+	ErrChangeConflict              = errors.New("destination changed since draft was created")
+	ErrAlreadyReviewed             = errors.New("reviewer already recorded a decision for this submission")
+	ErrDestinationSlugTaken        = errors.New("destination slug already in use")
 	errDefaultImageContentType     = "image/jpeg"
 	supportedImageTypes            = map[string]struct{}{
 		"image/jpeg": {},
@@ -64,6 +70,22 @@ type GalleryUploadResult struct {
 	Ordering int
 }
 
+// ChangeConflictError reports the fields an update draft would overwrite after
+// the destination moved past the version the draft was based on.
+type ChangeConflictError struct {
+	BaseVersion    int64
+	CurrentVersion int64
+	Conflicts      []domain.DestinationFieldConflict
+}
+
+func (e *ChangeConflictError) Error() string {
+	return fmt.Sprintf("%s: based on version %d, current version is %d", ErrChangeConflict.Error(), e.BaseVersion, e.CurrentVersion)
+}
+
+func (e *ChangeConflictError) Unwrap() error {
+	return ErrChangeConflict
+}
+
 type DestinationDraftInput struct {
 	Action        domain.DestinationChangeAction
 	DestinationID *uuid.UUID
@@ -79,12 +101,34 @@ type DestinationWorkflowConfig struct {
 	ApprovalRequired  bool
 	HardDeleteAllowed bool
 	ImageProcessor    media.Processor
+	// ApprovalsRequired is the number of distinct reviewers that must approve a
+	// change before it is applied. ActionApprovals overrides it per action.
+	ApprovalsRequired int
+	ActionApprovals   map[domain.DestinationChangeAction]int
+	// DefaultLocale is the language of the base name and description;
+	// SupportedLocales limits which translations drafts may carry.
+	DefaultLocale    string
+	SupportedLocales []string
+	// Categories is the managed taxonomy. When it holds any categories they
+	// replace AllowedCategories for validation.
+	Categories ports.DestinationCategoryRepository
+	// SearchIndex, when set, is refreshed for every applied change; optional.
+	SearchIndex ports.DestinationSearchIndex
+	// Cache, when set, is invalidated for every applied change; optional.
+	Cache ports.DestinationCache
+	// Webhooks, when set, is told about applied changes that partners can
+	// see; optional.
+	Webhooks WebhookPublisher
+	// Transactor, when set, runs each review decision and the change it
+	// applies in one transaction; optional.
+	Transactor ports.Transactor
 }
 
 type DestinationWorkflowService struct {
 	destinations ports.DestinationRepository
 	changes      ports.DestinationChangeRepository
 	versions     ports.DestinationVersionRepository
+	approvals    ports.DestinationApprovalRepository
 	storage      ports.ObjectStorage
 
 	bucket            string
@@ -92,13 +136,21 @@ type DestinationWorkflowService struct {
 	imageMaxBytes     int64
 	imageMaxDimension int
 	allowedCategories map[string]struct{}
+	categories        ports.DestinationCategoryRepository
 	approvalRequired  bool
+	approvalsRequired int
+	actionApprovals   map[domain.DestinationChangeAction]int
 	hardDeleteAllowed bool
+	locales           localeSet
 	now               func() time.Time
 	imageProcessor    media.Processor
+	searchIndex       ports.DestinationSearchIndex
+	cache             ports.DestinationCache
+	webhooks          WebhookPublisher
+	transactor        ports.Transactor
 }
 
-func NewDestinationWorkflowService(destRepo ports.DestinationRepository, changeRepo ports.DestinationChangeRepository, versionRepo ports.DestinationVersionRepository, storage ports.ObjectStorage, cfg DestinationWorkflowConfig) *DestinationWorkflowService {
+func NewDestinationWorkflowService(destRepo ports.DestinationRepository, changeRepo ports.DestinationChangeRepository, versionRepo ports.DestinationVersionRepository, approvalRepo ports.DestinationApprovalRepository, storage ports.ObjectStorage, cfg DestinationWorkflowConfig) *DestinationWorkflowService {
 	allowed := make(map[string]struct{}, len(cfg.AllowedCategories))
 	for _, cat := range cfg.AllowedCategories {
 		trimmed := strings.ToLower(strings.TrimSpace(cat))
@@ -115,21 +167,40 @@ func NewDestinationWorkflowService(destRepo ports.DestinationRepository, changeR
 	if maxDimension <= 0 {
 		maxDimension = media.DefaultMaxDimension
 	}
+	approvalsRequired := cfg.ApprovalsRequired
+	if approvalsRequired <= 0 {
+		approvalsRequired = 1
+	}
+	actionApprovals := make(map[domain.DestinationChangeAction]int, len(cfg.ActionApprovals))
+	for action, count := range cfg.ActionApprovals {
+		if count > 0 {
+			actionApprovals[action] = count
+		}
+	}
 
 	return &DestinationWorkflowService{
 		destinations:      destRepo,
 		changes:           changeRepo,
 		versions:          versionRepo,
+		approvals:         approvalRepo,
 		storage:           storage,
 		bucket:            cfg.Bucket,
 		publicBase:        publicBase,
 		imageMaxBytes:     imageMax,
 		imageMaxDimension: maxDimension,
 		allowedCategories: allowed,
+		categories:        cfg.Categories,
 		approvalRequired:  cfg.ApprovalRequired,
+		approvalsRequired: approvalsRequired,
+		actionApprovals:   actionApprovals,
 		hardDeleteAllowed: cfg.HardDeleteAllowed,
+		locales:           newLocaleSet(cfg.DefaultLocale, cfg.SupportedLocales),
 		now:               time.Now,
 		imageProcessor:    cfg.ImageProcessor,
+		searchIndex:       cfg.SearchIndex,
+		cache:             cfg.Cache,
+		webhooks:          cfg.Webhooks,
+		transactor:        cfg.Transactor,
 	}
 }
 
@@ -143,6 +214,8 @@ func (s *DestinationWorkflowService) CreateDraft(ctx context.Context, authorID u
 	if err := s.validateDraftInput(ctx, authorID, input, true); err != nil {
 		return nil, err
 	}
+	input.Fields.SourceVersion = nil
+	input.Fields.RemovedMedia = nil
 
 	change := &domain.DestinationChangeRequest{
 		ID:            uuid.Nil,
@@ -155,10 +228,23 @@ func (s *DestinationWorkflowService) CreateDraft(ctx context.Context, authorID u
 		CreatedAt:     s.now(),
 		UpdatedAt:     s.now(),
 	}
+	change.Payload.Tags = normalizeTagsPtr(change.Payload.Tags)
+	change.Payload.OpeningHours = normalizeOpeningHoursPtr(change.Payload.OpeningHours)
+	if input.DestinationID != nil {
+		dest, err := s.destinations.FindByID(ctx, *input.DestinationID)
+		if err != nil {
+			return nil, ErrDestinationNotFound
+		}
+		baseVersion := dest.Version
+		change.BaseVersion = &baseVersion
+	}
 
 	if !s.isDeleteAction(input.Action) {
 		requireAll := input.Action == domain.DestinationChangeActionCreate
-		if err := s.validateFields(input.Action, input.Fields, requireAll); err != nil {
+		if err := s.validateFields(ctx, input.Action, change.Payload, requireAll); err != nil {
+			return nil, err
+		}
+		if err := s.ensureFieldSlugAvailable(ctx, input.Action, input.DestinationID, change.Payload); err != nil {
 			return nil, err
 		}
 	}
@@ -181,14 +267,21 @@ func (s *DestinationWorkflowService) UpdateDraft(ctx context.Context, changeID u
 		return nil, fmt.Errorf("%w: stale draft version", ErrInvalidChangeState)
 	}
 
+	fields.Tags = normalizeTagsPtr(fields.Tags)
+	fields.OpeningHours = normalizeOpeningHoursPtr(fields.OpeningHours)
 	if !s.isDeleteAction(change.Action) {
-		if err := s.validateFields(change.Action, fields, change.Action == domain.DestinationChangeActionCreate); err != nil {
+		if err := s.validateFields(ctx, change.Action, fields, change.Action == domain.DestinationChangeActionCreate); err != nil {
+			return nil, err
+		}
+		if err := s.ensureFieldSlugAvailable(ctx, change.Action, change.DestinationID, fields); err != nil {
 			return nil, err
 		}
 	} else if fields.HardDelete != nil && *fields.HardDelete && !s.hardDeleteAllowed {
 		return nil, ErrHardDeleteNotAllowed
 	}
 
+	fields.SourceVersion = change.Payload.SourceVersion
+	fields.RemovedMedia = change.Payload.RemovedMedia
 	change.Payload = fields
 	change.DraftVersion++
 	change.UpdatedAt = s.now()
@@ -207,7 +300,7 @@ func (s *DestinationWorkflowService) SubmitDraft(ctx context.Context, changeID u
 		return nil, ErrInvalidChangeState
 	}
 	if !s.isDeleteAction(change.Action) {
-		if err := s.validateFields(change.Action, change.Payload, change.Action == domain.DestinationChangeActionCreate); err != nil {
+		if err := s.validateFields(ctx, change.Action, change.Payload, change.Action == domain.DestinationChangeActionCreate); err != nil {
 			return nil, err
 		}
 	}
@@ -216,74 +309,287 @@ func (s *DestinationWorkflowService) SubmitDraft(ctx context.Context, changeID u
 	return s.changes.MarkSubmitted(ctx, change.ID, now)
 }
 
-func (s *DestinationWorkflowService) Approve(ctx context.Context, changeID uuid.UUID, reviewerID uuid.UUID) (*domain.DestinationChangeRequest, *domain.Destination, error) {
+// WithdrawSubmission moves a pending submission back to draft so its author
+// can keep editing. Approvals collected for the submission no longer count.
+func (s *DestinationWorkflowService) WithdrawSubmission(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error) {
 	change, err := s.changes.FindByID(ctx, changeID)
 	if err != nil {
-		return nil, nil, ErrDestinationChangeNotFound
+		return nil, ErrDestinationChangeNotFound
+	}
+	if change.SubmittedBy != authorID {
+		return nil, ErrForbidden
 	}
 	if change.Status != domain.DestinationChangeStatusPendingReview {
-		return nil, nil, ErrInvalidChangeState
+		return nil, ErrInvalidChangeState
 	}
-	if s.approvalRequired && change.SubmittedBy == reviewerID {
-		return nil, nil, ErrReviewerConflict
+
+	change.Status = domain.DestinationChangeStatusDraft
+	change.SubmittedAt = nil
+	change.DraftVersion++
+	change.UpdatedAt = s.now()
+	return s.changes.Update(ctx, change)
+}
+
+// ReopenRejected moves a rejected change back to draft. The reviewer's message
+// and identity are kept so the author can address the feedback.
+func (s *DestinationWorkflowService) ReopenRejected(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error) {
+	change, err := s.changes.FindByID(ctx, changeID)
+	if err != nil {
+		return nil, ErrDestinationChangeNotFound
 	}
-	if !s.isDeleteAction(change.Action) {
-		if err := s.validateFields(change.Action, change.Payload, change.Action == domain.DestinationChangeActionCreate); err != nil {
-			return nil, nil, err
-		}
+	if change.SubmittedBy != authorID {
+		return nil, ErrForbidden
 	}
-
-	var destination *domain.Destination
-	switch change.Action {
-	case domain.DestinationChangeActionCreate:
-		destination, err = s.applyCreate(ctx, change, reviewerID)
-	case domain.DestinationChangeActionUpdate:
-		destination, err = s.applyUpdate(ctx, change, reviewerID)
-	case domain.DestinationChangeActionDelete:
-		destination, err = s.applyDelete(ctx, change, reviewerID)
-	default:
-		return nil, nil, ErrInvalidChangeAction
+	if change.Status != domain.DestinationChangeStatusRejected {
+		return nil, ErrInvalidChangeState
 	}
+
+	change.Status = domain.DestinationChangeStatusDraft
+	change.DraftVersion++
+	change.UpdatedAt = s.now()
+	return s.changes.Update(ctx, change)
+}
+
+// DiscardDraft deletes an unsubmitted or rejected change together with the
+// media uploaded for it. Storage failures are logged rather than returned,
+// since the change itself is already gone.
+func (s *DestinationWorkflowService) DiscardDraft(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID) error {
+	change, err := s.changes.FindByID(ctx, changeID)
 	if err != nil {
-		return nil, nil, err
+		return ErrDestinationChangeNotFound
+	}
+	if change.SubmittedBy != authorID {
+		return ErrForbidden
+	}
+	if change.Status != domain.DestinationChangeStatusDraft && change.Status != domain.DestinationChangeStatusRejected {
+		return ErrInvalidChangeState
 	}
 
-	now := s.now()
-	change.Status = domain.DestinationChangeStatusApproved
-	change.ReviewedAt = &now
-	change.ReviewedBy = &reviewerID
-	change.UpdatedAt = now
-	if destination != nil {
-		change.PublishedVersion = &destination.Version
+	keys := stagedObjectKeys(change)
+	if err := s.changes.DeleteByID(ctx, change.ID); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, s.bucket, key); err != nil {
+			log.Printf("destination change %s: delete staged object %s: %v", change.ID, key, err)
+		}
 	}
+	return nil
+}
+
+// Approve records the reviewer's approval and applies the change once the
+// approval policy for its action is satisfied. Until then the change stays in
+// pending_review and no destination is returned. The decision, the applied
+// change, the status update and the queued webhook event commit together.
+func (s *DestinationWorkflowService) Approve(ctx context.Context, changeID uuid.UUID, reviewerID uuid.UUID, comment string) (*domain.DestinationChangeRequest, *domain.Destination, error) {
+	var (
+		change      *domain.DestinationChangeRequest
+		destination *domain.Destination
+		removed     []string
+		applied     bool
+	)
+	err := s.withinTx(ctx, func(ctx context.Context) error {
+		var err error
+		change, err = s.changes.FindByIDForUpdate(ctx, changeID)
+		if err != nil {
+			return ErrDestinationChangeNotFound
+		}
+		if change.Status != domain.DestinationChangeStatusPendingReview {
+			return ErrInvalidChangeState
+		}
+		approved := 0
+		if s.approvalRequired {
+			if change.SubmittedBy == reviewerID {
+				return ErrReviewerConflict
+			}
+			round, err := s.currentApprovals(ctx, change)
+			if err != nil {
+				return err
+			}
+			for _, approval := range round {
+				if approval.ReviewerID == reviewerID {
+					return ErrAlreadyReviewed
+				}
+			}
+			approved = len(round)
+		}
+		if !s.isDeleteAction(change.Action) {
+			if err := s.validateFields(ctx, change.Action, change.Payload, change.Action == domain.DestinationChangeActionCreate); err != nil {
+				return err
+			}
+			if err := s.ensureFieldSlugAvailable(ctx, change.Action, change.DestinationID, change.Payload); err != nil {
+				return err
+			}
+		}
+		if _, err := s.recordDecision(ctx, change, reviewerID, domain.DestinationApprovalDecisionApproved, comment); err != nil {
+			return err
+		}
+		if s.approvalRequired && approved+1 < s.RequiredApprovals(change.Action) {
+			return nil
+		}
+
+		before := s.webhookSubject(ctx, change)
+		switch change.Action {
+		case domain.DestinationChangeActionCreate:
+			destination, err = s.applyCreate(ctx, change, reviewerID)
+		case domain.DestinationChangeActionUpdate:
+			destination, err = s.applyUpdate(ctx, change, reviewerID)
+		case domain.DestinationChangeActionDelete:
+			destination, err = s.applyDelete(ctx, change, reviewerID)
+		case domain.DestinationChangeActionRestore:
+			destination, err = s.applyRestore(ctx, change, reviewerID)
+		default:
+			return ErrInvalidChangeAction
+		}
+		if err != nil {
+			return err
+		}
 
-	change, err = s.changes.SetStatus(ctx, change.ID, change.Status, change.ReviewedBy, change.ReviewMessage, change.PublishedVersion)
+		now := s.now()
+		change.Status = domain.DestinationChangeStatusApproved
+		change.ReviewedAt = &now
+		change.ReviewedBy = &reviewerID
+		change.UpdatedAt = now
+		if destination != nil {
+			change.PublishedVersion = &destination.Version
+		}
+		removed = change.Payload.RemovedMedia
+		change, err = s.changes.SetStatus(ctx, change.ID, change.Status, change.ReviewedBy, change.ReviewMessage, change.PublishedVersion)
+		if err != nil {
+			return err
+		}
+		if err := s.publishChange(ctx, change, before, destination); err != nil {
+			return err
+		}
+		applied = true
+		return nil
+	})
 	if err != nil {
 		return nil, nil, err
 	}
+	if !applied {
+		return change, nil, nil
+	}
+
+	s.deleteRemovedMedia(ctx, change.ID, removed, destination)
+	s.refreshReads(ctx, change, destination)
 
 	return change, destination, nil
 }
 
+// withinTx runs fn in a transaction when a transactor is configured, and
+// directly otherwise.
+func (s *DestinationWorkflowService) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactor == nil {
+		return fn(ctx)
+	}
+	return s.transactor.WithinTx(ctx, fn)
+}
+
+// refreshReads brings the search index and the read cache in step with the
+// destination an approved change applied to. The index goes first so the
+// cache cannot refill from stale search results. Failures are logged: the
+// change is already applied, and the next approval, a rebuild or the cache
+// TTL catches up.
+func (s *DestinationWorkflowService) refreshReads(ctx context.Context, change *domain.DestinationChangeRequest, destination *domain.Destination) {
+	var id uuid.UUID
+	switch {
+	case destination != nil:
+		id = destination.ID
+	case change.DestinationID != nil:
+		id = *change.DestinationID
+	default:
+		return
+	}
+	if s.searchIndex != nil {
+		if err := s.searchIndex.Reindex(ctx, id); err != nil {
+			log.Printf("destination change %s: reindex destination %s: %v", change.ID, id, err)
+		}
+	}
+	if s.cache != nil {
+		if err := s.cache.Invalidate(ctx, id); err != nil {
+			log.Printf("destination change %s: invalidate cached destination %s: %v", change.ID, id, err)
+		}
+	}
+}
+
 func (s *DestinationWorkflowService) Reject(ctx context.Context, changeID uuid.UUID, reviewerID uuid.UUID, message string) (*domain.DestinationChangeRequest, error) {
+	var rejected *domain.DestinationChangeRequest
+	err := s.withinTx(ctx, func(ctx context.Context) error {
+		change, err := s.changes.FindByIDForUpdate(ctx, changeID)
+		if err != nil {
+			return ErrDestinationChangeNotFound
+		}
+		if change.Status != domain.DestinationChangeStatusPendingReview {
+			return ErrInvalidChangeState
+		}
+		if s.approvalRequired && change.SubmittedBy == reviewerID {
+			return ErrReviewerConflict
+		}
+		if _, err := s.recordDecision(ctx, change, reviewerID, domain.DestinationApprovalDecisionRejected, message); err != nil {
+			return err
+		}
+		now := s.now()
+		change.Status = domain.DestinationChangeStatusRejected
+		change.ReviewedAt = &now
+		change.ReviewedBy = &reviewerID
+		change.ReviewMessage = stringPtr(strings.TrimSpace(message))
+		change.UpdatedAt = now
+
+		rejected, err = s.changes.SetStatus(ctx, change.ID, change.Status, change.ReviewedBy, change.ReviewMessage, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rejected, nil
+}
+
+// RebaseDraft moves an update draft onto the destination's latest version.
+// Edits to fields that were also changed upstream are dropped in favour of the
+// published value; every other edit is kept. The dropped fields are returned.
+func (s *DestinationWorkflowService) RebaseDraft(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID) (*domain.DestinationChangeRequest, []domain.DestinationFieldConflict, error) {
 	change, err := s.changes.FindByID(ctx, changeID)
 	if err != nil {
-		return nil, ErrDestinationChangeNotFound
+		return nil, nil, ErrDestinationChangeNotFound
 	}
-	if change.Status != domain.DestinationChangeStatusPendingReview {
-		return nil, ErrInvalidChangeState
+	if change.SubmittedBy != authorID {
+		return nil, nil, ErrForbidden
 	}
-	if s.approvalRequired && change.SubmittedBy == reviewerID {
-		return nil, ErrReviewerConflict
+	if change.Action != domain.DestinationChangeActionUpdate || change.DestinationID == nil {
+		return nil, nil, fmt.Errorf("%w: only update changes can be rebased", ErrInvalidChangeState)
+	}
+	switch change.Status {
+	case domain.DestinationChangeStatusDraft, domain.DestinationChangeStatusRejected, domain.DestinationChangeStatusPendingReview:
+	default:
+		return nil, nil, ErrChangeNotEditable
 	}
-	now := s.now()
-	change.Status = domain.DestinationChangeStatusRejected
-	change.ReviewedAt = &now
-	change.ReviewedBy = &reviewerID
-	change.ReviewMessage = stringPtr(strings.TrimSpace(message))
-	change.UpdatedAt = now
 
-	return s.changes.SetStatus(ctx, change.ID, change.Status, change.ReviewedBy, change.ReviewMessage, nil)
+	dest, err := s.destinations.FindByID(ctx, *change.DestinationID)
+	if err != nil {
+		return nil, nil, ErrDestinationNotFound
+	}
+	if change.BaseVersion != nil && *change.BaseVersion == dest.Version {
+		return change, []domain.DestinationFieldConflict{}, nil
+	}
+
+	conflicts, err := s.detectConflicts(ctx, change, dest)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, conflict := range conflicts {
+		clearChangeField(&change.Payload, conflict.Field)
+	}
+
+	baseVersion := dest.Version
+	change.BaseVersion = &baseVersion
+	change.DraftVersion++
+	change.UpdatedAt = s.now()
+	updated, err := s.changes.Update(ctx, change)
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, conflicts, nil
 }
 
 func (s *DestinationWorkflowService) GetChange(ctx context.Context, changeID uuid.UUID) (*domain.DestinationChangeRequest, error) {
@@ -304,6 +610,81 @@ func (s *DestinationWorkflowService) ListChanges(ctx context.Context, filter dom
 	return s.changes.List(ctx, filter)
 }
 
+func (s *DestinationWorkflowService) ListVersions(ctx context.Context, destinationID uuid.UUID, limit int) ([]domain.DestinationVersion, error) {
+	if _, err := s.destinations.FindByID(ctx, destinationID); err != nil {
+		return nil, ErrDestinationNotFound
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.versions.ListByDestination(ctx, destinationID, limit)
+}
+
+// Rollback restores a destination to a previously published snapshot. The
+// snapshot is replayed as an update change request so it follows the normal
+// review flow; when approval is not required the change is applied directly.
+func (s *DestinationWorkflowService) Rollback(ctx context.Context, destinationID uuid.UUID, targetVersion int64, actorID uuid.UUID) (*domain.DestinationChangeRequest, *domain.Destination, error) {
+	dest, err := s.destinations.FindByID(ctx, destinationID)
+	if err != nil {
+		return nil, nil, ErrDestinationNotFound
+	}
+	if dest.IsArchived() || dest.DeletedAt != nil {
+		return nil, nil, fmt.Errorf("%w: destination is archived", ErrInvalidChangeState)
+	}
+	if targetVersion <= 0 || targetVersion >= dest.Version {
+		return nil, nil, fmt.Errorf("%w: version must be earlier than the current version %d", ErrDestinationChangeValidation, dest.Version)
+	}
+
+	record, err := s.findVersion(ctx, destinationID, targetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := changeFieldsFromSnapshot(record.Snapshot)
+	fields.SourceVersion = &record.Version
+	if err := s.validateFields(ctx, domain.DestinationChangeActionUpdate, fields, false); err != nil {
+		return nil, nil, err
+	}
+
+	now := s.now()
+	change, err := s.changes.Create(ctx, &domain.DestinationChangeRequest{
+		ID:            uuid.Nil,
+		DestinationID: &dest.ID,
+		Action:        domain.DestinationChangeActionUpdate,
+		Payload:       fields,
+		Status:        domain.DestinationChangeStatusDraft,
+		DraftVersion:  1,
+		SubmittedBy:   actorID,
+		BaseVersion:   &dest.Version,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	change, err = s.changes.MarkSubmitted(ctx, change.ID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.approvalRequired {
+		return change, nil, nil
+	}
+	return s.Approve(ctx, change.ID, actorID, "")
+}
+
+// findVersion loads a stored snapshot. Only a missing version is reported as
+// ErrDestinationVersionNotFound; other failures are returned as they are.
+func (s *DestinationWorkflowService) findVersion(ctx context.Context, destinationID uuid.UUID, version int64) (*domain.DestinationVersion, error) {
+	record, err := s.versions.FindByVersion(ctx, destinationID, version)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && record == nil) {
+		return nil, ErrDestinationVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
 func (s *DestinationWorkflowService) UploadHeroImage(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, image HeroImageUpload) (*domain.DestinationChangeRequest, error) {
 	if image.Size <= 0 || image.Reader == nil {
 		return nil, fmt.Errorf("%w: empty upload", ErrHeroImageRequired)
@@ -469,7 +850,8 @@ func (s *DestinationWorkflowService) UploadGalleryImages(ctx context.Context, ch
 func (s *DestinationWorkflowService) validateDraftInput(ctx context.Context, authorID uuid.UUID, input DestinationDraftInput, creating bool) error {
 	if input.Action != domain.DestinationChangeActionCreate &&
 		input.Action != domain.DestinationChangeActionUpdate &&
-		input.Action != domain.DestinationChangeActionDelete {
+		input.Action != domain.DestinationChangeActionDelete &&
+		input.Action != domain.DestinationChangeActionRestore {
 		return ErrInvalidChangeAction
 	}
 
@@ -493,14 +875,23 @@ func (s *DestinationWorkflowService) validateDraftInput(ctx context.Context, aut
 	} else if input.DestinationID == nil {
 		return fmt.Errorf("%w: destination_id required", ErrDestinationChangeValidation)
 	} else {
-		if _, err := s.destinations.FindByID(ctx, *input.DestinationID); err != nil {
+		dest, err := s.destinations.FindByID(ctx, *input.DestinationID)
+		if err != nil {
 			return ErrDestinationNotFound
 		}
+		if input.Action == domain.DestinationChangeActionRestore {
+			if dest.Status != domain.DestinationStatusArchived {
+				return fmt.Errorf("%w: only archived destinations can be restored", ErrInvalidChangeState)
+			}
+			if err := s.ensureSlugAvailable(ctx, dest.ID, restoreSlug(dest, input.Fields)); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
-func (s *DestinationWorkflowService) validateFields(action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error {
+func (s *DestinationWorkflowService) validateFields(ctx context.Context, action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error {
 	var problems []string
 	trim := func(ptr *string) *string {
 		if ptr == nil {
@@ -519,6 +910,7 @@ func (s *DestinationWorkflowService) validateFields(action domain.DestinationCha
 	fields.Contact = trim(fields.Contact)
 	fields.OpeningTime = trim(fields.OpeningTime)
 	fields.ClosingTime = trim(fields.ClosingTime)
+	fields.Timezone = trim(fields.Timezone)
 
 	if requireAll || fields.Name != nil {
 		if fields.Name == nil || *fields.Name == "" {
@@ -532,9 +924,15 @@ func (s *DestinationWorkflowService) validateFields(action domain.DestinationCha
 		}
 	}
 
-	if fields.Category != nil && len(s.allowedCategories) > 0 {
-		if _, ok := s.allowedCategories[strings.ToLower(*fields.Category)]; !ok {
-			problems = append(problems, "category not allowed")
+	// An empty category on an update clears it, which any category policy
+	// allows; rollbacks to versions without one rely on it.
+	if fields.Category != nil && (*fields.Category != "" || action == domain.DestinationChangeActionCreate) {
+		problem, err := s.categoryProblem(ctx, *fields.Category)
+		if err != nil {
+			return err
+		}
+		if problem != "" {
+			problems = append(problems, problem)
 		}
 	}
 
@@ -561,6 +959,21 @@ func (s *DestinationWorkflowService) validateFields(action domain.DestinationCha
 	validateTime("opening_time", fields.OpeningTime)
 	validateTime("closing_time", fields.ClosingTime)
 
+	if fields.Timezone != nil {
+		if err := validateTimezone(*fields.Timezone); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if fields.OpeningHours != nil {
+		problems = append(problems, validateOpeningHours(*fields.OpeningHours)...)
+	}
+	if fields.Translations != nil {
+		problems = append(problems, s.locales.validateTranslations(*fields.Translations)...)
+	}
+	if fields.Tags != nil {
+		problems = append(problems, validateTags(*fields.Tags)...)
+	}
+
 	if fields.Gallery != nil {
 		gallery := *fields.Gallery
 		for idx := range gallery {
@@ -595,14 +1008,56 @@ func (s *DestinationWorkflowService) validateFields(action domain.DestinationCha
 		}
 	}
 
+	if action == domain.DestinationChangeActionRestore {
+		if fields.Status != nil && *fields.Status != domain.DestinationStatusDraft && *fields.Status != domain.DestinationStatusPublished {
+			problems = append(problems, "restore status must be draft or published")
+		}
+		if fields.Name != nil || fields.City != nil || fields.Country != nil || fields.Category != nil ||
+			fields.Description != nil || fields.Latitude != nil || fields.Longitude != nil || fields.Contact != nil ||
+			fields.OpeningTime != nil || fields.ClosingTime != nil || fields.Timezone != nil ||
+			fields.OpeningHours != nil || fields.Translations != nil || fields.Tags != nil || fields.Gallery != nil ||
+			fields.HeroImageUploadID != nil || fields.HeroImageURL != nil || fields.HardDelete != nil {
+			problems = append(problems, "restore only accepts status and slug")
+		}
+	}
+
 	if len(problems) > 0 {
 		return fmt.Errorf("%w: %s", ErrDestinationChangeValidation, strings.Join(problems, "; "))
 	}
 	return nil
 }
 
-func (s *DestinationWorkflowService) ValidateFields(action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error {
-	return s.validateFields(action, fields, requireAll)
+func (s *DestinationWorkflowService) ValidateFields(ctx context.Context, action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error {
+	fields.Tags = normalizeTagsPtr(fields.Tags)
+	fields.OpeningHours = normalizeOpeningHoursPtr(fields.OpeningHours)
+	return s.validateFields(ctx, action, fields, requireAll)
+}
+
+// categoryProblem checks a category against the managed taxonomy, falling
+// back to the configured allow-list while no categories are managed.
+func (s *DestinationWorkflowService) categoryProblem(ctx context.Context, category string) (string, error) {
+	category = strings.TrimSpace(category)
+	if s.categories != nil {
+		managed, err := s.categories.List(ctx)
+		if err != nil {
+			return "", err
+		}
+		if len(managed) > 0 {
+			if category == "" {
+				return "", nil
+			}
+			if _, ok := findCategory(managed, category); !ok {
+				return fmt.Sprintf("category %q is not a managed category", category), nil
+			}
+			return "", nil
+		}
+	}
+	if len(s.allowedCategories) > 0 {
+		if _, ok := s.allowedCategories[strings.ToLower(category)]; !ok {
+			return "category not allowed", nil
+		}
+	}
+	return "", nil
 }
 
 func (s *DestinationWorkflowService) applyCreate(ctx context.Context, change *domain.DestinationChangeRequest, reviewerID uuid.UUID) (*domain.Destination, error) {
@@ -637,6 +1092,19 @@ func (s *DestinationWorkflowService) applyUpdate(ctx context.Context, change *do
 	if err != nil {
 		return nil, ErrDestinationNotFound
 	}
+	if change.BaseVersion != nil && *change.BaseVersion != dest.Version {
+		conflicts, err := s.detectConflicts(ctx, change, dest)
+		if err != nil {
+			return nil, err
+		}
+		if len(conflicts) > 0 {
+			return nil, &ChangeConflictError{
+				BaseVersion:    *change.BaseVersion,
+				CurrentVersion: dest.Version,
+				Conflicts:      conflicts,
+			}
+		}
+	}
 	statusOverride := change.Payload.Status
 	heroURL := change.Payload.HeroImageURL
 	dest, err = s.destinations.Update(ctx, dest.ID, change.Payload, reviewerID, statusOverride, heroURL)
@@ -648,6 +1116,7 @@ func (s *DestinationWorkflowService) applyUpdate(ctx context.Context, change *do
 		DestinationID:   dest.ID,
 		ChangeRequestID: &change.ID,
 		Version:         dest.Version,
+		SourceVersion:   change.Payload.SourceVersion,
 		Snapshot:        snapshotFromDestination(dest),
 		CreatedAt:       s.now(),
 		CreatedBy:       reviewerID,
@@ -705,6 +1174,100 @@ func (s *DestinationWorkflowService) applyDelete(ctx context.Context, change *do
 	return updated, nil
 }
 
+// applyRestore un-archives a destination. The slug is checked again because
+// another destination may have claimed it while the request was in review.
+func (s *DestinationWorkflowService) applyRestore(ctx context.Context, change *domain.DestinationChangeRequest, reviewerID uuid.UUID) (*domain.Destination, error) {
+	if change.DestinationID == nil {
+		return nil, fmt.Errorf("%w: destination_id required for restore", ErrDestinationChangeValidation)
+	}
+	dest, err := s.destinations.FindByID(ctx, *change.DestinationID)
+	if err != nil {
+		return nil, ErrDestinationNotFound
+	}
+	if dest.Status != domain.DestinationStatusArchived {
+		return nil, fmt.Errorf("%w: destination is no longer archived", ErrInvalidChangeState)
+	}
+	if err := s.ensureSlugAvailable(ctx, dest.ID, restoreSlug(dest, change.Payload)); err != nil {
+		return nil, err
+	}
+
+	status := domain.DestinationStatusPublished
+	if change.Payload.Status != nil {
+		status = *change.Payload.Status
+	}
+	var slug *string
+	if change.Payload.Slug != nil && strings.TrimSpace(*change.Payload.Slug) != "" {
+		slug = stringPtr(strings.TrimSpace(*change.Payload.Slug))
+	}
+	restored, err := s.destinations.Restore(ctx, dest.ID, reviewerID, status, slug)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: %s", ErrDestinationSlugTaken, restoreSlug(dest, change.Payload))
+		}
+		return nil, err
+	}
+
+	if _, err = s.versions.Create(ctx, &domain.DestinationVersion{
+		ID:              uuid.Nil,
+		DestinationID:   restored.ID,
+		ChangeRequestID: &change.ID,
+		Version:         restored.Version,
+		Snapshot:        snapshotFromDestination(restored),
+		CreatedAt:       s.now(),
+		CreatedBy:       reviewerID,
+	}); err != nil {
+		return restored, err
+	}
+	return restored, nil
+}
+
+// restoreSlug is the slug a restore would publish: the requested one, or the
+// slug the destination was archived with.
+func restoreSlug(dest *domain.Destination, fields domain.DestinationChangeFields) string {
+	if fields.Slug != nil && strings.TrimSpace(*fields.Slug) != "" {
+		return strings.TrimSpace(*fields.Slug)
+	}
+	if dest.Slug != nil {
+		return *dest.Slug
+	}
+	return ""
+}
+
+// ensureSlugAvailable rejects a slug that another destination uses now or used
+// before; retired slugs stay reserved so old links keep resolving. id is
+// uuid.Nil for destinations that do not exist yet.
+func (s *DestinationWorkflowService) ensureSlugAvailable(ctx context.Context, id uuid.UUID, slug string) error {
+	if slug == "" {
+		return nil
+	}
+	owner, err := s.destinations.FindSlugOwner(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if owner != id {
+		return fmt.Errorf("%w: %s", ErrDestinationSlugTaken, slug)
+	}
+	return nil
+}
+
+// ensureFieldSlugAvailable checks the slug a create or update would set.
+func (s *DestinationWorkflowService) ensureFieldSlugAvailable(ctx context.Context, action domain.DestinationChangeAction, destinationID *uuid.UUID, fields domain.DestinationChangeFields) error {
+	if fields.Slug == nil {
+		return nil
+	}
+	if action != domain.DestinationChangeActionCreate && action != domain.DestinationChangeActionUpdate {
+		return nil
+	}
+	id := uuid.Nil
+	if destinationID != nil {
+		id = *destinationID
+	}
+	return s.ensureSlugAvailable(ctx, id, strings.TrimSpace(*fields.Slug))
+}
+
 func (s *DestinationWorkflowService) isDeleteAction(action domain.DestinationChangeAction) bool {
 	return action == domain.DestinationChangeActionDelete
 }
@@ -714,20 +1277,24 @@ func snapshotFromDestination(dest *domain.Destination) domain.DestinationSnapsho
 		return domain.DestinationSnapshot{}
 	}
 	return domain.DestinationSnapshot{
-		ID:          dest.ID,
-		Name:        dest.Name,
-		Slug:        dest.Slug,
-		Status:      dest.Status,
-		Version:     dest.Version,
-		City:        dest.City,
-		Country:     dest.Country,
-		Category:    dest.Category,
-		Description: dest.Description,
-		Latitude:    dest.Latitude,
-		Longitude:   dest.Longitude,
-		Contact:     dest.Contact,
-		OpeningTime: dest.OpeningTime,
-		ClosingTime: dest.ClosingTime,
+		ID:           dest.ID,
+		Name:         dest.Name,
+		Slug:         dest.Slug,
+		Status:       dest.Status,
+		Version:      dest.Version,
+		City:         dest.City,
+		Country:      dest.Country,
+		Category:     dest.Category,
+		Description:  dest.Description,
+		Latitude:     dest.Latitude,
+		Longitude:    dest.Longitude,
+		Contact:      dest.Contact,
+		OpeningTime:  dest.OpeningTime,
+		ClosingTime:  dest.ClosingTime,
+		Timezone:     dest.Timezone,
+		OpeningHours: cloneOpeningHours(dest.OpeningHours),
+		Translations: cloneTranslations(dest.Translations),
+		Tags:         cloneTags(dest.Tags),
 		Gallery: func() domain.DestinationGallery {
 			if len(dest.Gallery) == 0 {
 				return nil
@@ -740,6 +1307,85 @@ func snapshotFromDestination(dest *domain.Destination) domain.DestinationSnapsho
 	}
 }
 
+// changeFieldsFromSnapshot builds a full update payload from a stored snapshot.
+// Empty strings are used for absent text fields so the update clears them.
+func changeFieldsFromSnapshot(snapshot domain.DestinationSnapshot) domain.DestinationChangeFields {
+	orEmpty := func(ptr *string) *string {
+		val := ""
+		if ptr != nil {
+			val = *ptr
+		}
+		return &val
+	}
+	name := snapshot.Name
+	gallery := cloneGallery(snapshot.Gallery)
+	if gallery == nil {
+		gallery = domain.DestinationGallery{}
+	}
+	fields := domain.DestinationChangeFields{
+		Name:         &name,
+		Slug:         orEmpty(snapshot.Slug),
+		City:         orEmpty(snapshot.City),
+		Country:      orEmpty(snapshot.Country),
+		Category:     orEmpty(snapshot.Category),
+		Description:  orEmpty(snapshot.Description),
+		Latitude:     snapshot.Latitude,
+		Longitude:    snapshot.Longitude,
+		Contact:      orEmpty(snapshot.Contact),
+		OpeningTime:  orEmpty(snapshot.OpeningTime),
+		ClosingTime:  orEmpty(snapshot.ClosingTime),
+		Timezone:     orEmpty(snapshot.Timezone),
+		OpeningHours: openingHoursOrEmpty(snapshot.OpeningHours),
+		Translations: translationsOrEmpty(snapshot.Translations),
+		Tags:         tagsOrEmpty(snapshot.Tags),
+		Gallery:      &gallery,
+		HeroImageURL: orEmpty(snapshot.HeroImage),
+	}
+	if snapshot.Status == domain.DestinationStatusDraft || snapshot.Status == domain.DestinationStatusPublished {
+		status := snapshot.Status
+		fields.Status = &status
+	}
+	return fields
+}
+
+// stagedObjectKeys lists the storage keys uploaded for a change request. Only
+// objects under the change's own upload prefix are returned, so media shared
+// with the published destination is never touched.
+func stagedObjectKeys(change *domain.DestinationChangeRequest) []string {
+	prefix := fmt.Sprintf("destinations/changes/%s/", change.ID)
+	seen := make(map[string]struct{})
+	keys := make([]string, 0)
+	add := func(ref *string) {
+		if ref == nil {
+			return
+		}
+		idx := strings.Index(*ref, prefix)
+		if idx < 0 {
+			return
+		}
+		key := (*ref)[idx:]
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	add(change.HeroImageTempKey)
+	add(change.Payload.HeroImageUploadID)
+	add(change.Payload.HeroImageURL)
+	if change.Payload.Gallery != nil {
+		for _, item := range *change.Payload.Gallery {
+			url := item.URL
+			add(&url)
+		}
+	}
+	for _, url := range change.Payload.RemovedMedia {
+		add(&url)
+	}
+	return keys
+}
+
 func stringPtr(v string) *string {
 	if v == "" {
 		return nil
@@ -6,13 +6,17 @@ import (
 	"database/sql"
 	"errors"
 	"io"
+	"math"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
 )
@@ -26,7 +30,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 	versionRepo := newMemoryVersionRepo()
 	storage := &memoryStorage{}
 
-	service := NewDestinationWorkflowService(destRepo, changeRepo, versionRepo, storage, DestinationWorkflowConfig{
+	service := NewDestinationWorkflowService(destRepo, changeRepo, versionRepo, newMemoryApprovalRepo(), storage, DestinationWorkflowConfig{
 		Bucket:            "fitcity-destinations",
 		PublicBaseURL:     "https://cdn.fitcity.local/fitcity-destinations",
 		ImageMaxBytes:     5 * 1024 * 1024,
@@ -54,7 +58,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 		if _, err = service.SubmitDraft(ctx, change.ID, admin); err != nil {
 			t.Fatalf("SubmitDraft: %v", err)
 		}
-		approved, dest, err := service.Approve(ctx, change.ID, reviewer)
+		approved, dest, err := service.Approve(ctx, change.ID, reviewer, "")
 		if err != nil {
 			t.Fatalf("Approve: %v", err)
 		}
@@ -110,7 +114,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 		if _, err = service.SubmitDraft(ctx, change.ID, admin); err != nil {
 			t.Fatalf("SubmitDraft: %v", err)
 		}
-		approved, dest, err := service.Approve(ctx, change.ID, reviewer)
+		approved, dest, err := service.Approve(ctx, change.ID, reviewer, "")
 		if err != nil {
 			t.Fatalf("Approve: %v", err)
 		}
@@ -177,7 +181,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 		if _, err = service.SubmitDraft(ctx, change.ID, admin); err != nil {
 			t.Fatalf("SubmitDraft: %v", err)
 		}
-		_, dest, err := service.Approve(ctx, change.ID, reviewer)
+		_, dest, err := service.Approve(ctx, change.ID, reviewer, "")
 		if err != nil {
 			t.Fatalf("Approve: %v", err)
 		}
@@ -233,7 +237,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 		if _, err = service.SubmitDraft(ctx, change.ID, admin); err != nil {
 			t.Fatalf("SubmitDraft: %v", err)
 		}
-		_, dest, err := service.Approve(ctx, change.ID, reviewer)
+		_, dest, err := service.Approve(ctx, change.ID, reviewer, "")
 		if err != nil {
 			t.Fatalf("Approve: %v", err)
 		}
@@ -287,7 +291,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 		if _, err = service.SubmitDraft(ctx, change.ID, admin); err != nil {
 			t.Fatalf("SubmitDraft: %v", err)
 		}
-		_, dest, err := service.Approve(ctx, change.ID, reviewer)
+		_, dest, err := service.Approve(ctx, change.ID, reviewer, "")
 		if err != nil {
 			t.Fatalf("Approve: %v", err)
 		}
@@ -357,7 +361,7 @@ func TestDestinationWorkflowService_Flows(t *testing.T) {
 		if _, err = service.SubmitDraft(ctx, change.ID, admin); err != nil {
 			t.Fatalf("SubmitDraft: %v", err)
 		}
-		_, dest, err := service.Approve(ctx, change.ID, reviewer)
+		_, dest, err := service.Approve(ctx, change.ID, reviewer, "")
 		if err != nil {
 			t.Fatalf("Approve: %v", err)
 		}
@@ -509,7 +513,7 @@ func TestDestinationWorkflowService_UploadHeroImageUsesProcessor(t *testing.T) {
 	storage := &memoryStorage{}
 	processor := &stubImageProcessor{output: []byte("processed-by-ffmpeg"), contentType: "image/png"}
 
-	service := NewDestinationWorkflowService(destRepo, changeRepo, versionRepo, storage, DestinationWorkflowConfig{
+	service := NewDestinationWorkflowService(destRepo, changeRepo, versionRepo, newMemoryApprovalRepo(), storage, DestinationWorkflowConfig{
 		Bucket:            "fitcity-destinations",
 		PublicBaseURL:     "https://cdn.example.com/destinations",
 		ImageMaxBytes:     5 * 1024 * 1024,
@@ -556,12 +560,795 @@ func TestDestinationWorkflowService_UploadHeroImageUsesProcessor(t *testing.T) {
 	}
 }
 
+func TestDestinationWorkflowService_Rollback(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 10, 12, 0, 0, 0, time.UTC)
+	admin := uuid.New()
+	reviewer := uuid.New()
+
+	setup := func(approvalRequired bool) (*DestinationWorkflowService, *memoryDestinationRepo, *memoryVersionRepo, *domain.Destination) {
+		destRepo := newMemoryDestinationRepo(now)
+		versionRepo := newMemoryVersionRepo()
+		svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), versionRepo, newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+			AllowedCategories: []string{"Nature"},
+			ApprovalRequired:  approvalRequired,
+		})
+		svc.SetClock(func() time.Time { return now })
+
+		change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{
+				Name:        strPtr("Lumpini Park"),
+				Category:    strPtr("Nature"),
+				Description: strPtr("Original description"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		_, dest, err := svc.Approve(ctx, change.ID, reviewer, "")
+		if err != nil {
+			t.Fatalf("Approve create: %v", err)
+		}
+
+		update, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+			Action:        domain.DestinationChangeActionUpdate,
+			DestinationID: &dest.ID,
+			Fields: domain.DestinationChangeFields{
+				Description: strPtr("Edited description"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft update: %v", err)
+		}
+		if _, err = svc.SubmitDraft(ctx, update.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft update: %v", err)
+		}
+		if _, dest, err = svc.Approve(ctx, update.ID, reviewer, ""); err != nil {
+			t.Fatalf("Approve update: %v", err)
+		}
+		return svc, destRepo, versionRepo, dest
+	}
+
+	t.Run("rollback goes through review when approval required", func(t *testing.T) {
+		svc, destRepo, versionRepo, dest := setup(true)
+
+		change, applied, err := svc.Rollback(ctx, dest.ID, 1, admin)
+		if err != nil {
+			t.Fatalf("Rollback: %v", err)
+		}
+		if applied != nil {
+			t.Fatalf("expected rollback to await review")
+		}
+		if change.Status != domain.DestinationChangeStatusPendingReview {
+			t.Fatalf("expected pending_review, got %s", change.Status)
+		}
+		if change.Payload.SourceVersion == nil || *change.Payload.SourceVersion != 1 {
+			t.Fatalf("expected source version 1, got %v", change.Payload.SourceVersion)
+		}
+
+		_, restored, err := svc.Approve(ctx, change.ID, reviewer, "")
+		if err != nil {
+			t.Fatalf("Approve rollback: %v", err)
+		}
+		if restored.Version != 3 {
+			t.Fatalf("expected version 3, got %d", restored.Version)
+		}
+		if restored.Description == nil || *restored.Description != "Original description" {
+			t.Fatalf("expected original description, got %v", restored.Description)
+		}
+		persisted, _ := destRepo.FindByID(ctx, dest.ID)
+		if persisted.Version != 3 {
+			t.Fatalf("expected persisted version 3, got %d", persisted.Version)
+		}
+		record, err := versionRepo.FindByVersion(ctx, dest.ID, 3)
+		if err != nil {
+			t.Fatalf("FindByVersion: %v", err)
+		}
+		if record.SourceVersion == nil || *record.SourceVersion != 1 {
+			t.Fatalf("expected version record to link to source 1, got %v", record.SourceVersion)
+		}
+	})
+
+	t.Run("rollback applies directly when approval not required", func(t *testing.T) {
+		svc, _, _, dest := setup(false)
+
+		change, applied, err := svc.Rollback(ctx, dest.ID, 1, admin)
+		if err != nil {
+			t.Fatalf("Rollback: %v", err)
+		}
+		if change.Status != domain.DestinationChangeStatusApproved {
+			t.Fatalf("expected approved change, got %s", change.Status)
+		}
+		if applied == nil || applied.Version != 3 {
+			t.Fatalf("expected destination at version 3")
+		}
+	})
+
+	t.Run("rollback clears a category the target version did not have", func(t *testing.T) {
+		svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+			AllowedCategories: []string{"Nature"},
+		})
+		svc.SetClock(func() time.Time { return now })
+		apply := func(input DestinationDraftInput) *domain.Destination {
+			t.Helper()
+			change, err := svc.CreateDraft(ctx, admin, input)
+			if err != nil {
+				t.Fatalf("CreateDraft: %v", err)
+			}
+			if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+				t.Fatalf("SubmitDraft: %v", err)
+			}
+			_, dest, err := svc.Approve(ctx, change.ID, reviewer, "")
+			if err != nil {
+				t.Fatalf("Approve: %v", err)
+			}
+			return dest
+		}
+		dest := apply(DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{Name: strPtr("Benjakitti Park")},
+		})
+		apply(DestinationDraftInput{
+			Action:        domain.DestinationChangeActionUpdate,
+			DestinationID: &dest.ID,
+			Fields:        domain.DestinationChangeFields{Category: strPtr("Nature")},
+		})
+
+		_, restored, err := svc.Rollback(ctx, dest.ID, 1, admin)
+		if err != nil {
+			t.Fatalf("Rollback: %v", err)
+		}
+		if restored.Category != nil && *restored.Category != "" {
+			t.Fatalf("expected the category cleared as in version 1, got %q", *restored.Category)
+		}
+	})
+
+	t.Run("rollback rejects unknown and current versions", func(t *testing.T) {
+		svc, _, _, dest := setup(true)
+
+		if _, _, err := svc.Rollback(ctx, dest.ID, dest.Version, admin); !errors.Is(err, ErrDestinationChangeValidation) {
+			t.Fatalf("expected validation error for current version, got %v", err)
+		}
+		if _, _, err := svc.Rollback(ctx, uuid.New(), 1, admin); !errors.Is(err, ErrDestinationNotFound) {
+			t.Fatalf("expected ErrDestinationNotFound, got %v", err)
+		}
+	})
+}
+
 // --- memory repositories for testing ---
 
+func TestDestinationWorkflowService_WithdrawDiscardReopen(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 14, 9, 0, 0, 0, time.UTC)
+	storage := &memoryStorage{}
+	changeRepo := newMemoryChangeRepo()
+	svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), changeRepo, newMemoryVersionRepo(), newMemoryApprovalRepo(), storage, DestinationWorkflowConfig{
+		Bucket:           "fitcity-destinations",
+		ApprovalRequired: true,
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	author := uuid.New()
+	reviewer := uuid.New()
+	newDraft := func() *domain.DestinationChangeRequest {
+		change, err := svc.CreateDraft(ctx, author, DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{
+				Name:        strPtr("Talad Rot Fai"),
+				Description: strPtr("Night market"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		return change
+	}
+
+	t.Run("withdraw returns pending submission to draft", func(t *testing.T) {
+		change := newDraft()
+		if _, err := svc.WithdrawSubmission(ctx, change.ID, author); !errors.Is(err, ErrInvalidChangeState) {
+			t.Fatalf("expected ErrInvalidChangeState for unsubmitted draft, got %v", err)
+		}
+		if _, err := svc.SubmitDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		if _, err := svc.WithdrawSubmission(ctx, change.ID, reviewer); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("expected ErrForbidden for non-author, got %v", err)
+		}
+		withdrawn, err := svc.WithdrawSubmission(ctx, change.ID, author)
+		if err != nil {
+			t.Fatalf("WithdrawSubmission: %v", err)
+		}
+		if withdrawn.Status != domain.DestinationChangeStatusDraft || withdrawn.SubmittedAt != nil {
+			t.Fatalf("expected draft without submitted_at, got %s", withdrawn.Status)
+		}
+		if _, _, err = svc.Approve(ctx, change.ID, reviewer, ""); !errors.Is(err, ErrInvalidChangeState) {
+			t.Fatalf("withdrawn change should not be approvable, got %v", err)
+		}
+	})
+
+	t.Run("reopen keeps reviewer feedback", func(t *testing.T) {
+		change := newDraft()
+		if _, err := svc.SubmitDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		if _, err := svc.Reject(ctx, change.ID, reviewer, "Add opening hours please"); err != nil {
+			t.Fatalf("Reject: %v", err)
+		}
+		if _, err := svc.ReopenRejected(ctx, change.ID, reviewer); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("expected ErrForbidden for non-author, got %v", err)
+		}
+		reopened, err := svc.ReopenRejected(ctx, change.ID, author)
+		if err != nil {
+			t.Fatalf("ReopenRejected: %v", err)
+		}
+		if reopened.Status != domain.DestinationChangeStatusDraft {
+			t.Fatalf("expected draft, got %s", reopened.Status)
+		}
+		if reopened.ReviewMessage == nil || *reopened.ReviewMessage != "Add opening hours please" || reopened.ReviewedBy == nil {
+			t.Fatalf("expected reviewer feedback kept, got %#v", reopened)
+		}
+		if _, err = svc.ReopenRejected(ctx, change.ID, author); !errors.Is(err, ErrInvalidChangeState) {
+			t.Fatalf("expected ErrInvalidChangeState reopening a draft, got %v", err)
+		}
+	})
+
+	t.Run("discard deletes change and staged media", func(t *testing.T) {
+		change := newDraft()
+		hero, err := svc.UploadHeroImage(ctx, change.ID, author, HeroImageUpload{
+			Reader:      bytes.NewReader([]byte("hero")),
+			Size:        4,
+			FileName:    "hero.jpg",
+			ContentType: "image/jpeg",
+		})
+		if err != nil {
+			t.Fatalf("UploadHeroImage: %v", err)
+		}
+		_, uploads, err := svc.UploadGalleryImages(ctx, change.ID, author, []GalleryImageUpload{{
+			Reader:      bytes.NewReader([]byte("gallery")),
+			Size:        7,
+			FileName:    "one.jpg",
+			ContentType: "image/jpeg",
+		}})
+		if err != nil {
+			t.Fatalf("UploadGalleryImages: %v", err)
+		}
+		staged := []string{*hero.HeroImageTempKey, uploads[0].UploadID}
+
+		if _, err = svc.SubmitDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		if err = svc.DiscardDraft(ctx, change.ID, author); !errors.Is(err, ErrInvalidChangeState) {
+			t.Fatalf("expected ErrInvalidChangeState discarding a pending change, got %v", err)
+		}
+		if _, err = svc.WithdrawSubmission(ctx, change.ID, author); err != nil {
+			t.Fatalf("WithdrawSubmission: %v", err)
+		}
+		if err = svc.DiscardDraft(ctx, change.ID, reviewer); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("expected ErrForbidden for non-author, got %v", err)
+		}
+		if err = svc.DiscardDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("DiscardDraft: %v", err)
+		}
+		if _, err = svc.GetChange(ctx, change.ID); !errors.Is(err, ErrDestinationChangeNotFound) {
+			t.Fatalf("expected change to be deleted, got %v", err)
+		}
+		for _, key := range staged {
+			if _, ok := storage.objects.Load(key); ok {
+				t.Fatalf("expected staged object %s to be deleted", key)
+			}
+		}
+	})
+}
+
+func TestDestinationWorkflowService_BulkReview(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC)
+	svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		ApprovalRequired: true,
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	importer := uuid.New()
+	other := uuid.New()
+	reviewer := uuid.New()
+	submit := func(author uuid.UUID, name string) *domain.DestinationChangeRequest {
+		change, err := svc.CreateDraft(ctx, author, DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{Name: strPtr(name)},
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		return change
+	}
+
+	t.Run("ids are processed independently", func(t *testing.T) {
+		first := submit(importer, "Pak Khlong Talat")
+		second := submit(reviewer, "Sampeng Lane")
+		draft, err := svc.CreateDraft(ctx, importer, DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{Name: strPtr("Unsubmitted")},
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+
+		result, err := svc.BulkReview(ctx, reviewer, BulkReviewInput{
+			ChangeIDs: []uuid.UUID{first.ID, second.ID, draft.ID, first.ID},
+			Decision:  BulkReviewApprove,
+		})
+		if err != nil {
+			t.Fatalf("BulkReview: %v", err)
+		}
+		if len(result.Items) != 3 || result.Succeeded != 1 || result.Failed != 2 || result.AllSucceeded {
+			t.Fatalf("unexpected summary: %+v", result)
+		}
+		if !result.Items[0].Success || result.Items[0].Status != domain.DestinationChangeStatusApproved || result.Items[0].DestinationID == nil {
+			t.Fatalf("expected first change approved, got %+v", result.Items[0])
+		}
+		if result.Items[1].Success || result.Items[1].Error == "" {
+			t.Fatalf("expected own submission to fail, got %+v", result.Items[1])
+		}
+		if result.Items[2].Success {
+			t.Fatalf("expected unsubmitted draft to fail, got %+v", result.Items[2])
+		}
+	})
+
+	t.Run("filter rejects pending changes from one author", func(t *testing.T) {
+		a := submit(importer, "Bang Krachao")
+		b := submit(importer, "Khlong Lat Mayom")
+		untouched := submit(other, "Taling Chan")
+
+		result, err := svc.BulkReview(ctx, reviewer, BulkReviewInput{
+			Filter:   domain.DestinationChangeFilter{SubmittedBy: &importer},
+			Decision: BulkReviewReject,
+			Message:  "Duplicate import rows",
+		})
+		if err != nil {
+			t.Fatalf("BulkReview: %v", err)
+		}
+		if !result.AllSucceeded || result.Succeeded != 2 {
+			t.Fatalf("expected two rejections, got %+v", result)
+		}
+		for _, id := range []uuid.UUID{a.ID, b.ID} {
+			change, _ := svc.GetChange(ctx, id)
+			if change.Status != domain.DestinationChangeStatusRejected || change.ReviewMessage == nil || *change.ReviewMessage != "Duplicate import rows" {
+				t.Fatalf("expected %s rejected with message, got %#v", id, change)
+			}
+		}
+		if change, _ := svc.GetChange(ctx, untouched.ID); change.Status != domain.DestinationChangeStatusPendingReview {
+			t.Fatalf("change from another author should be untouched, got %s", change.Status)
+		}
+	})
+
+	t.Run("filter reports matches beyond the cap", func(t *testing.T) {
+		bulk := uuid.New()
+		for i := 0; i < maxBulkReviewItems+3; i++ {
+			submit(bulk, "Row "+strconv.Itoa(i))
+		}
+
+		result, err := svc.BulkReview(ctx, reviewer, BulkReviewInput{
+			Filter:   domain.DestinationChangeFilter{SubmittedBy: &bulk},
+			Decision: BulkReviewReject,
+		})
+		if err != nil {
+			t.Fatalf("BulkReview: %v", err)
+		}
+		if len(result.Items) != maxBulkReviewItems || !result.Truncated || result.Remaining != 3 {
+			t.Fatalf("expected %d reviewed with 3 remaining, got %d items, truncated=%v remaining=%d", maxBulkReviewItems, len(result.Items), result.Truncated, result.Remaining)
+		}
+
+		result, err = svc.BulkReview(ctx, reviewer, BulkReviewInput{
+			Filter:   domain.DestinationChangeFilter{SubmittedBy: &bulk},
+			Decision: BulkReviewReject,
+		})
+		if err != nil {
+			t.Fatalf("BulkReview rest: %v", err)
+		}
+		if len(result.Items) != 3 || result.Truncated || result.Remaining != 0 {
+			t.Fatalf("expected the last 3 without truncation, got %+v", result)
+		}
+	})
+
+	t.Run("disabled actions and missing selection", func(t *testing.T) {
+		change := submit(importer, "Wang Lang")
+		result, err := svc.BulkReview(ctx, reviewer, BulkReviewInput{
+			ChangeIDs:      []uuid.UUID{change.ID},
+			Decision:       BulkReviewApprove,
+			EnabledActions: []domain.DestinationChangeAction{domain.DestinationChangeActionUpdate},
+		})
+		if err != nil {
+			t.Fatalf("BulkReview: %v", err)
+		}
+		if result.AllSucceeded || !strings.Contains(result.Items[0].Error, "disabled") {
+			t.Fatalf("expected disabled action failure, got %+v", result.Items)
+		}
+		if _, err = svc.BulkReview(ctx, reviewer, BulkReviewInput{Decision: BulkReviewApprove}); !errors.Is(err, ErrDestinationChangeValidation) {
+			t.Fatalf("expected validation error without ids or filter, got %v", err)
+		}
+		if _, err = svc.BulkReview(ctx, reviewer, BulkReviewInput{ChangeIDs: []uuid.UUID{change.ID}, Decision: "maybe"}); !errors.Is(err, ErrDestinationChangeValidation) {
+			t.Fatalf("expected validation error for decision, got %v", err)
+		}
+	})
+}
+
+func TestDestinationWorkflowService_Restore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 16, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	versionRepo := newMemoryVersionRepo()
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), versionRepo, newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		ApprovalRequired: true,
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	admin := uuid.New()
+	reviewer := uuid.New()
+	approve := func(action domain.DestinationChangeAction, id uuid.UUID, fields domain.DestinationChangeFields) (*domain.Destination, error) {
+		change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{Action: action, DestinationID: &id, Fields: fields})
+		if err != nil {
+			return nil, err
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			return nil, err
+		}
+		_, dest, err := svc.Approve(ctx, change.ID, reviewer, "")
+		return dest, err
+	}
+
+	existing := destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+		Name: strPtr("Wat Arun"),
+		Slug: strPtr("wat-arun"),
+	}, admin, domain.DestinationStatusPublished, nil)
+
+	if _, err := approve(domain.DestinationChangeActionRestore, existing.ID, domain.DestinationChangeFields{}); !errors.Is(err, ErrInvalidChangeState) {
+		t.Fatalf("expected invalid state for live destination, got %v", err)
+	}
+	archived, err := approve(domain.DestinationChangeActionDelete, existing.ID, domain.DestinationChangeFields{})
+	if err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+
+	destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+		Name: strPtr("Wat Arun Riverside"),
+		Slug: strPtr("wat-arun-riverside"),
+	}, admin, domain.DestinationStatusPublished, nil)
+	if _, err = approve(domain.DestinationChangeActionRestore, existing.ID, domain.DestinationChangeFields{Slug: strPtr("wat-arun-riverside")}); !errors.Is(err, ErrDestinationSlugTaken) {
+		t.Fatalf("expected slug clash, got %v", err)
+	}
+	if _, err = approve(domain.DestinationChangeActionRestore, existing.ID, domain.DestinationChangeFields{Name: strPtr("Renamed")}); !errors.Is(err, ErrDestinationChangeValidation) {
+		t.Fatalf("expected validation error for content fields, got %v", err)
+	}
+
+	restored, err := approve(domain.DestinationChangeActionRestore, existing.ID, domain.DestinationChangeFields{Slug: strPtr("wat-arun-temple")})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if restored.Status != domain.DestinationStatusPublished || restored.DeletedAt != nil {
+		t.Fatalf("expected published destination, got %s", restored.Status)
+	}
+	if restored.Slug == nil || *restored.Slug != "wat-arun-temple" {
+		t.Fatalf("expected new slug, got %v", restored.Slug)
+	}
+	if restored.Version != archived.Version+1 {
+		t.Fatalf("expected version %d, got %d", archived.Version+1, restored.Version)
+	}
+	if _, err = versionRepo.FindByVersion(ctx, restored.ID, restored.Version); err != nil {
+		t.Fatalf("expected version snapshot: %v", err)
+	}
+}
+
+func TestDestinationWorkflowService_SlugHistory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 16, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{})
+	svc.SetClock(func() time.Time { return now })
+	public := NewDestinationService(destRepo, DestinationServiceConfig{})
+
+	admin := uuid.New()
+	draft := func(action domain.DestinationChangeAction, id *uuid.UUID, fields domain.DestinationChangeFields) (*domain.DestinationChangeRequest, error) {
+		return svc.CreateDraft(ctx, admin, DestinationDraftInput{Action: action, DestinationID: id, Fields: fields})
+	}
+	approve := func(change *domain.DestinationChangeRequest) (*domain.Destination, error) {
+		if _, err := svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			return nil, err
+		}
+		_, dest, err := svc.Approve(ctx, change.ID, uuid.New(), "")
+		return dest, err
+	}
+
+	existing := destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+		Name: strPtr("Wat Pho"),
+		Slug: strPtr("wat-pho"),
+	}, admin, domain.DestinationStatusPublished, nil)
+
+	if _, err := draft(domain.DestinationChangeActionCreate, nil, domain.DestinationChangeFields{Name: strPtr("Reclining Buddha"), Slug: strPtr("wat-pho")}); !errors.Is(err, ErrDestinationSlugTaken) {
+		t.Fatalf("expected current slug to be taken, got %v", err)
+	}
+
+	// A create draft is allowed while the slug is free, then blocked at
+	// approval once another destination retires it.
+	pending, err := draft(domain.DestinationChangeActionCreate, nil, domain.DestinationChangeFields{Name: strPtr("Reclining Buddha"), Slug: strPtr("wat-pho-temple")})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+
+	rename, err := draft(domain.DestinationChangeActionUpdate, &existing.ID, domain.DestinationChangeFields{Slug: strPtr("wat-pho-temple")})
+	if err != nil {
+		t.Fatalf("CreateDraft update: %v", err)
+	}
+	if _, err = approve(rename); err != nil {
+		t.Fatalf("approve rename: %v", err)
+	}
+	if _, err = approve(pending); !errors.Is(err, ErrDestinationSlugTaken) {
+		t.Fatalf("expected slug clash at approval, got %v", err)
+	}
+
+	rename, err = draft(domain.DestinationChangeActionUpdate, &existing.ID, domain.DestinationChangeFields{Slug: strPtr("wat-pho-bangkok")})
+	if err != nil {
+		t.Fatalf("CreateDraft update: %v", err)
+	}
+	if _, err = approve(rename); err != nil {
+		t.Fatalf("approve rename: %v", err)
+	}
+
+	for _, slug := range []string{"wat-pho", "wat-pho-temple"} {
+		found, err := public.GetPublishedBySlug(ctx, slug)
+		if err != nil {
+			t.Fatalf("GetPublishedBySlug(%s): %v", slug, err)
+		}
+		if found.ID != existing.ID || found.RetiredSlug == nil || *found.RetiredSlug != slug || *found.Slug != "wat-pho-bangkok" {
+			t.Fatalf("expected %s to resolve to the canonical slug, got %+v", slug, found)
+		}
+	}
+	current, err := public.GetPublishedBySlug(ctx, "wat-pho-bangkok")
+	if err != nil || current.RetiredSlug != nil {
+		t.Fatalf("expected canonical lookup without redirect, got %+v, %v", current, err)
+	}
+
+	if _, err = draft(domain.DestinationChangeActionCreate, nil, domain.DestinationChangeFields{Name: strPtr("Imposter"), Slug: strPtr("wat-pho")}); !errors.Is(err, ErrDestinationSlugTaken) {
+		t.Fatalf("expected retired slug to stay reserved, got %v", err)
+	}
+
+	// The destination itself may take an old slug back, which frees it from
+	// the history.
+	rename, err = draft(domain.DestinationChangeActionUpdate, &existing.ID, domain.DestinationChangeFields{Slug: strPtr("wat-pho")})
+	if err != nil {
+		t.Fatalf("CreateDraft reclaim: %v", err)
+	}
+	if _, err = approve(rename); err != nil {
+		t.Fatalf("approve reclaim: %v", err)
+	}
+	current, err = public.GetPublishedBySlug(ctx, "wat-pho")
+	if err != nil || current.RetiredSlug != nil {
+		t.Fatalf("expected reclaimed slug to be canonical, got %+v, %v", current, err)
+	}
+
+	// Archiving hides the destination but keeps its slugs, current and
+	// retired, reserved for a restore.
+	archive, err := draft(domain.DestinationChangeActionDelete, &existing.ID, domain.DestinationChangeFields{})
+	if err != nil {
+		t.Fatalf("CreateDraft delete: %v", err)
+	}
+	if _, err = approve(archive); err != nil {
+		t.Fatalf("approve delete: %v", err)
+	}
+	if _, err = public.GetPublishedBySlug(ctx, "wat-pho-temple"); err == nil {
+		t.Fatalf("archived destination should not resolve publicly")
+	}
+	for _, slug := range []string{"wat-pho", "wat-pho-temple"} {
+		if _, err = draft(domain.DestinationChangeActionCreate, nil, domain.DestinationChangeFields{Name: strPtr("Imposter"), Slug: strPtr(slug)}); !errors.Is(err, ErrDestinationSlugTaken) {
+			t.Fatalf("expected %s reserved by the archived destination, got %v", slug, err)
+		}
+	}
+
+	lookupErr := errors.New("connection reset")
+	failing := NewDestinationWorkflowService(failingSlugRepo{memoryDestinationRepo: destRepo, err: lookupErr}, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{})
+	if _, err = failing.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Anywhere"), Slug: strPtr("anywhere")},
+	}); !errors.Is(err, lookupErr) {
+		t.Fatalf("expected the slug lookup error, got %v", err)
+	}
+}
+
+type failingSlugRepo struct {
+	*memoryDestinationRepo
+	err error
+}
+
+func (r failingSlugRepo) FindSlugOwner(context.Context, string) (uuid.UUID, error) {
+	return uuid.Nil, r.err
+}
+
+func TestDestinationWorkflowService_ApprovalPolicy(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 12, 8, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	approvalRepo := newMemoryApprovalRepo()
+
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), approvalRepo, &memoryStorage{}, DestinationWorkflowConfig{
+		ApprovalRequired:  true,
+		ApprovalsRequired: 1,
+		ActionApprovals:   map[domain.DestinationChangeAction]int{domain.DestinationChangeActionDelete: 2},
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	author := uuid.New()
+	first := uuid.New()
+	second := uuid.New()
+
+	if got := svc.RequiredApprovals(domain.DestinationChangeActionUpdate); got != 1 {
+		t.Fatalf("expected 1 approval for update, got %d", got)
+	}
+	if got := svc.RequiredApprovals(domain.DestinationChangeActionDelete); got != 2 {
+		t.Fatalf("expected 2 approvals for delete, got %d", got)
+	}
+
+	submitDelete := func() *domain.DestinationChangeRequest {
+		existing := destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+			Name: strPtr("Old Pier"),
+		}, author, domain.DestinationStatusPublished, nil)
+		change, err := svc.CreateDraft(ctx, author, DestinationDraftInput{
+			Action:        domain.DestinationChangeActionDelete,
+			DestinationID: &existing.ID,
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		return change
+	}
+
+	t.Run("delete needs two distinct reviewers", func(t *testing.T) {
+		change := submitDelete()
+
+		if _, _, err := svc.Approve(ctx, change.ID, author, ""); !errors.Is(err, ErrReviewerConflict) {
+			t.Fatalf("expected ErrReviewerConflict for self approval, got %v", err)
+		}
+		pending, dest, err := svc.Approve(ctx, change.ID, first, "looks unused")
+		if err != nil {
+			t.Fatalf("first Approve: %v", err)
+		}
+		if dest != nil || pending.Status != domain.DestinationChangeStatusPendingReview {
+			t.Fatalf("expected change to stay pending after one approval, got %s", pending.Status)
+		}
+		if _, _, err = svc.Approve(ctx, change.ID, first, ""); !errors.Is(err, ErrAlreadyReviewed) {
+			t.Fatalf("expected ErrAlreadyReviewed, got %v", err)
+		}
+		approved, dest, err := svc.Approve(ctx, change.ID, second, "")
+		if err != nil {
+			t.Fatalf("second Approve: %v", err)
+		}
+		if approved.Status != domain.DestinationChangeStatusApproved || dest == nil || dest.Status != domain.DestinationStatusArchived {
+			t.Fatalf("expected delete applied after second approval")
+		}
+
+		approvals, err := svc.ListApprovals(ctx, change.ID)
+		if err != nil {
+			t.Fatalf("ListApprovals: %v", err)
+		}
+		if len(approvals) != 2 || approvals[0].ReviewerID != first || approvals[0].Comment == nil || *approvals[0].Comment != "looks unused" {
+			t.Fatalf("unexpected approvals: %#v", approvals)
+		}
+	})
+
+	t.Run("rejection resets collected approvals", func(t *testing.T) {
+		change := submitDelete()
+
+		if _, _, err := svc.Approve(ctx, change.ID, first, ""); err != nil {
+			t.Fatalf("Approve: %v", err)
+		}
+		if _, err := svc.Reject(ctx, change.ID, second, "Still used by tours"); err != nil {
+			t.Fatalf("Reject: %v", err)
+		}
+		if _, err := svc.SubmitDraft(ctx, change.ID, author); err != nil {
+			t.Fatalf("resubmit: %v", err)
+		}
+		pending, dest, err := svc.Approve(ctx, change.ID, second, "")
+		if err != nil {
+			t.Fatalf("Approve after resubmit: %v", err)
+		}
+		if dest != nil || pending.Status != domain.DestinationChangeStatusPendingReview {
+			t.Fatalf("earlier approval should not count after rejection")
+		}
+		if _, dest, err = svc.Approve(ctx, change.ID, first, ""); err != nil || dest == nil {
+			t.Fatalf("expected delete applied, got dest=%v err=%v", dest, err)
+		}
+
+		approvals, _ := approvalRepo.ListByChange(ctx, change.ID)
+		if len(approvals) != 4 || approvals[1].Decision != domain.DestinationApprovalDecisionRejected {
+			t.Fatalf("expected full decision history, got %#v", approvals)
+		}
+	})
+}
+
+// staleApprovalRepo lists no earlier decisions, as a request racing another
+// reviewer would see them, and rejects duplicates the way the unique index does.
+type staleApprovalRepo struct {
+	*memoryApprovalRepo
+}
+
+func (r staleApprovalRepo) Create(ctx context.Context, approval *domain.DestinationChangeApproval) (*domain.DestinationChangeApproval, error) {
+	existing, _ := r.memoryApprovalRepo.ListByChange(ctx, approval.ChangeRequestID)
+	for _, record := range existing {
+		if record.ReviewerID == approval.ReviewerID && record.DraftVersion == approval.DraftVersion {
+			return nil, &pgconn.PgError{Code: "23505"}
+		}
+	}
+	return r.memoryApprovalRepo.Create(ctx, approval)
+}
+
+func (r staleApprovalRepo) ListByChange(context.Context, uuid.UUID) ([]domain.DestinationChangeApproval, error) {
+	return nil, nil
+}
+
+type countingTransactor struct {
+	calls int
+}
+
+func (t *countingTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.calls++
+	return fn(ctx)
+}
+
+func TestDestinationWorkflowService_ApproveRecordsDecisionOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 12, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	tx := &countingTransactor{}
+
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), staleApprovalRepo{newMemoryApprovalRepo()}, &memoryStorage{}, DestinationWorkflowConfig{
+		ApprovalRequired:  true,
+		ApprovalsRequired: 2,
+		Transactor:        tx,
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	author := uuid.New()
+	reviewer := uuid.New()
+	existing := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Old Pier")}, author, domain.DestinationStatusPublished, nil)
+	change, err := svc.CreateDraft(ctx, author, DestinationDraftInput{
+		Action:        domain.DestinationChangeActionDelete,
+		DestinationID: &existing.ID,
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	if _, err = svc.SubmitDraft(ctx, change.ID, author); err != nil {
+		t.Fatalf("SubmitDraft: %v", err)
+	}
+
+	if _, _, err = svc.Approve(ctx, change.ID, reviewer, ""); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if _, dest, err := svc.Approve(ctx, change.ID, reviewer, ""); !errors.Is(err, ErrAlreadyReviewed) || dest != nil {
+		t.Fatalf("expected ErrAlreadyReviewed for a duplicate decision, got dest=%v err=%v", dest, err)
+	}
+	if tx.calls != 2 {
+		t.Fatalf("expected each approval to run in a transaction, got %d", tx.calls)
+	}
+	if stored, _ := destRepo.FindByID(ctx, existing.ID); stored.Status != domain.DestinationStatusPublished {
+		t.Fatalf("duplicate approval should not apply the change, got %s", stored.Status)
+	}
+}
+
 type memoryDestinationRepo struct {
-	mu    sync.Mutex
-	store map[uuid.UUID]*domain.Destination
-	now   time.Time
+	mu      sync.Mutex
+	store   map[uuid.UUID]*domain.Destination
+	retired map[string]uuid.UUID
+	now     time.Time
 }
 
 func newMemoryDestinationRepo(now time.Time) *memoryDestinationRepo {
@@ -570,8 +1357,9 @@ func newMemoryDestinationRepo(now time.Time) *memoryDestinationRepo {
 
 func newMemoryDestinationRepoWithClock(now time.Time) *memoryDestinationRepo {
 	return &memoryDestinationRepo{
-		store: make(map[uuid.UUID]*domain.Destination),
-		now:   now,
+		store:   make(map[uuid.UUID]*domain.Destination),
+		retired: make(map[string]uuid.UUID),
+		now:     now,
 	}
 }
 
@@ -596,12 +1384,20 @@ func (m *memoryDestinationRepo) Create(ctx context.Context, fields domain.Destin
 		Contact:     copyStringPtr(fields.Contact),
 		OpeningTime: copyStringPtr(fields.OpeningTime),
 		ClosingTime: copyStringPtr(fields.ClosingTime),
+		Timezone:    copyStringPtr(fields.Timezone),
 		Gallery:     copyGalleryValue(fields.Gallery),
 		HeroImage:   copyStringPtr(heroImageURL),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		UpdatedBy:   copyUUIDPtr(&createdBy),
 	}
+	dest.OpeningHours = cloneOpeningHours(fields.OpeningHours)
+	if fields.Translations != nil {
+		dest.Translations = cloneTranslations(*fields.Translations)
+	}
+	if fields.Tags != nil {
+		dest.Tags = cloneTags(*fields.Tags)
+	}
 	m.store[id] = cloneDestination(dest)
 	return cloneDestination(dest), nil
 }
@@ -619,7 +1415,7 @@ func (m *memoryDestinationRepo) Update(ctx context.Context, id uuid.UUID, fields
 		dest.Name = *fields.Name
 	}
 	if fields.Slug != nil {
-		dest.Slug = copyStringPtr(fields.Slug)
+		m.changeSlug(dest, fields.Slug)
 	}
 	if fields.City != nil {
 		dest.City = copyStringPtr(fields.City)
@@ -654,6 +1450,18 @@ func (m *memoryDestinationRepo) Update(ctx context.Context, id uuid.UUID, fields
 	if fields.Gallery != nil {
 		dest.Gallery = copyGalleryValue(fields.Gallery)
 	}
+	if fields.Timezone != nil {
+		dest.Timezone = copyStringPtr(fields.Timezone)
+	}
+	if fields.OpeningHours != nil {
+		dest.OpeningHours = cloneOpeningHours(fields.OpeningHours)
+	}
+	if fields.Translations != nil {
+		dest.Translations = cloneTranslations(*fields.Translations)
+	}
+	if fields.Tags != nil {
+		dest.Tags = cloneTags(*fields.Tags)
+	}
 	if statusOverride != nil {
 		dest.Status = *statusOverride
 	}
@@ -686,6 +1494,29 @@ func (m *memoryDestinationRepo) Archive(ctx context.Context, id uuid.UUID, updat
 	return cloneDestination(dest), nil
 }
 
+func (m *memoryDestinationRepo) Restore(ctx context.Context, id uuid.UUID, updatedBy uuid.UUID, status domain.DestinationStatus, slug *string) (*domain.Destination, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dest, ok := m.store[id]
+	if !ok || dest.Status != domain.DestinationStatusArchived {
+		return nil, sql.ErrNoRows
+	}
+
+	now := m.now
+	dest.Status = status
+	if slug != nil {
+		m.changeSlug(dest, slug)
+	}
+	dest.DeletedAt = nil
+	dest.Version++
+	dest.UpdatedAt = now
+	dest.UpdatedBy = copyUUIDPtr(&updatedBy)
+
+	m.store[id] = cloneDestination(dest)
+	return cloneDestination(dest), nil
+}
+
 func (m *memoryDestinationRepo) HardDelete(ctx context.Context, id uuid.UUID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -725,9 +1556,42 @@ func (m *memoryDestinationRepo) FindBySlug(ctx context.Context, slug string) (*d
 			return cloneDestination(dest), nil
 		}
 	}
+	if id, ok := m.retired[slug]; ok {
+		if dest, ok := m.store[id]; ok && dest.DeletedAt == nil {
+			found := cloneDestination(dest)
+			found.RetiredSlug = stringPtr(slug)
+			return found, nil
+		}
+	}
 	return nil, sql.ErrNoRows
 }
 
+func (m *memoryDestinationRepo) FindSlugOwner(ctx context.Context, slug string) (uuid.UUID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, dest := range m.store {
+		if dest.Slug != nil && *dest.Slug == slug {
+			return dest.ID, nil
+		}
+	}
+	if id, ok := m.retired[slug]; ok {
+		return id, nil
+	}
+	return uuid.Nil, sql.ErrNoRows
+}
+
+// changeSlug mirrors the slug history trigger: the old slug is retired and a
+// slug taken back leaves the history.
+func (m *memoryDestinationRepo) changeSlug(dest *domain.Destination, slug *string) {
+	if dest.Slug != nil && (slug == nil || *slug != *dest.Slug) {
+		m.retired[*dest.Slug] = dest.ID
+	}
+	if slug != nil && m.retired[*slug] == dest.ID {
+		delete(m.retired, *slug)
+	}
+	dest.Slug = copyStringPtr(slug)
+}
+
 func (m *memoryDestinationRepo) ListPublished(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -757,38 +1621,65 @@ func (m *memoryDestinationRepo) ListPublished(ctx context.Context, limit, offset
 				continue
 			}
 		}
+		if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, dest.ID) {
+			continue
+		}
+		if filter.City != nil && !containsFold(dest.City, *filter.City) {
+			continue
+		}
+		if filter.Country != nil && !containsFold(dest.Country, *filter.Country) {
+			continue
+		}
 		if filter.MinRating != nil && dest.AverageRating < *filter.MinRating {
 			continue
 		}
 		if filter.MaxRating != nil && dest.AverageRating > *filter.MaxRating {
 			continue
 		}
-		published = append(published, *cloneDestination(dest))
+		if filter.OpenAt != nil && !DestinationOpenAt(dest, *filter.OpenAt) {
+			continue
+		}
+		if len(filter.Tags) > 0 && !destinationHasTags(dest, filter.Tags, filter.TagMatch) {
+			continue
+		}
+		clone := cloneDestination(dest)
+		if filter.Latitude != nil && filter.Longitude != nil && dest.Latitude != nil && dest.Longitude != nil {
+			distance := haversineKM(*filter.Latitude, *filter.Longitude, *dest.Latitude, *dest.Longitude)
+			clone.DistanceKM = &distance
+		}
+		if filter.MaxDistanceKM != nil && (clone.DistanceKM == nil || *clone.DistanceKM > *filter.MaxDistanceKM) {
+			continue
+		}
+		if filter.BBox != nil && (clone.Latitude == nil || clone.Longitude == nil || !filter.BBox.Contains(*clone.Latitude, *clone.Longitude)) {
+			continue
+		}
+		published = append(published, *clone)
 	}
 
 	sort.SliceStable(published, func(i, j int) bool {
-		switch filter.Sort {
-		case domain.DestinationSortRatingAsc:
-			if published[i].AverageRating == published[j].AverageRating {
-				return strings.ToLower(published[i].Name) < strings.ToLower(published[j].Name)
-			}
-			return published[i].AverageRating < published[j].AverageRating
-		case domain.DestinationSortRatingDesc:
-			if published[i].AverageRating == published[j].AverageRating {
-				return strings.ToLower(published[i].Name) < strings.ToLower(published[j].Name)
-			}
-			return published[i].AverageRating > published[j].AverageRating
-		case domain.DestinationSortNameAsc:
-			return strings.ToLower(published[i].Name) < strings.ToLower(published[j].Name)
-		case domain.DestinationSortNameDesc:
-			return strings.ToLower(published[i].Name) > strings.ToLower(published[j].Name)
-		default:
-			if published[i].UpdatedAt.Equal(published[j].UpdatedAt) {
-				return strings.ToLower(published[i].Name) < strings.ToLower(published[j].Name)
+		return publishedSortsBefore(filter.Sort, &published[i], &published[j])
+	})
+	if filter.After != nil {
+		cursor := filter.After
+		marker := domain.Destination{
+			ID:            cursor.ID,
+			Name:          cursor.Name,
+			UpdatedAt:     cursor.UpdatedAt,
+			AverageRating: cursor.Rating,
+			DistanceKM:    cursor.Distance,
+		}
+		after := make([]domain.Destination, 0, len(published))
+		for idx := range published {
+			if publishedSortsBefore(filter.Sort, &marker, &published[idx]) {
+				after = append(after, published[idx])
 			}
-			return published[i].UpdatedAt.After(published[j].UpdatedAt)
 		}
-	})
+		published, offset = after, 0
+	} else {
+		for idx := range published {
+			published[idx].TotalCount = len(published)
+		}
+	}
 
 	if offset >= len(published) {
 		return []domain.Destination{}, nil
@@ -800,6 +1691,106 @@ func (m *memoryDestinationRepo) ListPublished(ctx context.Context, limit, offset
 	return published[offset:end], nil
 }
 
+func (m *memoryDestinationRepo) CountPublishedFacet(ctx context.Context, filter domain.DestinationListFilter, facet domain.DestinationFacet, limit int) ([]domain.DestinationFacetCount, error) {
+	matches, err := m.ListPublished(ctx, 0, 0, filter)
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]domain.DestinationFacetCount, 0)
+	if facet == domain.DestinationFacetRating {
+		for _, band := range domain.DestinationRatingBands {
+			count := 0
+			for _, dest := range matches {
+				if dest.AverageRating >= float64(band) {
+					count++
+				}
+			}
+			counts = append(counts, domain.DestinationFacetCount{Value: strconv.Itoa(band), Count: count})
+		}
+		return counts, nil
+	}
+	byValue := make(map[string]int)
+	for _, dest := range matches {
+		var value *string
+		switch facet {
+		case domain.DestinationFacetCategory:
+			value = dest.Category
+		case domain.DestinationFacetCountry:
+			value = dest.Country
+		case domain.DestinationFacetCity:
+			value = dest.City
+		}
+		if value != nil && strings.TrimSpace(*value) != "" {
+			byValue[*value]++
+		}
+	}
+	for value, count := range byValue {
+		counts = append(counts, domain.DestinationFacetCount{Value: value, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts, nil
+}
+
+func containsFold(value *string, needle string) bool {
+	needle = strings.ToLower(strings.TrimSpace(needle))
+	if needle == "" {
+		return true
+	}
+	return value != nil && strings.Contains(strings.ToLower(*value), needle)
+}
+
+// publishedSortsBefore mirrors the postgres ORDER BY for each sort, ending in
+// the id tiebreaker.
+func publishedSortsBefore(order domain.DestinationListSort, a, b *domain.Destination) bool {
+	nameA, nameB := strings.ToLower(a.Name), strings.ToLower(b.Name)
+	byNameThenID := func() bool {
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		return a.ID.String() < b.ID.String()
+	}
+	switch order {
+	case domain.DestinationSortRatingAsc:
+		if a.AverageRating != b.AverageRating {
+			return a.AverageRating < b.AverageRating
+		}
+		return byNameThenID()
+	case domain.DestinationSortRatingDesc:
+		if a.AverageRating != b.AverageRating {
+			return a.AverageRating > b.AverageRating
+		}
+		return byNameThenID()
+	case domain.DestinationSortNameAsc:
+		return byNameThenID()
+	case domain.DestinationSortNameDesc:
+		if nameA != nameB {
+			return nameA > nameB
+		}
+		return a.ID.String() > b.ID.String()
+	case domain.DestinationSortDistanceAsc:
+		if (a.DistanceKM == nil) != (b.DistanceKM == nil) {
+			return a.DistanceKM != nil
+		}
+		if a.DistanceKM != nil && *a.DistanceKM != *b.DistanceKM {
+			return *a.DistanceKM < *b.DistanceKM
+		}
+		return byNameThenID()
+	default:
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+		return byNameThenID()
+	}
+}
+
 func (m *memoryDestinationRepo) Autocomplete(ctx context.Context, query string, limit int) ([]string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -812,19 +1803,27 @@ func (m *memoryDestinationRepo) Autocomplete(ctx context.Context, query string,
 		if dest.Status != domain.DestinationStatusPublished || dest.DeletedAt != nil {
 			continue
 		}
-		name := strings.TrimSpace(dest.Name)
-		if name == "" {
-			continue
-		}
-		lower := strings.ToLower(name)
-		if needle != "" && !strings.Contains(lower, needle) {
-			continue
+		candidates := []string{dest.Name}
+		for _, translation := range dest.Translations {
+			if translation.Name != nil {
+				candidates = append(candidates, *translation.Name)
+			}
 		}
-		if _, ok := seen[lower]; ok {
-			continue
+		for _, candidate := range candidates {
+			name := strings.TrimSpace(candidate)
+			if name == "" {
+				continue
+			}
+			lower := strings.ToLower(name)
+			if needle != "" && !strings.Contains(lower, needle) {
+				continue
+			}
+			if _, ok := seen[lower]; ok {
+				continue
+			}
+			seen[lower] = struct{}{}
+			names = append(names, name)
 		}
-		seen[lower] = struct{}{}
-		names = append(names, name)
 	}
 
 	sort.Strings(names)
@@ -834,6 +1833,157 @@ func (m *memoryDestinationRepo) Autocomplete(ctx context.Context, query string,
 	return names, nil
 }
 
+func (m *memoryDestinationRepo) ClusterPublished(ctx context.Context, filter domain.DestinationListFilter, cellDeg float64, limit int) ([]domain.DestinationMapCluster, error) {
+	matches, err := m.ListPublished(ctx, len(m.store), 0, filter)
+	if err != nil {
+		return nil, err
+	}
+	type cell struct{ x, y float64 }
+	clusters := make(map[cell]*domain.DestinationMapCluster)
+	order := make([]cell, 0)
+	for idx := range matches {
+		dest := matches[idx]
+		if dest.Latitude == nil || dest.Longitude == nil {
+			continue
+		}
+		key := cell{math.Floor(*dest.Latitude / cellDeg), math.Floor(*dest.Longitude / cellDeg)}
+		cluster, ok := clusters[key]
+		if !ok {
+			cluster = &domain.DestinationMapCluster{DestinationID: &dest.ID, Name: &dest.Name, Slug: dest.Slug}
+			clusters[key] = cluster
+			order = append(order, key)
+		}
+		cluster.Latitude = (cluster.Latitude*float64(cluster.Count) + *dest.Latitude) / float64(cluster.Count+1)
+		cluster.Longitude = (cluster.Longitude*float64(cluster.Count) + *dest.Longitude) / float64(cluster.Count+1)
+		cluster.Count++
+		if cluster.Count > 1 {
+			cluster.DestinationID, cluster.Name, cluster.Slug = nil, nil, nil
+		}
+	}
+	out := make([]domain.DestinationMapCluster, 0, len(order))
+	for _, key := range order {
+		out = append(out, *clusters[key])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Count > out[j].Count })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memoryDestinationRepo) ListSimilarCandidates(ctx context.Context, dest *domain.Destination, radiusKM float64, limit int) ([]domain.Destination, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]domain.Destination, 0)
+	for _, candidate := range m.store {
+		if candidate.ID == dest.ID || candidate.Status != domain.DestinationStatusPublished || candidate.DeletedAt != nil {
+			continue
+		}
+		related := dest.Category != nil && candidate.Category != nil && strings.EqualFold(*dest.Category, *candidate.Category)
+		related = related || destinationHasTags(candidate, dest.Tags, domain.DestinationTagMatchAny)
+		if dest.Latitude != nil && dest.Longitude != nil && candidate.Latitude != nil && candidate.Longitude != nil {
+			related = related || haversineKM(*dest.Latitude, *dest.Longitude, *candidate.Latitude, *candidate.Longitude) <= radiusKM
+		}
+		if related {
+			out = append(out, *cloneDestination(candidate))
+		}
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memoryDestinationRepo) CatalogVersion(ctx context.Context) (domain.DestinationCatalogVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var version domain.DestinationCatalogVersion
+	for _, dest := range m.store {
+		if dest.UpdatedAt.After(version.UpdatedAt) {
+			version.UpdatedAt = dest.UpdatedAt
+		}
+		if dest.Status == domain.DestinationStatusPublished && dest.DeletedAt == nil {
+			version.Destinations++
+		}
+	}
+	return version, nil
+}
+
+func (m *memoryDestinationRepo) ListForExport(ctx context.Context, filter domain.DestinationExportFilter, limit int) ([]domain.Destination, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]domain.Destination, 0)
+	for _, dest := range m.store {
+		if !destinationMatchesExport(dest, filter) {
+			continue
+		}
+		if filter.AfterID != nil && dest.ID.String() <= filter.AfterID.String() {
+			continue
+		}
+		out = append(out, *cloneDestination(dest))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID.String() < out[j].ID.String() })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memoryDestinationRepo) ExportLayout(ctx context.Context, filter domain.DestinationExportFilter) (domain.DestinationExportLayout, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var layout domain.DestinationExportLayout
+	for _, dest := range m.store {
+		if !destinationMatchesExport(dest, filter) {
+			continue
+		}
+		layout.GallerySlots = max(layout.GallerySlots, len(dest.Gallery))
+		for locale := range dest.Translations {
+			if !slices.Contains(layout.Locales, locale) {
+				layout.Locales = append(layout.Locales, locale)
+			}
+		}
+	}
+	sort.Strings(layout.Locales)
+	return layout, nil
+}
+
+func destinationMatchesExport(dest *domain.Destination, filter domain.DestinationExportFilter) bool {
+	if dest.DeletedAt != nil && !slices.Contains(filter.Statuses, domain.DestinationStatusArchived) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, dest.Status) {
+		return false
+	}
+	if len(filter.Categories) > 0 {
+		if dest.Category == nil || !slices.ContainsFunc(filter.Categories, func(category string) bool { return strings.EqualFold(category, *dest.Category) }) {
+			return false
+		}
+	}
+	return filter.UpdatedSince == nil || !dest.UpdatedAt.Before(*filter.UpdatedSince)
+}
+
+func destinationHasTags(dest *domain.Destination, tags []string, match domain.DestinationTagMatch) bool {
+	have := make(map[string]struct{}, len(dest.Tags))
+	for _, tag := range dest.Tags {
+		have[tag] = struct{}{}
+	}
+	matched := 0
+	for _, tag := range tags {
+		if _, ok := have[tag]; ok {
+			matched++
+		}
+	}
+	if match == domain.DestinationTagMatchAll {
+		return matched == len(tags)
+	}
+	return matched > 0
+}
+
 func destinationMatchesQuery(dest *domain.Destination, needle string) bool {
 	if dest == nil {
 		return false
@@ -859,6 +2009,14 @@ func destinationMatchesQuery(dest *domain.Destination, needle string) bool {
 	if dest.Contact != nil {
 		fields = append(fields, *dest.Contact)
 	}
+	for _, translation := range dest.Translations {
+		if translation.Name != nil {
+			fields = append(fields, *translation.Name)
+		}
+		if translation.Description != nil {
+			fields = append(fields, *translation.Description)
+		}
+	}
 	for _, field := range fields {
 		if strings.Contains(strings.ToLower(field), needle) {
 			return true
@@ -921,6 +2079,9 @@ func (m *memoryChangeRepo) MarkSubmitted(ctx context.Context, id uuid.UUID, subm
 	if change.Status != domain.DestinationChangeStatusDraft && change.Status != domain.DestinationChangeStatusRejected {
 		return nil, sql.ErrNoRows
 	}
+	if change.Status == domain.DestinationChangeStatusRejected {
+		change.DraftVersion++
+	}
 	change.Status = domain.DestinationChangeStatusPendingReview
 	change.SubmittedAt = &submittedAt
 	change.UpdatedAt = submittedAt
@@ -938,6 +2099,10 @@ func (m *memoryChangeRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.
 	return cloneChange(change), nil
 }
 
+func (m *memoryChangeRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.DestinationChangeRequest, error) {
+	return m.FindByID(ctx, id)
+}
+
 func (m *memoryChangeRepo) List(ctx context.Context, filter domain.DestinationChangeFilter) ([]domain.DestinationChangeRequest, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -961,8 +2126,27 @@ func (m *memoryChangeRepo) List(ctx context.Context, filter domain.DestinationCh
 				continue
 			}
 		}
+		if len(filter.Actions) > 0 {
+			match := false
+			for _, action := range filter.Actions {
+				if change.Action == action {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
 		out = append(out, *cloneChange(change))
 	}
+	total := len(out)
+	if filter.Limit > 0 && len(out) > filter.Limit {
+		out = out[:filter.Limit]
+	}
+	for i := range out {
+		out[i].TotalCount = total
+	}
 	return out, nil
 }
 
@@ -1022,6 +2206,45 @@ func (m *memoryVersionRepo) ListByDestination(ctx context.Context, destinationID
 	return append([]domain.DestinationVersion(nil), out...), nil
 }
 
+func (m *memoryVersionRepo) FindByVersion(ctx context.Context, destinationID uuid.UUID, version int64) (*domain.DestinationVersion, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rec := range m.records[destinationID] {
+		if rec.Version == version {
+			found := rec
+			return &found, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+type memoryApprovalRepo struct {
+	mu      sync.Mutex
+	records map[uuid.UUID][]domain.DestinationChangeApproval
+}
+
+func newMemoryApprovalRepo() *memoryApprovalRepo {
+	return &memoryApprovalRepo{records: make(map[uuid.UUID][]domain.DestinationChangeApproval)}
+}
+
+func (m *memoryApprovalRepo) Create(ctx context.Context, approval *domain.DestinationChangeApproval) (*domain.DestinationChangeApproval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec := *approval
+	rec.ID = uuid.New()
+	rec.Comment = copyStringPtr(approval.Comment)
+	m.records[rec.ChangeRequestID] = append(m.records[rec.ChangeRequestID], rec)
+	return &rec, nil
+}
+
+func (m *memoryApprovalRepo) ListByChange(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeApproval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.DestinationChangeApproval, len(m.records[changeID]))
+	copy(out, m.records[changeID])
+	return out, nil
+}
+
 type memoryStorage struct {
 	objects sync.Map
 }
@@ -1035,6 +2258,11 @@ func (m *memoryStorage) Upload(ctx context.Context, bucket, objectName, contentT
 	return "https://cdn.local/" + objectName, nil
 }
 
+func (m *memoryStorage) Delete(ctx context.Context, bucket, objectName string) error {
+	m.objects.Delete(objectName)
+	return nil
+}
+
 // helpers
 
 func cloneDestination(src *domain.Destination) *domain.Destination {
@@ -1052,6 +2280,10 @@ func cloneDestination(src *domain.Destination) *domain.Destination {
 	dest.Contact = copyStringPtr(src.Contact)
 	dest.OpeningTime = copyStringPtr(src.OpeningTime)
 	dest.ClosingTime = copyStringPtr(src.ClosingTime)
+	dest.Timezone = copyStringPtr(src.Timezone)
+	dest.OpeningHours = cloneOpeningHours(src.OpeningHours)
+	dest.Translations = cloneTranslations(src.Translations)
+	dest.Tags = cloneTags(src.Tags)
 	dest.Gallery = cloneGalleryTest(src.Gallery)
 	dest.HeroImage = copyStringPtr(src.HeroImage)
 	dest.UpdatedBy = copyUUIDPtr(src.UpdatedBy)
@@ -1107,6 +2339,8 @@ func cloneChange(src *domain.DestinationChangeRequest) *domain.DestinationChange
 	change.ReviewMessage = copyStringPtr(src.ReviewMessage)
 	change.SubmittedAt = copyTimePtr(src.SubmittedAt)
 	change.ReviewedAt = copyTimePtr(src.ReviewedAt)
+	change.PublishedVersion = copyInt64Ptr(src.PublishedVersion)
+	change.BaseVersion = copyInt64Ptr(src.BaseVersion)
 	return &change
 }
 
@@ -1164,6 +2398,10 @@ func copyChangeFields(src domain.DestinationChangeFields) domain.DestinationChan
 		Contact:            copyStringPtr(src.Contact),
 		OpeningTime:        copyStringPtr(src.OpeningTime),
 		ClosingTime:        copyStringPtr(src.ClosingTime),
+		Timezone:           copyStringPtr(src.Timezone),
+		OpeningHours:       copyOpeningHoursPtr(src.OpeningHours),
+		Translations:       copyTranslationsPtr(src.Translations),
+		Tags:               copyTagsPtr(src.Tags),
 		Gallery:            copyGalleryPtr(src.Gallery),
 		Latitude:           copyFloatPtr(src.Latitude),
 		Longitude:          copyFloatPtr(src.Longitude),
@@ -1172,7 +2410,38 @@ func copyChangeFields(src domain.DestinationChangeFields) domain.DestinationChan
 		HeroImageURL:       copyStringPtr(src.HeroImageURL),
 		PublishedHeroImage: copyStringPtr(src.PublishedHeroImage),
 		HardDelete:         copyBoolPtr(src.HardDelete),
+		SourceVersion:      copyInt64Ptr(src.SourceVersion),
+		RemovedMedia:       append([]string(nil), src.RemovedMedia...),
+	}
+}
+
+func copyTranslationsPtr(src *domain.DestinationTranslations) *domain.DestinationTranslations {
+	if src == nil {
+		return nil
+	}
+	return translationsOrEmpty(*src)
+}
+
+func copyTagsPtr(src *domain.DestinationTags) *domain.DestinationTags {
+	if src == nil {
+		return nil
+	}
+	return tagsOrEmpty(*src)
+}
+
+func copyOpeningHoursPtr(src *domain.OpeningHours) *domain.OpeningHours {
+	if src == nil {
+		return nil
+	}
+	return openingHoursOrEmpty(src)
+}
+
+func copyInt64Ptr(src *int64) *int64 {
+	if src == nil {
+		return nil
 	}
+	v := *src
+	return &v
 }
 
 func copyStatusPtr(src *domain.DestinationStatus) *domain.DestinationStatus {
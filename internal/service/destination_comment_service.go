@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+var (
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrCommentValidation = errors.New("comment validation failed")
+)
+
+const (
+	maxCommentLength = 4000
+	// commentNotifyTimeout bounds how long comment notifications may take once
+	// they leave the request.
+	commentNotifyTimeout = time.Minute
+)
+
+// commentAnchorFields are the change fields a comment may be pinned to. They
+// match the field names reported by change diffs.
+var commentAnchorFields = map[string]struct{}{
+	"name": {}, "slug": {}, "status": {}, "city": {}, "country": {}, "category": {},
+	"description": {}, "latitude": {}, "longitude": {}, "contact": {},
+	"opening_time": {}, "closing_time": {}, "timezone": {}, "opening_hours": {}, "translations": {}, "tags": {}, "hero_image_url": {}, "gallery": {},
+}
+
+// ChangeCommentNotifier is told about new comments so the people involved in a
+// change request can be alerted. It is called in the background; failures are
+// logged and never block the comment itself.
+type ChangeCommentNotifier interface {
+	NotifyChangeComment(ctx context.Context, recipientID uuid.UUID, change *domain.DestinationChangeRequest, comment *domain.DestinationChangeComment) error
+}
+
+type ChangeCommentInput struct {
+	Body     string
+	Field    *string
+	ParentID *uuid.UUID
+}
+
+type DestinationCommentService struct {
+	changes  ports.DestinationChangeRepository
+	comments ports.DestinationCommentRepository
+	notifier ChangeCommentNotifier
+	now      func() time.Time
+	// pending tracks notifications still being sent.
+	pending sync.WaitGroup
+}
+
+func NewDestinationCommentService(changes ports.DestinationChangeRepository, comments ports.DestinationCommentRepository, notifier ChangeCommentNotifier) *DestinationCommentService {
+	return &DestinationCommentService{
+		changes:  changes,
+		comments: comments,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+func (s *DestinationCommentService) SetClock(now func() time.Time) {
+	if now != nil {
+		s.now = now
+	}
+}
+
+// Wait blocks until every notification already started has been sent or has
+// timed out. Call it on shutdown, once the server stops taking requests.
+func (s *DestinationCommentService) Wait() {
+	s.pending.Wait()
+}
+
+// AddComment starts a thread on a change request, or replies to one when
+// ParentID is set. Replies to replies are attached to the thread root.
+func (s *DestinationCommentService) AddComment(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, input ChangeCommentInput) (*domain.DestinationChangeComment, error) {
+	change, err := s.changes.FindByID(ctx, changeID)
+	if err != nil {
+		return nil, ErrDestinationChangeNotFound
+	}
+
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: body is required", ErrCommentValidation)
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return nil, fmt.Errorf("%w: body must be at most %d characters", ErrCommentValidation, maxCommentLength)
+	}
+
+	var field *string
+	if input.Field != nil {
+		trimmed := strings.ToLower(strings.TrimSpace(*input.Field))
+		if trimmed != "" {
+			if _, ok := commentAnchorFields[trimmed]; !ok {
+				return nil, fmt.Errorf("%w: unknown field %q", ErrCommentValidation, trimmed)
+			}
+			field = &trimmed
+		}
+	}
+
+	var root *domain.DestinationChangeComment
+	if input.ParentID != nil {
+		root, err = s.threadRoot(ctx, changeID, *input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if field == nil {
+			field = root.Field
+		}
+	}
+
+	now := s.now()
+	comment := &domain.DestinationChangeComment{
+		ChangeRequestID: changeID,
+		AuthorID:        authorID,
+		Body:            body,
+		Field:           field,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if root != nil {
+		comment.ParentID = &root.ID
+	}
+	created, err := s.comments.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, change, root, created)
+	return created, nil
+}
+
+func (s *DestinationCommentService) ListComments(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeComment, error) {
+	if _, err := s.changes.FindByID(ctx, changeID); err != nil {
+		return nil, ErrDestinationChangeNotFound
+	}
+	return s.comments.ListByChange(ctx, changeID)
+}
+
+// ResolveThread marks the thread containing commentID as resolved.
+func (s *DestinationCommentService) ResolveThread(ctx context.Context, changeID, commentID, actorID uuid.UUID) (*domain.DestinationChangeComment, error) {
+	root, err := s.threadRoot(ctx, changeID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if root.Resolved() {
+		return root, nil
+	}
+	now := s.now()
+	return s.comments.SetResolved(ctx, root.ID, &actorID, &now)
+}
+
+// UnresolveThread reopens the thread containing commentID.
+func (s *DestinationCommentService) UnresolveThread(ctx context.Context, changeID, commentID uuid.UUID) (*domain.DestinationChangeComment, error) {
+	root, err := s.threadRoot(ctx, changeID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if !root.Resolved() {
+		return root, nil
+	}
+	return s.comments.SetResolved(ctx, root.ID, nil, nil)
+}
+
+func (s *DestinationCommentService) threadRoot(ctx context.Context, changeID, commentID uuid.UUID) (*domain.DestinationChangeComment, error) {
+	comment, err := s.comments.FindByID(ctx, commentID)
+	if err != nil || comment == nil || comment.ChangeRequestID != changeID {
+		return nil, ErrCommentNotFound
+	}
+	if comment.ParentID == nil {
+		return comment, nil
+	}
+	root, err := s.comments.FindByID(ctx, *comment.ParentID)
+	if err != nil || root == nil {
+		return nil, ErrCommentNotFound
+	}
+	return root, nil
+}
+
+// notify alerts the change author and, for replies, the thread starter, in the
+// background so slow mail delivery does not hold up the request. The commenter
+// is never notified about their own comment.
+func (s *DestinationCommentService) notify(ctx context.Context, change *domain.DestinationChangeRequest, root, comment *domain.DestinationChangeComment) {
+	if s.notifier == nil {
+		return
+	}
+	recipients := []uuid.UUID{change.SubmittedBy}
+	if root != nil {
+		recipients = append(recipients, root.AuthorID)
+	}
+	seen := map[uuid.UUID]struct{}{comment.AuthorID: {}}
+	targets := make([]uuid.UUID, 0, len(recipients))
+	for _, recipient := range recipients {
+		if _, ok := seen[recipient]; ok {
+			continue
+		}
+		seen[recipient] = struct{}{}
+		targets = append(targets, recipient)
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), commentNotifyTimeout)
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		defer cancel()
+		for _, recipient := range targets {
+			if err := s.notifier.NotifyChangeComment(ctx, recipient, change, comment); err != nil {
+				log.Printf("change comment: notify %s about %s failed: %v", recipient, comment.ID, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationCommentService_Threads(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 13, 10, 0, 0, 0, time.UTC)
+	author := uuid.New()
+	reviewer := uuid.New()
+	editor := uuid.New()
+
+	changeRepo := newMemoryChangeRepo()
+	change, err := changeRepo.Create(ctx, &domain.DestinationChangeRequest{
+		Action:      domain.DestinationChangeActionCreate,
+		Status:      domain.DestinationChangeStatusPendingReview,
+		SubmittedBy: author,
+		Payload:     domain.DestinationChangeFields{Name: strPtr("Wat Arun")},
+	})
+	if err != nil {
+		t.Fatalf("create change: %v", err)
+	}
+
+	notifier := &recordingCommentNotifier{}
+	svc := NewDestinationCommentService(changeRepo, newMemoryCommentRepo(), notifier)
+	svc.SetClock(func() time.Time { return now })
+
+	if _, err = svc.AddComment(ctx, change.ID, reviewer, ChangeCommentInput{Body: "  "}); !errors.Is(err, ErrCommentValidation) {
+		t.Fatalf("expected ErrCommentValidation for empty body, got %v", err)
+	}
+	if _, err = svc.AddComment(ctx, change.ID, reviewer, ChangeCommentInput{Body: "hi", Field: strPtr("rating")}); !errors.Is(err, ErrCommentValidation) {
+		t.Fatalf("expected ErrCommentValidation for unknown field, got %v", err)
+	}
+
+	root, err := svc.AddComment(ctx, change.ID, reviewer, ChangeCommentInput{Body: "Please expand this", Field: strPtr("Description")})
+	if err != nil {
+		t.Fatalf("AddComment: %v", err)
+	}
+	if root.Field == nil || *root.Field != "description" {
+		t.Fatalf("expected description anchor, got %v", root.Field)
+	}
+	reply, err := svc.AddComment(ctx, change.ID, author, ChangeCommentInput{Body: "Done", ParentID: &root.ID})
+	if err != nil {
+		t.Fatalf("AddComment reply: %v", err)
+	}
+	nested, err := svc.AddComment(ctx, change.ID, editor, ChangeCommentInput{Body: "Looks good", ParentID: &reply.ID})
+	if err != nil {
+		t.Fatalf("AddComment nested reply: %v", err)
+	}
+	if nested.ParentID == nil || *nested.ParentID != root.ID || nested.Field == nil || *nested.Field != "description" {
+		t.Fatalf("nested reply should join root thread and inherit anchor, got %#v", nested)
+	}
+
+	svc.Wait()
+	counts := make(map[uuid.UUID]int)
+	for _, id := range notifier.recipients {
+		counts[id]++
+	}
+	if len(notifier.recipients) != 4 || counts[author] != 2 || counts[reviewer] != 2 {
+		t.Fatalf("expected two notifications each for author and reviewer, got %v", notifier.recipients)
+	}
+
+	resolved, err := svc.ResolveThread(ctx, change.ID, reply.ID, reviewer)
+	if err != nil {
+		t.Fatalf("ResolveThread: %v", err)
+	}
+	if resolved.ID != root.ID || !resolved.Resolved() || *resolved.ResolvedBy != reviewer {
+		t.Fatalf("expected root thread resolved, got %#v", resolved)
+	}
+	reopened, err := svc.UnresolveThread(ctx, change.ID, root.ID)
+	if err != nil {
+		t.Fatalf("UnresolveThread: %v", err)
+	}
+	if reopened.Resolved() || reopened.ResolvedBy != nil {
+		t.Fatalf("expected thread reopened, got %#v", reopened)
+	}
+
+	if _, err = svc.ResolveThread(ctx, uuid.New(), root.ID, reviewer); !errors.Is(err, ErrCommentNotFound) {
+		t.Fatalf("expected ErrCommentNotFound for comment on another change, got %v", err)
+	}
+
+	comments, err := svc.ListComments(ctx, change.ID)
+	if err != nil {
+		t.Fatalf("ListComments: %v", err)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("expected 3 comments, got %d", len(comments))
+	}
+}
+
+func TestDestinationCommentService_NotifiesInBackground(t *testing.T) {
+	ctx := context.Background()
+	changeRepo := newMemoryChangeRepo()
+	change, err := changeRepo.Create(ctx, &domain.DestinationChangeRequest{
+		Action:      domain.DestinationChangeActionCreate,
+		Status:      domain.DestinationChangeStatusPendingReview,
+		SubmittedBy: uuid.New(),
+	})
+	if err != nil {
+		t.Fatalf("create change: %v", err)
+	}
+
+	release := make(chan struct{})
+	notifier := &recordingCommentNotifier{block: release}
+	svc := NewDestinationCommentService(changeRepo, newMemoryCommentRepo(), notifier)
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	if _, err = svc.AddComment(reqCtx, change.ID, uuid.New(), ChangeCommentInput{Body: "Needs a photo"}); err != nil {
+		t.Fatalf("AddComment should not wait for delivery: %v", err)
+	}
+	cancel()
+	close(release)
+	svc.Wait()
+
+	if len(notifier.recipients) != 1 || notifier.recipients[0] != change.SubmittedBy {
+		t.Fatalf("expected the author notified, got %v", notifier.recipients)
+	}
+	if notifier.ctxErr != nil {
+		t.Fatalf("delivery should outlive the request, got %v", notifier.ctxErr)
+	}
+}
+
+type recordingCommentNotifier struct {
+	mu         sync.Mutex
+	recipients []uuid.UUID
+	block      chan struct{}
+	ctxErr     error
+}
+
+func (n *recordingCommentNotifier) NotifyChangeComment(ctx context.Context, recipientID uuid.UUID, change *domain.DestinationChangeRequest, comment *domain.DestinationChangeComment) error {
+	if n.block != nil {
+		<-n.block
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.recipients = append(n.recipients, recipientID)
+	if err := ctx.Err(); err != nil {
+		n.ctxErr = err
+	}
+	return nil
+}
+
+type memoryCommentRepo struct {
+	mu      sync.Mutex
+	records []domain.DestinationChangeComment
+}
+
+func newMemoryCommentRepo() *memoryCommentRepo {
+	return &memoryCommentRepo{}
+}
+
+func (m *memoryCommentRepo) Create(ctx context.Context, comment *domain.DestinationChangeComment) (*domain.DestinationChangeComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rec := *comment
+	rec.ID = uuid.New()
+	m.records = append(m.records, rec)
+	return &rec, nil
+}
+
+func (m *memoryCommentRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.DestinationChangeComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rec := range m.records {
+		if rec.ID == id {
+			found := rec
+			return &found, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (m *memoryCommentRepo) ListByChange(ctx context.Context, changeID uuid.UUID) ([]domain.DestinationChangeComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.DestinationChangeComment, 0)
+	for _, rec := range m.records {
+		if rec.ChangeRequestID == changeID {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryCommentRepo) SetResolved(ctx context.Context, id uuid.UUID, resolvedBy *uuid.UUID, resolvedAt *time.Time) (*domain.DestinationChangeComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.records {
+		if m.records[i].ID == id {
+			m.records[i].ResolvedBy = copyUUIDPtr(resolvedBy)
+			m.records[i].ResolvedAt = copyTimePtr(resolvedAt)
+			found := m.records[i]
+			return &found, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func TestDestinationCommentService_LimitsBodyInCharacters(t *testing.T) {
+	ctx := context.Background()
+	changeRepo := newMemoryChangeRepo()
+	change, err := changeRepo.Create(ctx, &domain.DestinationChangeRequest{
+		Action:      domain.DestinationChangeActionCreate,
+		Status:      domain.DestinationChangeStatusPendingReview,
+		SubmittedBy: uuid.New(),
+		Payload:     domain.DestinationChangeFields{Name: strPtr("Wat Arun")},
+	})
+	if err != nil {
+		t.Fatalf("create change: %v", err)
+	}
+	svc := NewDestinationCommentService(changeRepo, newMemoryCommentRepo(), nil)
+	reviewer := uuid.New()
+
+	// Thai takes three bytes per character in UTF-8.
+	if _, err := svc.AddComment(ctx, change.ID, reviewer, ChangeCommentInput{Body: strings.Repeat("ก", maxCommentLength)}); err != nil {
+		t.Fatalf("expected a Thai comment at the limit accepted, got %v", err)
+	}
+	if _, err := svc.AddComment(ctx, change.ID, reviewer, ChangeCommentInput{Body: strings.Repeat("ก", maxCommentLength+1)}); !errors.Is(err, ErrCommentValidation) {
+		t.Fatalf("expected ErrCommentValidation past the limit, got %v", err)
+	}
+}
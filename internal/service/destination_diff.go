@@ -0,0 +1,455 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// DiffChange compares what a change request would publish against the live
+// destination it targets. Creates are compared against an empty destination.
+func (s *DestinationWorkflowService) DiffChange(ctx context.Context, changeID uuid.UUID) (*domain.DestinationDiff, error) {
+	change, err := s.changes.FindByID(ctx, changeID)
+	if err != nil {
+		return nil, ErrDestinationChangeNotFound
+	}
+
+	var before domain.DestinationSnapshot
+	if change.DestinationID != nil {
+		dest, err := s.destinations.FindByID(ctx, *change.DestinationID)
+		if err != nil {
+			return nil, ErrDestinationNotFound
+		}
+		before = snapshotFromDestination(dest)
+	}
+
+	after := before
+	switch change.Action {
+	case domain.DestinationChangeActionCreate:
+		after = applyChangeFieldsToSnapshot(domain.DestinationSnapshot{Status: domain.DestinationStatusPublished}, change.Payload)
+	case domain.DestinationChangeActionUpdate:
+		after = applyChangeFieldsToSnapshot(before, change.Payload)
+	case domain.DestinationChangeActionDelete:
+		after.Status = domain.DestinationStatusArchived
+	case domain.DestinationChangeActionRestore:
+		after.Status = domain.DestinationStatusPublished
+		if change.Payload.Status != nil {
+			after.Status = *change.Payload.Status
+		}
+		if change.Payload.Slug != nil && *change.Payload.Slug != "" {
+			after.Slug = stringPtr(*change.Payload.Slug)
+		}
+	default:
+		return nil, ErrInvalidChangeAction
+	}
+
+	diff := diffSnapshots(before, after)
+	diff.DestinationID = change.DestinationID
+	if change.DestinationID != nil {
+		version := before.Version
+		diff.FromVersion = &version
+	}
+	return &diff, nil
+}
+
+// DiffVersions compares two stored snapshots of the same destination.
+func (s *DestinationWorkflowService) DiffVersions(ctx context.Context, destinationID uuid.UUID, fromVersion, toVersion int64) (*domain.DestinationDiff, error) {
+	from, err := s.findVersion(ctx, destinationID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findVersion(ctx, destinationID, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffSnapshots(from.Snapshot, to.Snapshot)
+	diff.DestinationID = &destinationID
+	diff.FromVersion = &from.Version
+	diff.ToVersion = &to.Version
+	return &diff, nil
+}
+
+// detectConflicts lists the fields an update draft edits that were also changed
+// between the draft's base version and the destination's current version.
+func (s *DestinationWorkflowService) detectConflicts(ctx context.Context, change *domain.DestinationChangeRequest, dest *domain.Destination) ([]domain.DestinationFieldConflict, error) {
+	current := snapshotFromDestination(dest)
+	draft := applyChangeFieldsToSnapshot(current, change.Payload)
+	pending := diffSnapshots(current, draft)
+	conflicts := make([]domain.DestinationFieldConflict, 0)
+	if pending.IsEmpty() || change.BaseVersion == nil {
+		return conflicts, nil
+	}
+
+	var upstream *domain.DestinationDiff
+	record, err := s.versions.FindByVersion(ctx, dest.ID, *change.BaseVersion)
+	switch {
+	case err == nil && record != nil:
+		diff := diffSnapshots(record.Snapshot, current)
+		upstream = &diff
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	upstreamFields := make(map[string]domain.DestinationFieldChange)
+	if upstream != nil {
+		for _, field := range upstream.Fields {
+			upstreamFields[field.Field] = field
+		}
+	}
+	for _, field := range pending.Fields {
+		if upstream == nil {
+			conflicts = append(conflicts, domain.DestinationFieldConflict{Field: field.Field, Current: field.Old, Draft: field.New})
+			continue
+		}
+		if moved, ok := upstreamFields[field.Field]; ok {
+			conflicts = append(conflicts, domain.DestinationFieldConflict{Field: field.Field, Base: moved.Old, Current: moved.New, Draft: field.New})
+		}
+	}
+	if len(pending.Gallery) > 0 && (upstream == nil || len(upstream.Gallery) > 0) {
+		conflict := domain.DestinationFieldConflict{Field: "gallery", Current: current.Gallery, Draft: draft.Gallery}
+		if record != nil {
+			conflict.Base = record.Snapshot.Gallery
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, nil
+}
+
+// clearChangeField removes a single field edit from a draft payload.
+func clearChangeField(fields *domain.DestinationChangeFields, field string) {
+	switch field {
+	case "name":
+		fields.Name = nil
+	case "slug":
+		fields.Slug = nil
+	case "status":
+		fields.Status = nil
+	case "city":
+		fields.City = nil
+	case "country":
+		fields.Country = nil
+	case "category":
+		fields.Category = nil
+	case "description":
+		fields.Description = nil
+	case "latitude":
+		fields.Latitude = nil
+	case "longitude":
+		fields.Longitude = nil
+	case "contact":
+		fields.Contact = nil
+	case "opening_time":
+		fields.OpeningTime = nil
+	case "closing_time":
+		fields.ClosingTime = nil
+	case "timezone":
+		fields.Timezone = nil
+	case "opening_hours":
+		fields.OpeningHours = nil
+	case "translations":
+		fields.Translations = nil
+	case "tags":
+		fields.Tags = nil
+	case "hero_image_url":
+		fields.HeroImageURL = nil
+		fields.HeroImageUploadID = nil
+	case "gallery":
+		fields.Gallery = nil
+	}
+}
+
+// applyChangeFieldsToSnapshot mirrors the repository update semantics: nil
+// fields keep the current value and empty strings clear it.
+func applyChangeFieldsToSnapshot(base domain.DestinationSnapshot, fields domain.DestinationChangeFields) domain.DestinationSnapshot {
+	out := base
+	setString := func(dst **string, src *string) {
+		if src == nil {
+			return
+		}
+		*dst = stringPtr(*src)
+	}
+
+	if fields.Name != nil {
+		out.Name = *fields.Name
+	}
+	setString(&out.Slug, fields.Slug)
+	setString(&out.City, fields.City)
+	setString(&out.Country, fields.Country)
+	setString(&out.Category, fields.Category)
+	setString(&out.Description, fields.Description)
+	setString(&out.Contact, fields.Contact)
+	setString(&out.OpeningTime, fields.OpeningTime)
+	setString(&out.ClosingTime, fields.ClosingTime)
+	setString(&out.Timezone, fields.Timezone)
+	if fields.OpeningHours != nil {
+		out.OpeningHours = cloneOpeningHours(fields.OpeningHours)
+	}
+	if fields.Translations != nil {
+		out.Translations = cloneTranslations(*fields.Translations)
+	}
+	if fields.Tags != nil {
+		out.Tags = cloneTags(*fields.Tags)
+	}
+	setString(&out.HeroImage, fields.HeroImageURL)
+	if fields.Latitude != nil {
+		lat := *fields.Latitude
+		out.Latitude = &lat
+	}
+	if fields.Longitude != nil {
+		lng := *fields.Longitude
+		out.Longitude = &lng
+	}
+	if fields.Gallery != nil {
+		out.Gallery = cloneGallery(*fields.Gallery)
+	}
+	if fields.Status != nil {
+		out.Status = *fields.Status
+	}
+	return out
+}
+
+func diffSnapshots(before, after domain.DestinationSnapshot) domain.DestinationDiff {
+	diff := domain.DestinationDiff{
+		Fields:  make([]domain.DestinationFieldChange, 0),
+		Gallery: diffGallery(before.Gallery, after.Gallery),
+	}
+
+	addString := func(field string, oldVal, newVal *string) {
+		if stringValue(oldVal) == stringValue(newVal) {
+			return
+		}
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: field, Old: optionalValue(oldVal), New: optionalValue(newVal)})
+	}
+	addFloat := func(field string, oldVal, newVal *float64) {
+		if oldVal == nil && newVal == nil {
+			return
+		}
+		if oldVal != nil && newVal != nil && *oldVal == *newVal {
+			return
+		}
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: field, Old: optionalValue(oldVal), New: optionalValue(newVal)})
+	}
+
+	if before.Name != after.Name {
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: "name", Old: emptyAsNil(before.Name), New: emptyAsNil(after.Name)})
+	}
+	addString("slug", before.Slug, after.Slug)
+	if before.Status != after.Status {
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: "status", Old: emptyAsNil(string(before.Status)), New: emptyAsNil(string(after.Status))})
+	}
+	addString("city", before.City, after.City)
+	addString("country", before.Country, after.Country)
+	addString("category", before.Category, after.Category)
+	addString("description", before.Description, after.Description)
+	addFloat("latitude", before.Latitude, after.Latitude)
+	addFloat("longitude", before.Longitude, after.Longitude)
+	addString("contact", before.Contact, after.Contact)
+	addString("opening_time", before.OpeningTime, after.OpeningTime)
+	addString("closing_time", before.ClosingTime, after.ClosingTime)
+	addString("timezone", before.Timezone, after.Timezone)
+	if !sameOpeningHours(before.OpeningHours, after.OpeningHours) {
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: "opening_hours", Old: optionalValue(before.OpeningHours), New: optionalValue(after.OpeningHours)})
+	}
+	if !sameTranslations(before.Translations, after.Translations) {
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: "translations", Old: emptyTranslationsAsNil(before.Translations), New: emptyTranslationsAsNil(after.Translations)})
+	}
+	if !sameTags(before.Tags, after.Tags) {
+		diff.Fields = append(diff.Fields, domain.DestinationFieldChange{Field: "tags", Old: emptyTagsAsNil(before.Tags), New: emptyTagsAsNil(after.Tags)})
+	}
+	addString("hero_image_url", before.HeroImage, after.HeroImage)
+
+	return diff
+}
+
+// diffGallery matches gallery items by URL. Of the items present on both
+// sides, the largest set still in their old relative order stays put and only
+// the rest are reported as reordered, so an insert, a removal or moving one
+// image does not flag every item it shifts.
+func diffGallery(before, after domain.DestinationGallery) []domain.DestinationGalleryChange {
+	oldItems := orderedGallery(before)
+	newItems := orderedGallery(after)
+
+	oldIndex := make(map[string]int, len(oldItems))
+	for idx, item := range oldItems {
+		oldIndex[item.URL] = idx
+	}
+	newIndex := make(map[string]int, len(newItems))
+	for idx, item := range newItems {
+		newIndex[item.URL] = idx
+	}
+
+	changes := make([]domain.DestinationGalleryChange, 0)
+	for idx, item := range oldItems {
+		if _, ok := newIndex[item.URL]; ok {
+			continue
+		}
+		pos := idx
+		changes = append(changes, domain.DestinationGalleryChange{
+			Type:        domain.DestinationGalleryItemRemoved,
+			URL:         item.URL,
+			OldOrdering: &pos,
+			OldCaption:  item.Caption,
+		})
+	}
+
+	sharedOld := make([]string, 0, len(oldItems))
+	for _, item := range oldItems {
+		if _, ok := newIndex[item.URL]; ok {
+			sharedOld = append(sharedOld, item.URL)
+		}
+	}
+	sharedRank := make(map[string]int, len(sharedOld))
+	for rank, url := range sharedOld {
+		sharedRank[url] = rank
+	}
+	ranks := make([]int, 0, len(sharedOld))
+	for _, item := range newItems {
+		if rank, ok := sharedRank[item.URL]; ok {
+			ranks = append(ranks, rank)
+		}
+	}
+	kept := longestIncreasing(ranks)
+
+	shared := 0
+	for idx, item := range newItems {
+		oldPos, ok := oldIndex[item.URL]
+		if !ok {
+			pos := idx
+			changes = append(changes, domain.DestinationGalleryChange{
+				Type:        domain.DestinationGalleryItemAdded,
+				URL:         item.URL,
+				NewOrdering: &pos,
+				NewCaption:  item.Caption,
+			})
+			continue
+		}
+		oldOrdering, newOrdering := oldPos, idx
+		if !kept[shared] {
+			changes = append(changes, domain.DestinationGalleryChange{
+				Type:        domain.DestinationGalleryItemReordered,
+				URL:         item.URL,
+				OldOrdering: &oldOrdering,
+				NewOrdering: &newOrdering,
+			})
+		}
+		if stringValue(oldItems[oldPos].Caption) != stringValue(item.Caption) {
+			changes = append(changes, domain.DestinationGalleryChange{
+				Type:        domain.DestinationGalleryItemCaptionChanged,
+				URL:         item.URL,
+				OldOrdering: &oldOrdering,
+				NewOrdering: &newOrdering,
+				OldCaption:  oldItems[oldPos].Caption,
+				NewCaption:  item.Caption,
+			})
+		}
+		shared++
+	}
+	return changes
+}
+
+// longestIncreasing marks the positions of one longest strictly increasing
+// subsequence of values.
+func longestIncreasing(values []int) []bool {
+	// tails[k] is the position ending the best subsequence of length k+1 found
+	// so far; prev links each position to the one before it.
+	tails := make([]int, 0, len(values))
+	prev := make([]int, len(values))
+	for pos, value := range values {
+		k := sort.Search(len(tails), func(i int) bool { return values[tails[i]] >= value })
+		prev[pos] = -1
+		if k > 0 {
+			prev[pos] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, pos)
+		} else {
+			tails[k] = pos
+		}
+	}
+	kept := make([]bool, len(values))
+	if len(tails) > 0 {
+		for pos := tails[len(tails)-1]; pos >= 0; pos = prev[pos] {
+			kept[pos] = true
+		}
+	}
+	return kept
+}
+
+func orderedGallery(gallery domain.DestinationGallery) domain.DestinationGallery {
+	out := cloneGallery(gallery)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Ordering < out[j].Ordering
+	})
+	return out
+}
+
+// cloneOpeningHours deep-copies hours through JSON; empty hours become nil.
+func cloneOpeningHours(src *domain.OpeningHours) *domain.OpeningHours {
+	if src == nil || src.IsEmpty() {
+		return nil
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		return nil
+	}
+	var out domain.OpeningHours
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil
+	}
+	return &out
+}
+
+func sameOpeningHours(a, b *domain.OpeningHours) bool {
+	if a == nil || a.IsEmpty() {
+		return b == nil || b.IsEmpty()
+	}
+	if b == nil || b.IsEmpty() {
+		return false
+	}
+	left, errA := json.Marshal(a)
+	right, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(left, right)
+}
+
+func emptyTranslationsAsNil(val domain.DestinationTranslations) any {
+	if len(val) == 0 {
+		return nil
+	}
+	return val
+}
+
+func emptyTagsAsNil(val domain.DestinationTags) any {
+	if len(val) == 0 {
+		return nil
+	}
+	return val
+}
+
+func stringValue(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
+
+func optionalValue[T any](ptr *T) any {
+	if ptr == nil {
+		return nil
+	}
+	return *ptr
+}
+
+func emptyAsNil(val string) any {
+	if val == "" {
+		return nil
+	}
+	return val
+}
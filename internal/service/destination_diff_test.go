@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDiffGallery(t *testing.T) {
+	before := domain.DestinationGallery{
+		{URL: "a.jpg", Ordering: 0},
+		{URL: "b.jpg", Ordering: 1, Caption: strPtr("Bridge")},
+		{URL: "c.jpg", Ordering: 2},
+	}
+	after := domain.DestinationGallery{
+		{URL: "c.jpg", Ordering: 0},
+		{URL: "b.jpg", Ordering: 1, Caption: strPtr("Old bridge")},
+		{URL: "d.jpg", Ordering: 2},
+	}
+
+	changes := diffGallery(before, after)
+	byType := make(map[domain.DestinationGalleryChangeType][]domain.DestinationGalleryChange)
+	for _, change := range changes {
+		byType[change.Type] = append(byType[change.Type], change)
+	}
+
+	if removed := byType[domain.DestinationGalleryItemRemoved]; len(removed) != 1 || removed[0].URL != "a.jpg" {
+		t.Fatalf("expected a.jpg removed, got %#v", removed)
+	}
+	if added := byType[domain.DestinationGalleryItemAdded]; len(added) != 1 || added[0].URL != "d.jpg" || *added[0].NewOrdering != 2 {
+		t.Fatalf("expected d.jpg added at 2, got %#v", added)
+	}
+	if reordered := byType[domain.DestinationGalleryItemReordered]; len(reordered) != 1 || reordered[0].URL != "c.jpg" {
+		t.Fatalf("expected only c.jpg reordered, got %#v", reordered)
+	}
+	captions := byType[domain.DestinationGalleryItemCaptionChanged]
+	if len(captions) != 1 || captions[0].URL != "b.jpg" || *captions[0].NewCaption != "Old bridge" {
+		t.Fatalf("expected caption change on b.jpg, got %#v", captions)
+	}
+}
+
+func TestDiffGalleryIgnoresShiftFromRemoval(t *testing.T) {
+	before := domain.DestinationGallery{
+		{URL: "a.jpg", Ordering: 0},
+		{URL: "b.jpg", Ordering: 1},
+		{URL: "c.jpg", Ordering: 2},
+	}
+	after := domain.DestinationGallery{
+		{URL: "b.jpg", Ordering: 0},
+		{URL: "c.jpg", Ordering: 1},
+	}
+
+	changes := diffGallery(before, after)
+	if len(changes) != 1 || changes[0].Type != domain.DestinationGalleryItemRemoved {
+		t.Fatalf("expected only a removal, got %#v", changes)
+	}
+}
+
+func TestDiffGalleryFlagsOnlyTheMovedItem(t *testing.T) {
+	before := domain.DestinationGallery{
+		{URL: "a.jpg", Ordering: 0},
+		{URL: "b.jpg", Ordering: 1},
+		{URL: "c.jpg", Ordering: 2},
+		{URL: "d.jpg", Ordering: 3},
+	}
+	after := domain.DestinationGallery{
+		{URL: "d.jpg", Ordering: 0},
+		{URL: "a.jpg", Ordering: 1},
+		{URL: "b.jpg", Ordering: 2},
+		{URL: "c.jpg", Ordering: 3},
+	}
+
+	changes := diffGallery(before, after)
+	if len(changes) != 1 || changes[0].Type != domain.DestinationGalleryItemReordered || changes[0].URL != "d.jpg" {
+		t.Fatalf("expected only d.jpg reordered, got %#v", changes)
+	}
+	if *changes[0].OldOrdering != 3 || *changes[0].NewOrdering != 0 {
+		t.Fatalf("expected d.jpg moved from 3 to 0, got %d to %d", *changes[0].OldOrdering, *changes[0].NewOrdering)
+	}
+}
+
+func TestDestinationWorkflowService_DiffChangeAndVersions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 10, 12, 0, 0, 0, time.UTC)
+	admin := uuid.New()
+	reviewer := uuid.New()
+
+	svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		ApprovalRequired: true,
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	create, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{
+			Name:         strPtr("Chatuchak Market"),
+			City:         strPtr("Bangkok"),
+			HeroImageURL: strPtr("https://cdn/hero-1.jpg"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	createDiff, err := svc.DiffChange(ctx, create.ID)
+	if err != nil {
+		t.Fatalf("DiffChange create: %v", err)
+	}
+	if len(createDiff.Fields) != 4 {
+		t.Fatalf("expected name, status, city and hero image for create, got %#v", createDiff.Fields)
+	}
+	if _, err = svc.SubmitDraft(ctx, create.ID, admin); err != nil {
+		t.Fatalf("SubmitDraft: %v", err)
+	}
+	_, dest, err := svc.Approve(ctx, create.ID, reviewer, "")
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+
+	update, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &dest.ID,
+		Fields: domain.DestinationChangeFields{
+			City:         strPtr("Bangkok"),
+			Description:  strPtr("Weekend market"),
+			HeroImageURL: strPtr("https://cdn/hero-2.jpg"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft update: %v", err)
+	}
+	updateDiff, err := svc.DiffChange(ctx, update.ID)
+	if err != nil {
+		t.Fatalf("DiffChange update: %v", err)
+	}
+	fields := make(map[string]domain.DestinationFieldChange)
+	for _, field := range updateDiff.Fields {
+		fields[field.Field] = field
+	}
+	if _, ok := fields["city"]; ok {
+		t.Fatalf("unchanged city should not appear in diff")
+	}
+	if hero, ok := fields["hero_image_url"]; !ok || hero.Old != "https://cdn/hero-1.jpg" || hero.New != "https://cdn/hero-2.jpg" {
+		t.Fatalf("expected hero image change, got %#v", hero)
+	}
+	if desc, ok := fields["description"]; !ok || desc.Old != nil || desc.New != "Weekend market" {
+		t.Fatalf("expected description change, got %#v", desc)
+	}
+
+	if _, err = svc.SubmitDraft(ctx, update.ID, admin); err != nil {
+		t.Fatalf("SubmitDraft update: %v", err)
+	}
+	if _, _, err = svc.Approve(ctx, update.ID, reviewer, ""); err != nil {
+		t.Fatalf("Approve update: %v", err)
+	}
+
+	versionDiff, err := svc.DiffVersions(ctx, dest.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("DiffVersions: %v", err)
+	}
+	if len(versionDiff.Fields) != 2 {
+		t.Fatalf("expected description and hero image in version diff, got %#v", versionDiff.Fields)
+	}
+	if _, err = svc.DiffVersions(ctx, dest.ID, 1, 9); !errors.Is(err, ErrDestinationVersionNotFound) {
+		t.Fatalf("expected ErrDestinationVersionNotFound, got %v", err)
+	}
+}
+
+func TestDestinationWorkflowService_StaleBaseConflicts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 11, 9, 0, 0, 0, time.UTC)
+	admin := uuid.New()
+	reviewer := uuid.New()
+
+	svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		ApprovalRequired: true,
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	publish := func(input DestinationDraftInput) (*domain.DestinationChangeRequest, *domain.Destination, error) {
+		change, err := svc.CreateDraft(ctx, admin, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			return nil, nil, err
+		}
+		return svc.Approve(ctx, change.ID, reviewer, "")
+	}
+	draftUpdate := func(destID uuid.UUID, fields domain.DestinationChangeFields) *domain.DestinationChangeRequest {
+		change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+			Action:        domain.DestinationChangeActionUpdate,
+			DestinationID: &destID,
+			Fields:        fields,
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft update: %v", err)
+		}
+		if change.BaseVersion == nil || *change.BaseVersion != 1 {
+			t.Fatalf("expected base version 1, got %v", change.BaseVersion)
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		return change
+	}
+
+	_, dest, err := publish(DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{
+			Name:        strPtr("Lumphini Park"),
+			City:        strPtr("Bangkok"),
+			Description: strPtr("City park"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	first := draftUpdate(dest.ID, domain.DestinationChangeFields{Description: strPtr("Large city park")})
+	overlapping := draftUpdate(dest.ID, domain.DestinationChangeFields{
+		Description: strPtr("Park with a lake"),
+		Contact:     strPtr("+66 2 000 0000"),
+	})
+	disjoint := draftUpdate(dest.ID, domain.DestinationChangeFields{OpeningTime: strPtr("04:30")})
+
+	if _, _, err = svc.Approve(ctx, first.ID, reviewer, ""); err != nil {
+		t.Fatalf("Approve first: %v", err)
+	}
+
+	_, _, err = svc.Approve(ctx, overlapping.ID, reviewer, "")
+	var conflictErr *ChangeConflictError
+	if !errors.As(err, &conflictErr) || !errors.Is(err, ErrChangeConflict) {
+		t.Fatalf("expected ChangeConflictError, got %v", err)
+	}
+	if conflictErr.BaseVersion != 1 || conflictErr.CurrentVersion != 2 {
+		t.Fatalf("unexpected versions in conflict: %+v", conflictErr)
+	}
+	if len(conflictErr.Conflicts) != 1 || conflictErr.Conflicts[0].Field != "description" {
+		t.Fatalf("expected description conflict, got %#v", conflictErr.Conflicts)
+	}
+	if got := conflictErr.Conflicts[0]; got.Base != "City park" || got.Current != "Large city park" || got.Draft != "Park with a lake" {
+		t.Fatalf("unexpected conflict values: %#v", got)
+	}
+
+	if _, applied, err := svc.Approve(ctx, disjoint.ID, reviewer, ""); err != nil {
+		t.Fatalf("Approve disjoint: %v", err)
+	} else if applied.Description == nil || *applied.Description != "Large city park" {
+		t.Fatalf("disjoint approval should keep upstream description, got %v", applied.Description)
+	}
+
+	if _, _, err = svc.RebaseDraft(ctx, overlapping.ID, reviewer); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden rebasing another author's draft, got %v", err)
+	}
+	rebased, conflicts, err := svc.RebaseDraft(ctx, overlapping.ID, admin)
+	if err != nil {
+		t.Fatalf("RebaseDraft: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Field != "description" {
+		t.Fatalf("expected description dropped on rebase, got %#v", conflicts)
+	}
+	if rebased.Payload.Description != nil || rebased.Payload.Contact == nil {
+		t.Fatalf("rebase should drop description and keep contact, got %#v", rebased.Payload)
+	}
+	if rebased.BaseVersion == nil || *rebased.BaseVersion != 3 {
+		t.Fatalf("expected base version 3 after rebase, got %v", rebased.BaseVersion)
+	}
+
+	_, applied, err := svc.Approve(ctx, overlapping.ID, reviewer, "")
+	if err != nil {
+		t.Fatalf("Approve rebased: %v", err)
+	}
+	if applied.Contact == nil || *applied.Contact != "+66 2 000 0000" || *applied.Description != "Large city park" {
+		t.Fatalf("unexpected destination after rebased approval: %#v", applied)
+	}
+}
+
+type failingVersionRepo struct {
+	*memoryVersionRepo
+	err error
+}
+
+func (r failingVersionRepo) FindByVersion(context.Context, uuid.UUID, int64) (*domain.DestinationVersion, error) {
+	return nil, r.err
+}
+
+func TestDestinationWorkflowService_DiffVersionsKeepsLookupErrors(t *testing.T) {
+	lookupErr := errors.New("connection reset")
+	svc := &DestinationWorkflowService{versions: failingVersionRepo{memoryVersionRepo: newMemoryVersionRepo(), err: lookupErr}}
+
+	_, err := svc.DiffVersions(context.Background(), uuid.New(), 1, 2)
+	if !errors.Is(err, lookupErr) || errors.Is(err, ErrDestinationVersionNotFound) {
+		t.Fatalf("expected the lookup error, got %v", err)
+	}
+}
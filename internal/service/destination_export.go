@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+const (
+	destinationExportBatchSize = 500
+	// destinationExportMinGallery keeps the template's three gallery slots in
+	// every export, even when no destination fills them.
+	destinationExportMinGallery = 3
+)
+
+// DestinationCSVHeader is the column layout the bulk importer reads: fixed
+// columns, name/description translations per locale, then gallery slots with
+// their translated captions. The id column is informational on import.
+func DestinationCSVHeader(locales []string, gallerySlots int) []string {
+	header := []string{
+		"id", "slug", "name", "status", "category", "city", "country", "description",
+		"latitude", "longitude", "contact", "opening_time", "closing_time",
+		"timezone", "opening_hours", "tags",
+	}
+	for _, locale := range locales {
+		header = append(header, "name_"+locale, "description_"+locale)
+	}
+	header = append(header, "hero_image_url")
+	for slot := 1; slot <= gallerySlots; slot++ {
+		prefix := "gallery_" + strconv.Itoa(slot)
+		header = append(header, prefix+"_url", prefix+"_caption")
+		for _, locale := range locales {
+			header = append(header, prefix+"_caption_"+locale)
+		}
+	}
+	return header
+}
+
+// ExportCSV writes every destination matching filter, whatever its status, as
+// an import-compatible CSV. Rows are loaded a batch at a time and flushed as
+// they are written, so large catalogues stream.
+func (s *DestinationService) ExportCSV(ctx context.Context, w io.Writer, filter domain.DestinationExportFilter) error {
+	if len(filter.Categories) > 0 && s.categories != nil {
+		managed, err := s.categories.List(ctx)
+		if err != nil {
+			return err
+		}
+		filter.Categories = expandCategories(managed, filter.Categories)
+	}
+	filter.AfterID = nil
+
+	layout, err := s.destinations.ExportLayout(ctx, filter)
+	if err != nil {
+		return err
+	}
+	locales := s.exportLocales(layout.Locales)
+	header := DestinationCSVHeader(locales, max(layout.GallerySlots, destinationExportMinGallery))
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for {
+		batch, err := s.destinations.ListForExport(ctx, filter, destinationExportBatchSize)
+		if err != nil {
+			return err
+		}
+		for idx := range batch {
+			values := destinationCSVValues(&batch[idx])
+			record := make([]string, len(header))
+			for col, key := range header {
+				record[col] = values[key]
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		if len(batch) < destinationExportBatchSize {
+			return nil
+		}
+		last := batch[len(batch)-1].ID
+		filter.AfterID = &last
+	}
+}
+
+// exportLocales is every configured translation locale plus any stored ones,
+// sorted, so exports from the same deployment share a header.
+func (s *DestinationService) exportLocales(stored []string) []string {
+	locales := make([]string, 0, len(s.locales.supported)+len(stored))
+	for locale := range s.locales.supported {
+		if locale != s.locales.defaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	for _, locale := range stored {
+		if locale = normalizeLocale(locale); locale != "" && !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// destinationCSVValues maps a destination to import column values. Columns
+// missing from the map are written empty.
+func destinationCSVValues(dest *domain.Destination) map[string]string {
+	values := map[string]string{
+		"id":     dest.ID.String(),
+		"name":   dest.Name,
+		"status": string(dest.Status),
+		"tags":   strings.Join(dest.Tags, "|"),
+	}
+	text := func(key string, value *string) {
+		if value != nil {
+			values[key] = *value
+		}
+	}
+	number := func(key string, value *float64) {
+		if value != nil {
+			values[key] = strconv.FormatFloat(*value, 'f', -1, 64)
+		}
+	}
+	text("slug", dest.Slug)
+	text("category", dest.Category)
+	text("city", dest.City)
+	text("country", dest.Country)
+	text("description", dest.Description)
+	number("latitude", dest.Latitude)
+	number("longitude", dest.Longitude)
+	text("contact", dest.Contact)
+	text("opening_time", dest.OpeningTime)
+	text("closing_time", dest.ClosingTime)
+	text("timezone", dest.Timezone)
+	text("hero_image_url", dest.HeroImage)
+	if dest.OpeningHours != nil && !dest.OpeningHours.IsEmpty() {
+		values["opening_hours"] = formatOpeningHoursText(*dest.OpeningHours)
+	}
+	for locale, translation := range dest.Translations {
+		text("name_"+locale, translation.Name)
+		text("description_"+locale, translation.Description)
+	}
+	for idx, item := range dest.Gallery {
+		prefix := "gallery_" + strconv.Itoa(idx+1)
+		values[prefix+"_url"] = item.URL
+		text(prefix+"_caption", item.Caption)
+		for locale, translation := range dest.Translations {
+			if caption, ok := translation.Captions[item.URL]; ok {
+				values[prefix+"_caption_"+locale] = caption
+			}
+		}
+	}
+	return values
+}
+
+// formatOpeningHoursText writes opening hours in the compact form
+// parseOpeningHoursText reads, joining consecutive weekdays with the same
+// periods into ranges. Hours the compact form cannot carry, such as
+// exception notes, are written as JSON instead.
+func formatOpeningHoursText(hours domain.OpeningHours) string {
+	if !openingHoursFitText(hours) {
+		data, _ := json.Marshal(hours)
+		return string(data)
+	}
+
+	// Weekdays in Monday-first order, as people write them.
+	week := append(slices.Clone(domain.OpeningWeekdays[1:]), domain.OpeningWeekdays[0])
+	specs := make(map[string]string, len(week))
+	for _, period := range hours.Weekly {
+		span := period.Open + "-" + period.Close
+		if specs[period.Day] != "" {
+			span = specs[period.Day] + "," + span
+		}
+		specs[period.Day] = span
+	}
+
+	var entries []string
+	for start := 0; start < len(week); {
+		end := start
+		for end+1 < len(week) && specs[week[end+1]] == specs[week[start]] {
+			end++
+		}
+		if spec := specs[week[start]]; spec != "" {
+			days := week[start]
+			if end > start {
+				days += "-" + week[end]
+			}
+			entries = append(entries, days+" "+spec)
+		}
+		start = end + 1
+	}
+	for _, exception := range hours.Exceptions {
+		spec := "closed"
+		if !exception.Closed {
+			spans := make([]string, 0, len(exception.Periods))
+			for _, period := range exception.Periods {
+				spans = append(spans, period.Open+"-"+period.Close)
+			}
+			spec = strings.Join(spans, ",")
+		}
+		entries = append(entries, exception.Date+" "+spec)
+	}
+	return strings.Join(entries, "; ")
+}
+
+func openingHoursFitText(hours domain.OpeningHours) bool {
+	for _, period := range hours.Weekly {
+		if !slices.Contains(domain.OpeningWeekdays[:], period.Day) {
+			return false
+		}
+	}
+	for _, exception := range hours.Exceptions {
+		if exception.Note != nil || exception.Closed == (len(exception.Periods) > 0) {
+			return false
+		}
+		if _, err := time.Parse("2006-01-02", exception.Date); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationService_ExportCSVRoundTripsThroughImport(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC))
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{SupportedLocales: []string{"en", "th"}})
+	admin := uuid.New()
+
+	gallery := domain.DestinationGallery{
+		{URL: "https://cdn.example/jj-1.jpg", Caption: strPtr("Clock tower"), Ordering: 0},
+		{URL: "https://cdn.example/jj-2.jpg", Ordering: 1},
+		{URL: "https://cdn.example/jj-3.jpg", Ordering: 2},
+		{URL: "https://cdn.example/jj-4.jpg", Caption: strPtr("Food, drinks \"and\" more"), Ordering: 3},
+	}
+	translations := domain.DestinationTranslations{
+		"th": {
+			Name:     strPtr("ตลาดนัดจตุจักร"),
+			Captions: map[string]string{"https://cdn.example/jj-4.jpg": "อาหาร"},
+		},
+	}
+	tags := domain.DestinationTags{"market", "night-life"}
+	lat, lng := 13.7999, 100.5500
+	fields := domain.DestinationChangeFields{
+		Name:         strPtr("Chatuchak Market"),
+		Slug:         strPtr("chatuchak-market"),
+		Category:     strPtr("Shopping"),
+		City:         strPtr("Bangkok"),
+		Country:      strPtr("Thailand"),
+		Description:  strPtr("Weekend market,\nover 15,000 stalls."),
+		Contact:      strPtr("+66 2 272 4440"),
+		Timezone:     strPtr("Asia/Bangkok"),
+		Latitude:     &lat,
+		Longitude:    &lng,
+		Tags:         &tags,
+		Gallery:      &gallery,
+		Translations: &translations,
+		OpeningHours: &domain.OpeningHours{
+			Weekly: []domain.OpeningPeriod{
+				{Day: "mon", Open: "09:00", Close: "17:00"},
+				{Day: "tue", Open: "09:00", Close: "17:00"},
+				{Day: "sat", Open: "09:00", Close: "12:00"},
+				{Day: "sat", Open: "13:00", Close: "18:00"},
+			},
+			Exceptions: []domain.OpeningException{{Date: "2024-12-25", Closed: true}},
+		},
+		HeroImageURL: strPtr("https://cdn.example/jj-hero.jpg"),
+	}
+	dest := destRepo.mustCreate(ctx, fields, admin, domain.DestinationStatusArchived, fields.HeroImageURL)
+	destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Lumphini Park"), Category: strPtr("Park")}, admin, domain.DestinationStatusPublished, nil)
+
+	var buf bytes.Buffer
+	filter := domain.DestinationExportFilter{
+		Statuses:   []domain.DestinationStatus{domain.DestinationStatusArchived},
+		Categories: []string{"shopping"},
+	}
+	if err := svc.ExportCSV(ctx, &buf, filter); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	header, records, err := parseCSV(buf.Bytes())
+	if err != nil {
+		t.Fatalf("parse export: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 exported row, got %d", len(records))
+	}
+	if !strings.Contains(strings.Join(header, ","), "gallery_4_url,gallery_4_caption,gallery_4_caption_th") {
+		t.Fatalf("expected a fourth gallery slot, got header %v", header)
+	}
+	values := rowToMap(header, records[0])
+	if values["id"] != dest.ID.String() {
+		t.Fatalf("expected id %s, got %q", dest.ID, values["id"])
+	}
+	if values["opening_hours"] != "mon-tue 09:00-17:00; sat 09:00-12:00,13:00-18:00; 2024-12-25 closed" {
+		t.Fatalf("unexpected opening_hours %q", values["opening_hours"])
+	}
+
+	got, errs := buildChangeFields(values)
+	if len(errs) > 0 {
+		t.Fatalf("reimport errors: %v", errs)
+	}
+	want := fields
+	want.Status = statusPtr(domain.DestinationStatusArchived)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip mismatch\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestFormatOpeningHoursTextFallsBackToJSON(t *testing.T) {
+	hours := domain.OpeningHours{
+		Exceptions: []domain.OpeningException{{Date: "2024-04-13", Closed: true, Note: strPtr("Songkran")}},
+	}
+	text := formatOpeningHoursText(hours)
+	if !strings.HasPrefix(text, "{") {
+		t.Fatalf("expected JSON for hours with notes, got %q", text)
+	}
+	parsed, err := parseOpeningHoursText(text)
+	if err != nil || !reflect.DeepEqual(parsed, hours) {
+		t.Fatalf("expected hours to round trip, got %+v (%v)", parsed, err)
+	}
+}
+
+func TestDestinationService_ExportCSVIncludesArchivedOnlyWhenAsked(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC))
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{SupportedLocales: []string{"en"}})
+	admin := uuid.New()
+
+	archived := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Old Pier")}, admin, domain.DestinationStatusPublished, nil)
+	if _, err := destRepo.Archive(ctx, archived.ID, admin); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	published := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Lumphini Park")}, admin, domain.DestinationStatusPublished, nil)
+
+	exportedIDs := func(filter domain.DestinationExportFilter) []string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := svc.ExportCSV(ctx, &buf, filter); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+		header, records, err := parseCSV(buf.Bytes())
+		if err != nil {
+			t.Fatalf("parse export: %v", err)
+		}
+		ids := make([]string, 0, len(records))
+		for _, record := range records {
+			ids = append(ids, rowToMap(header, record)["id"])
+		}
+		return ids
+	}
+
+	if got := exportedIDs(domain.DestinationExportFilter{}); !reflect.DeepEqual(got, []string{published.ID.String()}) {
+		t.Fatalf("expected only the live destination without filters, got %v", got)
+	}
+	filter := domain.DestinationExportFilter{Statuses: []domain.DestinationStatus{domain.DestinationStatusArchived}}
+	if got := exportedIDs(filter); !reflect.DeepEqual(got, []string{archived.ID.String()}) {
+		t.Fatalf("expected the archived destination for status=archived, got %v", got)
+	}
+}
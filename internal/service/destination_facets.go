@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// facetValueLimit caps how many values a category, country or city facet
+// returns.
+const facetValueLimit = 50
+
+// Facets counts the destinations matching filter for each requested facet.
+// Each facet is counted with its own selection removed, so the counts show
+// what picking another value would return rather than only the current one.
+func (s *DestinationService) Facets(ctx context.Context, filter domain.DestinationListFilter, facets []domain.DestinationFacet) (map[domain.DestinationFacet][]domain.DestinationFacetCount, error) {
+	result := make(map[domain.DestinationFacet][]domain.DestinationFacetCount, len(facets))
+	for _, facet := range facets {
+		if _, done := result[facet]; done {
+			continue
+		}
+		facetFilter := filter
+		switch facet {
+		case domain.DestinationFacetCategory:
+			facetFilter.Categories = nil
+		case domain.DestinationFacetCountry:
+			facetFilter.Country = nil
+		case domain.DestinationFacetCity:
+			facetFilter.City = nil
+		case domain.DestinationFacetRating:
+			facetFilter.MinRating = nil
+			facetFilter.MaxRating = nil
+		default:
+			continue
+		}
+		facetFilter, err := s.prepareFilter(ctx, facetFilter)
+		if err != nil {
+			return nil, err
+		}
+		facetFilter.After = nil
+		counts, err := s.destinations.CountPublishedFacet(ctx, facetFilter, facet, facetValueLimit)
+		if err != nil {
+			return nil, err
+		}
+		result[facet] = counts
+	}
+	return result, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationService_FacetsExcludeOwnSelection(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 21, 9, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	create := func(name, category, city, country string, rating float64) {
+		dest := destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+			Name:     strPtr(name),
+			Category: strPtr(category),
+			City:     strPtr(city),
+			Country:  strPtr(country),
+		}, admin, domain.DestinationStatusPublished, nil)
+		destRepo.store[dest.ID].AverageRating = rating
+	}
+	create("Grand Palace", "Temple", "Bangkok", "Thailand", 4.7)
+	create("Wat Arun", "Temple", "Bangkok", "Thailand", 3.8)
+	create("Chatuchak Market", "Market", "Bangkok", "Thailand", 3.4)
+	create("Doi Suthep", "Temple", "Chiang Mai", "Thailand", 4.9)
+	create("Angkor Wat", "Temple", "Siem Reap", "Cambodia", 4.8)
+
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{})
+	minRating := 4.0
+	filter := domain.DestinationListFilter{
+		Categories: []string{"Temple"},
+		Country:    strPtr("Thailand"),
+		MinRating:  &minRating,
+	}
+	facets, err := svc.Facets(ctx, filter, []domain.DestinationFacet{
+		domain.DestinationFacetCategory,
+		domain.DestinationFacetCountry,
+		domain.DestinationFacetCity,
+		domain.DestinationFacetRating,
+	})
+	if err != nil {
+		t.Fatalf("Facets: %v", err)
+	}
+
+	// Categories ignore the category selection: Thai places rated 4+.
+	if got := facets[domain.DestinationFacetCategory]; len(got) != 1 || got[0] != (domain.DestinationFacetCount{Value: "Temple", Count: 2}) {
+		t.Fatalf("unexpected category facet %+v", got)
+	}
+	// Countries ignore the country selection: temples rated 4+ anywhere.
+	if got := facets[domain.DestinationFacetCountry]; len(got) != 2 || got[0] != (domain.DestinationFacetCount{Value: "Thailand", Count: 2}) || got[1] != (domain.DestinationFacetCount{Value: "Cambodia", Count: 1}) {
+		t.Fatalf("unexpected country facet %+v", got)
+	}
+	// Cities keep every selection.
+	if got := facets[domain.DestinationFacetCity]; len(got) != 2 || got[0] != (domain.DestinationFacetCount{Value: "Bangkok", Count: 1}) || got[1].Value != "Chiang Mai" {
+		t.Fatalf("unexpected city facet %+v", got)
+	}
+	// Rating bands ignore min_rating: Thai temples at each band.
+	want := []domain.DestinationFacetCount{{Value: "4", Count: 2}, {Value: "3", Count: 3}, {Value: "2", Count: 3}, {Value: "1", Count: 3}}
+	got := facets[domain.DestinationFacetRating]
+	if len(got) != len(want) {
+		t.Fatalf("unexpected rating facet %+v", got)
+	}
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Fatalf("unexpected rating facet %+v", got)
+		}
+	}
+}
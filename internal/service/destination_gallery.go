@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+var ErrGalleryItemNotFound = errors.New("gallery item not found")
+
+// ReorderGallery puts the change's gallery in the order of urls, which must
+// list every gallery item exactly once.
+func (s *DestinationWorkflowService) ReorderGallery(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, urls []string) (*domain.DestinationChangeRequest, error) {
+	return s.editGallery(ctx, changeID, authorID, func(change *domain.DestinationChangeRequest, gallery domain.DestinationGallery) (domain.DestinationGallery, error) {
+		if len(urls) != len(gallery) {
+			return nil, fmt.Errorf("%w: order must list all %d gallery items", ErrDestinationChangeValidation, len(gallery))
+		}
+		byURL := make(map[string]domain.DestinationMedia, len(gallery))
+		for _, item := range gallery {
+			byURL[item.URL] = item
+		}
+		ordered := make(domain.DestinationGallery, 0, len(urls))
+		for _, url := range urls {
+			url = strings.TrimSpace(url)
+			item, ok := byURL[url]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s is missing or listed twice", ErrDestinationChangeValidation, url)
+			}
+			delete(byURL, url)
+			ordered = append(ordered, item)
+		}
+		return ordered, nil
+	})
+}
+
+// CaptionGalleryItem sets or, with an empty caption, clears the caption of the
+// gallery item at url.
+func (s *DestinationWorkflowService) CaptionGalleryItem(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, url string, caption string) (*domain.DestinationChangeRequest, error) {
+	return s.editGallery(ctx, changeID, authorID, func(change *domain.DestinationChangeRequest, gallery domain.DestinationGallery) (domain.DestinationGallery, error) {
+		idx := galleryIndex(gallery, url)
+		if idx < 0 {
+			return nil, ErrGalleryItemNotFound
+		}
+		gallery[idx].Caption = nil
+		if trimmed := strings.TrimSpace(caption); trimmed != "" {
+			gallery[idx].Caption = &trimmed
+		}
+		return gallery, nil
+	})
+}
+
+// RemoveGalleryItem drops the item at url from the gallery. Its object is
+// deleted from storage once the change is approved.
+func (s *DestinationWorkflowService) RemoveGalleryItem(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, url string) (*domain.DestinationChangeRequest, error) {
+	return s.editGallery(ctx, changeID, authorID, func(change *domain.DestinationChangeRequest, gallery domain.DestinationGallery) (domain.DestinationGallery, error) {
+		idx := galleryIndex(gallery, url)
+		if idx < 0 {
+			return nil, ErrGalleryItemNotFound
+		}
+		removed := gallery[idx].URL
+		if !slices.Contains(change.Payload.RemovedMedia, removed) {
+			change.Payload.RemovedMedia = append(change.Payload.RemovedMedia, removed)
+		}
+		return append(gallery[:idx], gallery[idx+1:]...), nil
+	})
+}
+
+// SetGalleryHero makes the gallery image at url the change's hero image.
+func (s *DestinationWorkflowService) SetGalleryHero(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, url string) (*domain.DestinationChangeRequest, error) {
+	return s.editGallery(ctx, changeID, authorID, func(change *domain.DestinationChangeRequest, gallery domain.DestinationGallery) (domain.DestinationGallery, error) {
+		idx := galleryIndex(gallery, url)
+		if idx < 0 {
+			return nil, ErrGalleryItemNotFound
+		}
+		change.Payload.HeroImageURL = stringPtr(gallery[idx].URL)
+		change.Payload.HeroImageUploadID = nil
+		return gallery, nil
+	})
+}
+
+// editGallery loads an editable create or update change, applies edit to its
+// gallery and saves it with orderings renumbered from zero. Update changes
+// that have not touched the gallery yet start from the published one.
+func (s *DestinationWorkflowService) editGallery(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID, edit func(change *domain.DestinationChangeRequest, gallery domain.DestinationGallery) (domain.DestinationGallery, error)) (*domain.DestinationChangeRequest, error) {
+	change, err := s.changes.FindByID(ctx, changeID)
+	if err != nil {
+		return nil, ErrDestinationChangeNotFound
+	}
+	if change.SubmittedBy != authorID {
+		return nil, ErrForbidden
+	}
+	if change.Status != domain.DestinationChangeStatusDraft && change.Status != domain.DestinationChangeStatusRejected {
+		return nil, ErrChangeNotEditable
+	}
+	if change.Action != domain.DestinationChangeActionCreate && change.Action != domain.DestinationChangeActionUpdate {
+		return nil, fmt.Errorf("%w: gallery edits apply to create and update changes only", ErrDestinationChangeValidation)
+	}
+
+	var gallery domain.DestinationGallery
+	switch {
+	case change.Payload.Gallery != nil:
+		gallery = cloneGallery(*change.Payload.Gallery)
+	case change.DestinationID != nil:
+		dest, err := s.destinations.FindByID(ctx, *change.DestinationID)
+		if err != nil {
+			return nil, ErrDestinationNotFound
+		}
+		gallery = cloneGallery(dest.Gallery)
+	}
+	sortGallery(gallery)
+
+	gallery, err = edit(change, gallery)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range gallery {
+		gallery[idx].Ordering = idx
+	}
+	if gallery == nil {
+		gallery = domain.DestinationGallery{}
+	}
+	change.Payload.Gallery = &gallery
+	change.UpdatedAt = s.now()
+	change.DraftVersion++
+	return s.changes.Update(ctx, change)
+}
+
+// deleteRemovedMedia deletes the objects behind gallery URLs an approved
+// change removed, as long as the destination does not still use them. Only
+// the change's own uploads are deleted: no stored version can reference them,
+// while published media stays in the version snapshots that rollbacks restore.
+// Failures are logged, as the change is already applied.
+func (s *DestinationWorkflowService) deleteRemovedMedia(ctx context.Context, changeID uuid.UUID, removed []string, current *domain.Destination) {
+	if len(removed) == 0 {
+		return
+	}
+	stagedPrefix := fmt.Sprintf("destinations/changes/%s/", changeID)
+	for _, url := range removed {
+		if !strings.Contains(url, stagedPrefix) || destinationUsesMedia(current, url) {
+			continue
+		}
+		key := s.objectKey(url)
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, s.bucket, key); err != nil {
+			log.Printf("destination change %s: delete removed object %s: %v", changeID, key, err)
+		}
+	}
+}
+
+// objectKey maps a media URL back to its storage key. URLs outside the public
+// base and the destination upload prefix are not ours and yield "".
+func (s *DestinationWorkflowService) objectKey(url string) string {
+	if s.publicBase != "" && strings.HasPrefix(url, s.publicBase+"/") {
+		return strings.TrimPrefix(url, s.publicBase+"/")
+	}
+	if idx := strings.Index(url, "destinations/changes/"); idx >= 0 {
+		return url[idx:]
+	}
+	return ""
+}
+
+func destinationUsesMedia(dest *domain.Destination, url string) bool {
+	if dest == nil {
+		return false
+	}
+	if dest.HeroImage != nil && *dest.HeroImage == url {
+		return true
+	}
+	return galleryIndex(dest.Gallery, url) >= 0
+}
+
+func galleryIndex(gallery domain.DestinationGallery, url string) int {
+	url = strings.TrimSpace(url)
+	for idx, item := range gallery {
+		if item.URL == url {
+			return idx
+		}
+	}
+	return -1
+}
+
+func sortGallery(gallery domain.DestinationGallery) {
+	sort.SliceStable(gallery, func(i, j int) bool {
+		return gallery[i].Ordering < gallery[j].Ordering
+	})
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationWorkflowService_GalleryEdits(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 18, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	storage := &memoryStorage{}
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), storage, DestinationWorkflowConfig{})
+	svc.SetClock(func() time.Time { return now })
+	admin := uuid.New()
+
+	upload := func(changeID uuid.UUID, names ...string) []GalleryUploadResult {
+		t.Helper()
+		uploads := make([]GalleryImageUpload, 0, len(names))
+		for _, name := range names {
+			uploads = append(uploads, GalleryImageUpload{
+				Reader:      bytes.NewReader([]byte(name)),
+				Size:        int64(len(name)),
+				FileName:    name + ".jpg",
+				ContentType: "image/jpeg",
+			})
+		}
+		_, results, err := svc.UploadGalleryImages(ctx, changeID, admin, uploads)
+		if err != nil {
+			t.Fatalf("UploadGalleryImages: %v", err)
+		}
+		return results
+	}
+	approve := func(changeID uuid.UUID) *domain.Destination {
+		t.Helper()
+		if _, err := svc.SubmitDraft(ctx, changeID, admin); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		_, dest, err := svc.Approve(ctx, changeID, uuid.New(), "")
+		if err != nil {
+			t.Fatalf("Approve: %v", err)
+		}
+		return dest
+	}
+	stored := func(key string) bool {
+		_, ok := storage.objects.Load(key)
+		return ok
+	}
+
+	create, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Lumphini Park")},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	published := upload(create.ID, "lake", "pavilion", "gate")
+	lake, pavilion, gate := published[0], published[1], published[2]
+	dest := approve(create.ID)
+
+	update, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &dest.ID,
+		Fields:        domain.DestinationChangeFields{},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft update: %v", err)
+	}
+
+	if _, err = svc.ReorderGallery(ctx, update.ID, admin, []string{gate.URL, lake.URL, lake.URL}); !errors.Is(err, ErrDestinationChangeValidation) {
+		t.Fatalf("expected duplicate url to be rejected, got %v", err)
+	}
+	change, err := svc.ReorderGallery(ctx, update.ID, admin, []string{gate.URL, lake.URL, pavilion.URL})
+	if err != nil {
+		t.Fatalf("ReorderGallery: %v", err)
+	}
+	if got := galleryURLs(*change.Payload.Gallery); len(got) != 3 || got[0] != gate.URL || got[2] != pavilion.URL {
+		t.Fatalf("expected published gallery reordered, got %v", got)
+	}
+
+	if change, err = svc.CaptionGalleryItem(ctx, update.ID, admin, lake.URL, "  The lake "); err != nil {
+		t.Fatalf("CaptionGalleryItem: %v", err)
+	}
+	if caption := (*change.Payload.Gallery)[1].Caption; caption == nil || *caption != "The lake" {
+		t.Fatalf("expected trimmed caption, got %v", caption)
+	}
+	if _, err = svc.SetGalleryHero(ctx, update.ID, admin, "https://cdn.local/unknown.jpg"); !errors.Is(err, ErrGalleryItemNotFound) {
+		t.Fatalf("expected unknown item, got %v", err)
+	}
+	if _, err = svc.SetGalleryHero(ctx, update.ID, admin, gate.URL); err != nil {
+		t.Fatalf("SetGalleryHero: %v", err)
+	}
+	if _, err = svc.CaptionGalleryItem(ctx, update.ID, uuid.New(), lake.URL, "x"); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected other authors to be forbidden, got %v", err)
+	}
+
+	staged := upload(update.ID, "kiosk")[0]
+	if _, err = svc.RemoveGalleryItem(ctx, update.ID, admin, staged.URL); err != nil {
+		t.Fatalf("RemoveGalleryItem staged: %v", err)
+	}
+	if change, err = svc.RemoveGalleryItem(ctx, update.ID, admin, pavilion.URL); err != nil {
+		t.Fatalf("RemoveGalleryItem: %v", err)
+	}
+	if len(change.Payload.RemovedMedia) != 2 {
+		t.Fatalf("expected two removals recorded, got %v", change.Payload.RemovedMedia)
+	}
+	if !stored(pavilion.UploadID) {
+		t.Fatalf("expected removed object kept until approval")
+	}
+
+	dest = approve(update.ID)
+	if got := galleryURLs(dest.Gallery); len(got) != 2 || got[0] != gate.URL || got[1] != lake.URL {
+		t.Fatalf("expected gate then lake, got %v", got)
+	}
+	for idx, item := range dest.Gallery {
+		if item.Ordering != idx {
+			t.Fatalf("expected contiguous ordering, got %+v", dest.Gallery)
+		}
+	}
+	if dest.HeroImage == nil || *dest.HeroImage != gate.URL {
+		t.Fatalf("expected gate as hero, got %v", dest.HeroImage)
+	}
+	if stored(staged.UploadID) {
+		t.Fatalf("expected the never-published staged object deleted after approval")
+	}
+	if !stored(pavilion.UploadID) {
+		t.Fatalf("expected published media to stay in storage for rollbacks")
+	}
+	if !stored(gate.UploadID) || !stored(lake.UploadID) {
+		t.Fatalf("expected kept objects to stay in storage")
+	}
+
+	_, rollback, err := svc.Rollback(ctx, dest.ID, 1, admin)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if got := galleryURLs(rollback.Gallery); len(got) != 3 || got[1] != pavilion.URL {
+		t.Fatalf("expected rollback to bring the pavilion back, got %v", got)
+	}
+}
+
+func galleryURLs(gallery domain.DestinationGallery) []string {
+	out := make([]string, 0, len(gallery))
+	for _, item := range gallery {
+		out = append(out, item.URL)
+	}
+	return out
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+const maxOpeningPeriodsPerDay = 4
+
+// validateTimezone accepts IANA names such as "Asia/Bangkok" and "UTC".
+func validateTimezone(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if raw == "Local" {
+		return fmt.Errorf("timezone must be an IANA name")
+	}
+	if _, err := time.LoadLocation(raw); err != nil {
+		return fmt.Errorf("timezone must be an IANA name")
+	}
+	return nil
+}
+
+func validateOpeningHours(hours domain.OpeningHours) []string {
+	var problems []string
+
+	perDay := make(map[string]int, len(domain.OpeningWeekdays))
+	for idx, period := range hours.Weekly {
+		day := strings.ToLower(strings.TrimSpace(period.Day))
+		if !isOpeningWeekday(day) {
+			problems = append(problems, fmt.Sprintf("opening_hours.weekly[%d] day must be one of mon, tue, wed, thu, fri, sat, sun", idx))
+			continue
+		}
+		perDay[day]++
+		problems = append(problems, validateOpeningPeriod(fmt.Sprintf("opening_hours.weekly[%d]", idx), period)...)
+	}
+	for day, count := range perDay {
+		if count > maxOpeningPeriodsPerDay {
+			problems = append(problems, fmt.Sprintf("opening_hours.weekly allows at most %d periods for %s", maxOpeningPeriodsPerDay, day))
+		}
+	}
+
+	seen := make(map[string]struct{}, len(hours.Exceptions))
+	for idx, exception := range hours.Exceptions {
+		label := fmt.Sprintf("opening_hours.exceptions[%d]", idx)
+		if _, err := time.Parse("2006-01-02", exception.Date); err != nil {
+			problems = append(problems, label+" date must be in YYYY-MM-DD format")
+		} else if _, dup := seen[exception.Date]; dup {
+			problems = append(problems, fmt.Sprintf("%s duplicates date %s", label, exception.Date))
+		} else {
+			seen[exception.Date] = struct{}{}
+		}
+		switch {
+		case exception.Closed && len(exception.Periods) > 0:
+			problems = append(problems, label+" cannot be closed and have periods")
+		case !exception.Closed && len(exception.Periods) == 0:
+			problems = append(problems, label+" needs periods or closed")
+		case len(exception.Periods) > maxOpeningPeriodsPerDay:
+			problems = append(problems, fmt.Sprintf("%s allows at most %d periods", label, maxOpeningPeriodsPerDay))
+		}
+		for pIdx, period := range exception.Periods {
+			problems = append(problems, validateOpeningPeriod(fmt.Sprintf("%s.periods[%d]", label, pIdx), period)...)
+		}
+	}
+	return problems
+}
+
+// normalizeOpeningHoursPtr lower-cases weekday keys and zero-pads times to
+// HH:MM, the form the database compares. Times that do not parse are kept for
+// validation to report.
+func normalizeOpeningHoursPtr(hours *domain.OpeningHours) *domain.OpeningHours {
+	if hours == nil {
+		return nil
+	}
+	normalized := domain.OpeningHours{
+		Weekly:     normalizeOpeningPeriods(hours.Weekly),
+		Exceptions: make([]domain.OpeningException, len(hours.Exceptions)),
+	}
+	if hours.Exceptions == nil {
+		normalized.Exceptions = nil
+	}
+	for idx, exception := range hours.Exceptions {
+		exception.Periods = normalizeOpeningPeriods(exception.Periods)
+		normalized.Exceptions[idx] = exception
+	}
+	return &normalized
+}
+
+func normalizeOpeningPeriods(periods []domain.OpeningPeriod) []domain.OpeningPeriod {
+	if periods == nil {
+		return nil
+	}
+	out := make([]domain.OpeningPeriod, len(periods))
+	for idx, period := range periods {
+		out[idx] = domain.OpeningPeriod{
+			Day:   strings.ToLower(strings.TrimSpace(period.Day)),
+			Open:  normalizeClock(period.Open, false),
+			Close: normalizeClock(period.Close, true),
+		}
+	}
+	return out
+}
+
+func normalizeClock(raw string, allowEndOfDay bool) string {
+	raw = strings.TrimSpace(raw)
+	minutes, ok := domain.ParseClockMinutes(raw, allowEndOfDay)
+	if !ok {
+		return raw
+	}
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func validateOpeningPeriod(label string, period domain.OpeningPeriod) []string {
+	var problems []string
+	open, okOpen := domain.ParseClockMinutes(period.Open, false)
+	if !okOpen {
+		problems = append(problems, label+" open must be in HH:MM (24h) format")
+	}
+	close, okClose := domain.ParseClockMinutes(period.Close, true)
+	if !okClose {
+		problems = append(problems, label+" close must be in HH:MM (24h) format")
+	}
+	if okOpen && okClose && open == close {
+		problems = append(problems, label+" open and close cannot be equal")
+	}
+	return problems
+}
+
+func isOpeningWeekday(day string) bool {
+	for _, key := range domain.OpeningWeekdays {
+		if key == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOpeningHoursText reads the compact CSV form of opening hours, e.g.
+// "mon-fri 09:00-17:00; sat 10:00-14:00,18:00-02:00; 2024-12-25 closed".
+// Day ranges wrap (fri-mon), days can be listed with commas (sat,sun) and a
+// weekday marked closed simply has no periods. JSON is accepted as well.
+func parseOpeningHoursText(raw string) (domain.OpeningHours, error) {
+	raw = strings.TrimSpace(raw)
+	var hours domain.OpeningHours
+	if raw == "" {
+		return hours, nil
+	}
+	if strings.HasPrefix(raw, "{") {
+		if err := json.Unmarshal([]byte(raw), &hours); err != nil {
+			return hours, fmt.Errorf("opening_hours JSON is invalid")
+		}
+		return hours, nil
+	}
+
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Fields(entry)
+		if len(parts) != 2 {
+			return hours, fmt.Errorf("opening_hours entry %q must be \"<days> <hh:mm-hh:mm,...>\"", entry)
+		}
+		selector, spec := strings.ToLower(parts[0]), strings.ToLower(parts[1])
+
+		var periods []domain.OpeningPeriod
+		closed := spec == "closed"
+		if !closed {
+			for _, span := range strings.Split(spec, ",") {
+				open, close, ok := strings.Cut(span, "-")
+				if !ok {
+					return hours, fmt.Errorf("opening_hours period %q must be hh:mm-hh:mm", span)
+				}
+				periods = append(periods, domain.OpeningPeriod{Open: open, Close: close})
+			}
+		}
+
+		if _, err := time.Parse("2006-01-02", selector); err == nil {
+			hours.Exceptions = append(hours.Exceptions, domain.OpeningException{Date: selector, Closed: closed, Periods: periods})
+			continue
+		}
+		days, err := expandOpeningDays(selector)
+		if err != nil {
+			return hours, err
+		}
+		for _, day := range days {
+			for _, period := range periods {
+				period.Day = day
+				hours.Weekly = append(hours.Weekly, period)
+			}
+		}
+	}
+	return hours, nil
+}
+
+func expandOpeningDays(selector string) ([]string, error) {
+	index := func(day string) int {
+		for idx, key := range domain.OpeningWeekdays {
+			if key == day {
+				return idx
+			}
+		}
+		return -1
+	}
+
+	var days []string
+	for _, part := range strings.Split(selector, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		start := index(from)
+		if start < 0 {
+			return nil, fmt.Errorf("opening_hours day %q is not a weekday or YYYY-MM-DD date", part)
+		}
+		if !isRange {
+			days = append(days, from)
+			continue
+		}
+		end := index(to)
+		if end < 0 {
+			return nil, fmt.Errorf("opening_hours day %q is not a weekday", to)
+		}
+		for idx := start; ; idx = (idx + 1) % 7 {
+			days = append(days, domain.OpeningWeekdays[idx])
+			if idx == end {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// destinationLocation resolves a stored timezone, falling back to UTC.
+func destinationLocation(timezone *string) *time.Location {
+	if timezone == nil || *timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(*timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DestinationOpenAt reports whether a destination with structured hours is
+// open at the given instant. Destinations without hours are never open.
+func DestinationOpenAt(dest *domain.Destination, at time.Time) bool {
+	if dest == nil || dest.OpeningHours == nil || dest.OpeningHours.IsEmpty() {
+		return false
+	}
+	return dest.OpeningHours.IsOpenAt(at, destinationLocation(dest.Timezone))
+}
+
+// openingHoursOrEmpty returns a copy of hours, or empty hours so that an
+// update built from a snapshot clears them.
+func openingHoursOrEmpty(hours *domain.OpeningHours) *domain.OpeningHours {
+	if cloned := cloneOpeningHours(hours); cloned != nil {
+		return cloned
+	}
+	return &domain.OpeningHours{}
+}
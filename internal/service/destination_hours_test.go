@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationOpenAt(t *testing.T) {
+	hours, err := parseOpeningHoursText("mon-fri 09:00-12:00,13:00-17:00; sat 18:00-02:00; 2024-07-17 closed; 2024-07-19 20:00-01:00")
+	if err != nil {
+		t.Fatalf("parseOpeningHoursText: %v", err)
+	}
+	if problems := validateOpeningHours(hours); len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	dest := &domain.Destination{OpeningHours: &hours, Timezone: strPtr("Asia/Bangkok")}
+	bangkok, err := time.LoadLocation("Asia/Bangkok")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 7, day, hour, minute, 0, 0, bangkok)
+	}
+
+	cases := []struct {
+		name string
+		at   time.Time
+		open bool
+	}{
+		{"monday morning", at(15, 9, 30), true},
+		{"lunch break", at(15, 12, 30), false},
+		{"closing minute", at(15, 17, 0), false},
+		{"closed exception", at(17, 10, 0), false},
+		{"exception replaces weekly", at(19, 10, 0), false},
+		{"exception evening", at(19, 22, 0), true},
+		{"exception runs past midnight", at(20, 0, 30), true},
+		{"saturday night", at(20, 23, 0), true},
+		{"after midnight sunday", at(21, 1, 59), true},
+		{"sunday afternoon", at(21, 15, 0), false},
+		{"utc instant in bangkok hours", time.Date(2024, 7, 15, 3, 0, 0, 0, time.UTC), true},
+	}
+	for _, tc := range cases {
+		if got := DestinationOpenAt(dest, tc.at); got != tc.open {
+			t.Errorf("%s: expected open=%v, got %v", tc.name, tc.open, got)
+		}
+	}
+
+	if DestinationOpenAt(&domain.Destination{}, at(15, 10, 0)) {
+		t.Fatal("destination without hours should not be open")
+	}
+}
+
+func TestParseOpeningHoursTextErrors(t *testing.T) {
+	for _, raw := range []string{"mon", "someday 09:00-10:00", "mon 0900", "{not json"} {
+		if _, err := parseOpeningHoursText(raw); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+
+	hours, err := parseOpeningHoursText("fri-mon 10:00-11:00")
+	if err != nil {
+		t.Fatalf("parseOpeningHoursText: %v", err)
+	}
+	if len(hours.Weekly) != 4 || hours.Weekly[0].Day != "fri" || hours.Weekly[3].Day != "mon" {
+		t.Fatalf("expected wrapping range fri..mon, got %+v", hours.Weekly)
+	}
+
+	invalid := domain.OpeningHours{
+		Weekly:     []domain.OpeningPeriod{{Day: "funday", Open: "09:00", Close: "10:00"}, {Day: "mon", Open: "10:00", Close: "10:00"}},
+		Exceptions: []domain.OpeningException{{Date: "2024-02-30"}, {Date: "2024-03-01", Closed: true, Periods: []domain.OpeningPeriod{{Open: "09:00", Close: "10:00"}}}},
+	}
+	if problems := validateOpeningHours(invalid); len(problems) != 5 {
+		t.Fatalf("expected 5 problems, got %v", problems)
+	}
+}
+
+func TestDestinationWorkflowService_OpeningHours(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{})
+	svc.SetClock(func() time.Time { return now })
+	admin := uuid.New()
+
+	_, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Night Market"), Timezone: strPtr("Mars/Olympus")},
+	})
+	if !errors.Is(err, ErrDestinationChangeValidation) {
+		t.Fatalf("expected validation error for timezone, got %v", err)
+	}
+
+	hours := domain.OpeningHours{Weekly: []domain.OpeningPeriod{{Day: "mon", Open: "17:00", Close: "23:00"}}}
+	change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Night Market"), Timezone: strPtr("UTC"), OpeningHours: &hours},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+		t.Fatalf("SubmitDraft: %v", err)
+	}
+	if _, _, err = svc.Approve(ctx, change.ID, uuid.New(), ""); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("No Hours")}, admin, domain.DestinationStatusPublished, nil)
+
+	list := NewDestinationService(destRepo, DestinationServiceConfig{})
+	evening := time.Date(2024, 7, 15, 18, 0, 0, 0, time.UTC)
+	open, err := list.ListPublished(ctx, 10, 0, domain.DestinationListFilter{OpenAt: &evening})
+	if err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if len(open) != 1 || open[0].Name != "Night Market" {
+		t.Fatalf("expected only the night market open, got %d results", len(open))
+	}
+	if open[0].OpeningHours == nil || len(open[0].OpeningHours.Weekly) != 1 {
+		t.Fatalf("expected opening hours to be stored")
+	}
+
+	morning := time.Date(2024, 7, 15, 10, 0, 0, 0, time.UTC)
+	closed, err := list.ListPublished(ctx, 10, 0, domain.DestinationListFilter{OpenAt: &morning})
+	if err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if len(closed) != 0 {
+		t.Fatalf("expected nothing open in the morning, got %d", len(closed))
+	}
+}
+
+func TestDestinationWorkflowService_OpeningHoursZeroPadded(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC)
+	svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{})
+	svc.SetClock(func() time.Time { return now })
+
+	hours := domain.OpeningHours{
+		Weekly:     []domain.OpeningPeriod{{Day: " Mon ", Open: "9:00", Close: "17:30"}},
+		Exceptions: []domain.OpeningException{{Date: "2024-07-20", Periods: []domain.OpeningPeriod{{Open: "8:05", Close: "24:00"}}}},
+	}
+	change, err := svc.CreateDraft(ctx, uuid.New(), DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Flower Market"), OpeningHours: &hours},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+
+	stored := change.Payload.OpeningHours
+	if got := stored.Weekly[0]; got.Day != "mon" || got.Open != "09:00" || got.Close != "17:30" {
+		t.Fatalf("expected weekly period stored as mon 09:00-17:30, got %#v", got)
+	}
+	if got := stored.Exceptions[0].Periods[0]; got.Open != "08:05" || got.Close != "24:00" {
+		t.Fatalf("expected exception period stored as 08:05-24:00, got %#v", got)
+	}
+	if hours.Weekly[0].Open != "9:00" {
+		t.Fatalf("input hours should not be modified")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -29,7 +30,7 @@ var (
 type destinationWorkflow interface {
 	CreateDraft(ctx context.Context, authorID uuid.UUID, input DestinationDraftInput) (*domain.DestinationChangeRequest, error)
 	SubmitDraft(ctx context.Context, changeID uuid.UUID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error)
-	ValidateFields(action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error
+	ValidateFields(ctx context.Context, action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error
 }
 
 type DestinationImportServiceConfig struct {
@@ -40,7 +41,9 @@ type DestinationImportServiceConfig struct {
 }
 
 type destinationLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error)
 	FindBySlug(ctx context.Context, slug string) (*domain.Destination, error)
+	FindSlugOwner(ctx context.Context, slug string) (uuid.UUID, error)
 }
 
 type DestinationImportService struct {
@@ -101,9 +104,8 @@ func (s *DestinationImportService) Import(ctx context.Context, uploadedBy uuid.U
 		return nil, nil, ErrImportRowLimitExceeded
 	}
 
-	required := []string{"name", "category", "city", "country", "description", "latitude", "longitude", "contact", "hero_image_url"}
-	if missing := missingColumns(header, required); len(missing) > 0 {
-		return nil, nil, fmt.Errorf("%w: %s", ErrImportInvalidHeaders, strings.Join(missing, ", "))
+	if err := checkImportHeader(header, records); err != nil {
+		return nil, nil, err
 	}
 
 	jobID := uuid.New()
@@ -140,48 +142,28 @@ func (s *DestinationImportService) Import(ctx context.Context, uploadedBy uuid.U
 		}
 	}()
 
-	seenSlugs := make(map[string]int)
-	existingSlugs := make(map[string]bool)
+	seen := importSeen{
+		slugs:         make(map[string]int),
+		destinations:  make(map[uuid.UUID]int),
+		existingSlugs: make(map[string]bool),
+	}
 	pendingIDs := make([]uuid.UUID, 0, s.maxPendingIDs)
 	rows := make([]domain.DestinationImportRow, 0, len(records))
 
 	for idx, record := range records {
 		rowNumber := idx + 2 // account for header line
-		normalized := rowToMap(header, record)
-
-		fields, parseErrs := buildChangeFields(normalized)
-		rowErrors := append([]string{}, parseErrs...)
-
-		slugVal := normalized["slug"]
-		if slugVal != "" {
-			lower := strings.ToLower(slugVal)
-			if prev, ok := seenSlugs[lower]; ok {
-				rowErrors = append(rowErrors, fmt.Sprintf("slug duplicates row %d", prev))
-			} else {
-				seenSlugs[lower] = rowNumber
-				exists, err := s.slugExists(ctx, lower, existingSlugs)
-				if err != nil {
-					return nil, nil, err
-				}
-				if exists {
-					rowErrors = append(rowErrors, "slug already exists")
-				}
-			}
-		}
-
-		if fields.HeroImageURL == nil || strings.TrimSpace(*fields.HeroImageURL) == "" {
-			rowErrors = append(rowErrors, "hero image url is required")
-		}
-
-		if err := s.workflow.ValidateFields(domain.DestinationChangeActionCreate, fields, true); err != nil {
-			rowErrors = append(rowErrors, err.Error())
+		plan, err := s.planRow(ctx, rowToMap(header, record), rowNumber, &seen)
+		if err != nil {
+			return nil, nil, err
 		}
+		rowErrors := plan.errors
 
 		var changeID *uuid.UUID
-		if len(rowErrors) == 0 && !dryRun {
+		if len(rowErrors) == 0 && !dryRun && !plan.unchanged {
 			change, err := s.workflow.CreateDraft(ctx, uploadedBy, DestinationDraftInput{
-				Action: domain.DestinationChangeActionCreate,
-				Fields: fields,
+				Action:        plan.action,
+				DestinationID: plan.destinationID,
+				Fields:        plan.fields,
 			})
 			if err != nil {
 				rowErrors = append(rowErrors, err.Error())
@@ -202,8 +184,9 @@ func (s *DestinationImportService) Import(ctx context.Context, uploadedBy uuid.U
 		row := domain.DestinationImportRow{
 			JobID:     job.ID,
 			RowNumber: rowNumber,
-			Action:    domain.DestinationChangeActionCreate,
-			Payload:   fields,
+			Action:    plan.action,
+			Payload:   plan.fields,
+			Diff:      plan.diff,
 		}
 
 		if len(rowErrors) > 0 {
@@ -211,7 +194,7 @@ func (s *DestinationImportService) Import(ctx context.Context, uploadedBy uuid.U
 			row.Status = domain.DestinationImportRowStatusFailed
 			message := strings.Join(rowErrors, "; ")
 			row.ErrorMessage = &message
-		} else if dryRun {
+		} else if dryRun || plan.unchanged {
 			row.Status = domain.DestinationImportRowStatusSkipped
 		} else {
 			row.Status = domain.DestinationImportRowStatusPendingReview
@@ -271,6 +254,267 @@ func (s *DestinationImportService) slugExists(ctx context.Context, slug string,
 	return cache[slug], nil
 }
 
+// importRowPlan is what one CSV row asks for: the change request to draft,
+// its diff against the live destination and any validation errors.
+type importRowPlan struct {
+	action        domain.DestinationChangeAction
+	destinationID *uuid.UUID
+	fields        domain.DestinationChangeFields
+	diff          *domain.DestinationDiff
+	// unchanged marks update rows that match the live destination; no change
+	// request is drafted for them.
+	unchanged bool
+	errors    []string
+}
+
+// importSeen tracks the rows already planned so one file cannot create the
+// same slug twice or edit a destination from two rows.
+type importSeen struct {
+	slugs         map[string]int
+	destinations  map[uuid.UUID]int
+	existingSlugs map[string]bool
+}
+
+func (s *DestinationImportService) planRow(ctx context.Context, values map[string]string, rowNumber int, seen *importSeen) (importRowPlan, error) {
+	action, err := importRowAction(values)
+	if err != nil {
+		return importRowPlan{action: domain.DestinationChangeActionCreate, errors: []string{err.Error()}}, nil
+	}
+	if action == domain.DestinationChangeActionCreate {
+		return s.planCreate(ctx, values, rowNumber, seen)
+	}
+
+	plan := importRowPlan{action: action}
+	target, problem, err := s.findImportTarget(ctx, values)
+	if err != nil {
+		return plan, err
+	}
+	if problem != "" {
+		plan.errors = append(plan.errors, problem)
+		return plan, nil
+	}
+	plan.destinationID = &target.ID
+	if prev, ok := seen.destinations[target.ID]; ok {
+		plan.errors = append(plan.errors, fmt.Sprintf("destination duplicates row %d", prev))
+	} else {
+		seen.destinations[target.ID] = rowNumber
+	}
+
+	current := snapshotFromDestination(target)
+	if action == domain.DestinationChangeActionDelete {
+		archived := current
+		archived.Status = domain.DestinationStatusArchived
+		plan.diff = importDiff(target, diffSnapshots(current, archived))
+		return plan, nil
+	}
+
+	fields, parseErrs := buildChangeFields(values)
+	plan.errors = append(plan.errors, parseErrs...)
+	if strings.TrimSpace(values["status"]) == "" {
+		// Creates default to published; updates keep the current status.
+		fields.Status = nil
+	}
+	if strings.TrimSpace(values["id"]) == "" {
+		// The slug picked the destination, so it cannot also rename it.
+		fields.Slug = nil
+	}
+	if fields.Translations != nil {
+		merged := mergeImportTranslations(target.Translations, *fields.Translations)
+		fields.Translations = &merged
+	}
+
+	diff := diffSnapshots(current, applyChangeFieldsToSnapshot(current, fields))
+	keepChangedFields(&fields, diff)
+	plan.fields = fields
+	plan.diff = importDiff(target, diff)
+	plan.unchanged = diff.IsEmpty()
+
+	if fields.Slug != nil && *fields.Slug != "" {
+		if prev, ok := seen.slugs[*fields.Slug]; ok {
+			plan.errors = append(plan.errors, fmt.Sprintf("slug duplicates row %d", prev))
+		} else {
+			seen.slugs[*fields.Slug] = rowNumber
+			owner, err := s.destinations.FindSlugOwner(ctx, *fields.Slug)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return plan, err
+			}
+			if err == nil && owner != target.ID {
+				plan.errors = append(plan.errors, "slug already exists")
+			}
+		}
+	}
+	if !plan.unchanged {
+		if err := s.workflow.ValidateFields(ctx, action, fields, false); err != nil {
+			plan.errors = append(plan.errors, err.Error())
+		}
+	}
+	return plan, nil
+}
+
+func (s *DestinationImportService) planCreate(ctx context.Context, values map[string]string, rowNumber int, seen *importSeen) (importRowPlan, error) {
+	fields, parseErrs := buildChangeFields(values)
+	plan := importRowPlan{action: domain.DestinationChangeActionCreate, fields: fields}
+	plan.errors = append(plan.errors, parseErrs...)
+
+	if strings.TrimSpace(values["id"]) != "" {
+		plan.errors = append(plan.errors, "id must be blank for create rows")
+	}
+	if slugVal := values["slug"]; slugVal != "" {
+		lower := strings.ToLower(slugVal)
+		if prev, ok := seen.slugs[lower]; ok {
+			plan.errors = append(plan.errors, fmt.Sprintf("slug duplicates row %d", prev))
+		} else {
+			seen.slugs[lower] = rowNumber
+			exists, err := s.slugExists(ctx, lower, seen.existingSlugs)
+			if err != nil {
+				return plan, err
+			}
+			if exists {
+				plan.errors = append(plan.errors, "slug already exists")
+			}
+		}
+	}
+
+	if fields.HeroImageURL == nil || strings.TrimSpace(*fields.HeroImageURL) == "" {
+		plan.errors = append(plan.errors, "hero image url is required")
+	}
+
+	if err := s.workflow.ValidateFields(ctx, domain.DestinationChangeActionCreate, fields, true); err != nil {
+		plan.errors = append(plan.errors, err.Error())
+	}
+
+	diff := diffSnapshots(domain.DestinationSnapshot{}, applyChangeFieldsToSnapshot(domain.DestinationSnapshot{Status: domain.DestinationStatusPublished}, fields))
+	plan.diff = &diff
+	return plan, nil
+}
+
+// findImportTarget resolves the destination an update or delete row names,
+// by id when given and by slug otherwise. Problems with the row are returned
+// as a message; err is only set when the lookup itself fails.
+func (s *DestinationImportService) findImportTarget(ctx context.Context, values map[string]string) (_ *domain.Destination, problem string, err error) {
+	var dest *domain.Destination
+	if rawID := strings.TrimSpace(values["id"]); rawID != "" {
+		id, parseErr := uuid.Parse(rawID)
+		if parseErr != nil {
+			return nil, "id must be a valid UUID", nil
+		}
+		dest, err = s.destinations.FindByID(ctx, id)
+	} else if slug := strings.ToLower(strings.TrimSpace(values["slug"])); slug != "" {
+		dest, err = s.destinations.FindBySlug(ctx, slug)
+	} else {
+		return nil, "id or slug is required to update or delete", nil
+	}
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && (dest == nil || dest.DeletedAt != nil)) {
+		return nil, "destination not found", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return dest, "", nil
+}
+
+// importRowAction reads the action column. Rows without one update the
+// destination in their id column, as in an edited export, and create
+// otherwise.
+func importRowAction(values map[string]string) (domain.DestinationChangeAction, error) {
+	switch action := domain.DestinationChangeAction(strings.ToLower(strings.TrimSpace(values["action"]))); action {
+	case "":
+		if strings.TrimSpace(values["id"]) != "" {
+			return domain.DestinationChangeActionUpdate, nil
+		}
+		return domain.DestinationChangeActionCreate, nil
+	case domain.DestinationChangeActionCreate, domain.DestinationChangeActionUpdate, domain.DestinationChangeActionDelete:
+		return action, nil
+	default:
+		return "", errors.New("action must be create, update, or delete")
+	}
+}
+
+// checkImportHeader requires the create columns only when some row creates,
+// and a way to match destinations when some row updates or deletes.
+func checkImportHeader(header []string, records [][]string) error {
+	var creates, edits bool
+	for _, record := range records {
+		action, err := importRowAction(rowToMap(header, record))
+		switch {
+		case err != nil:
+		case action == domain.DestinationChangeActionCreate:
+			creates = true
+		default:
+			edits = true
+		}
+	}
+	var missing []string
+	if creates {
+		required := []string{"name", "category", "city", "country", "description", "latitude", "longitude", "contact", "hero_image_url"}
+		missing = missingColumns(header, required)
+	}
+	if edits && !slices.Contains(header, "id") && !slices.Contains(header, "slug") {
+		missing = append(missing, "id or slug")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrImportInvalidHeaders, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// importChangeFields lists the fields clearChangeField knows, gallery aside.
+var importChangeFields = []string{
+	"name", "slug", "status", "city", "country", "category", "description",
+	"latitude", "longitude", "contact", "opening_time", "closing_time",
+	"timezone", "opening_hours", "translations", "tags", "hero_image_url",
+}
+
+// keepChangedFields drops the fields of an update row that match the live
+// destination, so the change request carries only real edits.
+func keepChangedFields(fields *domain.DestinationChangeFields, diff domain.DestinationDiff) {
+	changed := make(map[string]bool, len(diff.Fields))
+	for _, field := range diff.Fields {
+		changed[field.Field] = true
+	}
+	for _, field := range importChangeFields {
+		if !changed[field] {
+			clearChangeField(fields, field)
+		}
+	}
+	if len(diff.Gallery) == 0 {
+		clearChangeField(fields, "gallery")
+	}
+}
+
+// mergeImportTranslations lays the translation columns of a row over the
+// stored translations, so a file carrying only name_th keeps the rest.
+func mergeImportTranslations(live, incoming domain.DestinationTranslations) domain.DestinationTranslations {
+	merged := cloneTranslations(live)
+	if merged == nil {
+		merged = domain.DestinationTranslations{}
+	}
+	for locale, translation := range incoming {
+		current := merged[locale]
+		if translation.Name != nil {
+			current.Name = translation.Name
+		}
+		if translation.Description != nil {
+			current.Description = translation.Description
+		}
+		for url, caption := range translation.Captions {
+			if current.Captions == nil {
+				current.Captions = make(map[string]string)
+			}
+			current.Captions[url] = caption
+		}
+		merged[locale] = current
+	}
+	return merged
+}
+
+func importDiff(dest *domain.Destination, diff domain.DestinationDiff) *domain.DestinationDiff {
+	diff.DestinationID = &dest.ID
+	version := dest.Version
+	diff.FromVersion = &version
+	return &diff
+}
+
 func parseCSV(contents []byte) ([]string, [][]string, error) {
 	reader := csv.NewReader(bytes.NewReader(contents))
 	reader.TrimLeadingSpace = true
@@ -363,6 +607,20 @@ func buildChangeFields(values map[string]string) (domain.DestinationChangeFields
 	if v := strings.TrimSpace(values["closing_time"]); v != "" {
 		fields.ClosingTime = stringPointer(v)
 	}
+	if v := strings.TrimSpace(values["timezone"]); v != "" {
+		fields.Timezone = stringPointer(v)
+	}
+	if v := strings.TrimSpace(values["opening_hours"]); v != "" {
+		if hours, err := parseOpeningHoursText(v); err == nil {
+			fields.OpeningHours = normalizeOpeningHoursPtr(&hours)
+		} else {
+			errs = append(errs, err.Error())
+		}
+	}
+	if v := strings.TrimSpace(values["tags"]); v != "" {
+		tags := normalizeTags(strings.FieldsFunc(v, func(r rune) bool { return r == '|' || r == ',' || r == ';' }))
+		fields.Tags = &tags
+	}
 	if latStr := strings.TrimSpace(values["latitude"]); latStr != "" {
 		if lat, err := parseFloat(latStr); err == nil {
 			fields.Latitude = &lat
@@ -400,15 +658,68 @@ func buildChangeFields(values map[string]string) (domain.DestinationChangeFields
 	if gallery := buildGallery(values); gallery != nil {
 		fields.Gallery = gallery
 	}
+	if translations := buildTranslations(values, fields.Gallery); translations != nil {
+		fields.Translations = translations
+	}
 
 	return fields, errs
 }
 
+// buildTranslations collects name_<locale>, description_<locale> and
+// gallery_<n>_caption_<locale> columns. Captions are keyed by the URL in the
+// matching gallery_<n>_url column.
+func buildTranslations(values map[string]string, gallery *domain.DestinationGallery) *domain.DestinationTranslations {
+	translations := domain.DestinationTranslations{}
+	entry := func(locale string) domain.DestinationTranslation {
+		return translations[normalizeLocale(locale)]
+	}
+	for key, raw := range values {
+		value := strings.TrimSpace(raw)
+		if value == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(key, "name_"):
+			locale := strings.TrimPrefix(key, "name_")
+			translation := entry(locale)
+			translation.Name = stringPointer(value)
+			translations[normalizeLocale(locale)] = translation
+		case strings.HasPrefix(key, "description_"):
+			locale := strings.TrimPrefix(key, "description_")
+			translation := entry(locale)
+			translation.Description = stringPointer(value)
+			translations[normalizeLocale(locale)] = translation
+		case strings.HasPrefix(key, "gallery_") && strings.Contains(key, "_caption_"):
+			slot, locale, _ := strings.Cut(strings.TrimPrefix(key, "gallery_"), "_caption_")
+			url := strings.TrimSpace(values["gallery_"+slot+"_url"])
+			if url == "" {
+				continue
+			}
+			translation := entry(locale)
+			if translation.Captions == nil {
+				translation.Captions = make(map[string]string)
+			}
+			translation.Captions[url] = value
+			translations[normalizeLocale(locale)] = translation
+		}
+	}
+	if len(translations) == 0 {
+		return nil
+	}
+	return &translations
+}
+
+// buildGallery reads gallery_<n>_url and gallery_<n>_caption from the first
+// slot up to the last gallery column present; the template has three slots,
+// exports as many as the largest gallery.
 func buildGallery(values map[string]string) *domain.DestinationGallery {
 	items := make([]domain.DestinationMedia, 0, 3)
-	for idx := 1; idx <= 3; idx++ {
+	for idx := 1; ; idx++ {
 		urlKey := fmt.Sprintf("gallery_%d_url", idx)
 		captionKey := fmt.Sprintf("gallery_%d_caption", idx)
+		if _, ok := values[urlKey]; !ok && idx > 3 {
+			break
+		}
 		if url := strings.TrimSpace(values[urlKey]); url != "" {
 			item := domain.DestinationMedia{
 				URL:      url,
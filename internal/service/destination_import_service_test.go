@@ -123,15 +123,125 @@ func TestDestinationImportService_DetectsDuplicateSlugs(t *testing.T) {
 	}
 }
 
+func TestDestinationImportService_UpdateRowsCarryOnlyChangedFields(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	market := destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+		Name:     strPtr("Chatuchak Market"),
+		Slug:     strPtr("chatuchak-market"),
+		City:     strPtr("Bangkok"),
+		Category: strPtr("Shopping"),
+		Translations: &domain.DestinationTranslations{
+			"th": {Name: strPtr("ตลาดนัดจตุจักร"), Description: strPtr("ตลาดนัดสุดสัปดาห์")},
+		},
+	}, admin, domain.DestinationStatusPublished, nil)
+	destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Lumphini Park"), Slug: strPtr("lumphini-park")}, admin, domain.DestinationStatusPublished, nil)
+
+	workflow := &stubWorkflow{}
+	svc := NewDestinationImportService(newMemoryImportRepo(), destRepo, workflow, &noopStorage{}, DestinationImportServiceConfig{})
+
+	csvData := "id,action,slug,name,city,category,status,name_th\n" +
+		market.ID.String() + ",,chatuchak-market,Chatuchak Market,Chatuchak,Shopping,,ตลาดจตุจักร\n" +
+		",update,lumphini-park,Lumphini Park,,,,\n" +
+		",delete,lumphini-park,,,,,\n"
+
+	job, rows, err := svc.Import(ctx, admin, "updates.csv", []byte(csvData), false, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.ChangesCreated != 1 || len(workflow.created) != 1 {
+		t.Fatalf("expected 1 change, got %d (%d drafts)", job.ChangesCreated, len(workflow.created))
+	}
+
+	update := workflow.created[0]
+	if update.Action != domain.DestinationChangeActionUpdate || update.DestinationID == nil || *update.DestinationID != market.ID {
+		t.Fatalf("expected update of %s, got %s on %v", market.ID, update.Action, update.DestinationID)
+	}
+	fields := update.Payload
+	if fields.City == nil || *fields.City != "Chatuchak" {
+		t.Fatalf("expected city change, got %v", fields.City)
+	}
+	if fields.Name != nil || fields.Slug != nil || fields.Category != nil || fields.Status != nil {
+		t.Fatalf("expected unchanged fields to be dropped, got %+v", fields)
+	}
+	if fields.Translations == nil {
+		t.Fatal("expected translation change")
+	}
+	th := (*fields.Translations)["th"]
+	if th.Name == nil || *th.Name != "ตลาดจตุจักร" || th.Description == nil || *th.Description != "ตลาดนัดสุดสัปดาห์" {
+		t.Fatalf("expected new name over kept description, got %+v", th)
+	}
+
+	if rows[1].Status != domain.DestinationImportRowStatusSkipped || rows[1].ChangeID != nil {
+		t.Fatalf("unchanged row should be skipped, got %+v", rows[1])
+	}
+	if rows[2].Action != domain.DestinationChangeActionDelete || rows[2].Status != domain.DestinationImportRowStatusFailed {
+		t.Fatalf("second row for the same destination should fail, got %+v", rows[2])
+	}
+	if rows[2].ErrorMessage == nil || !strings.Contains(*rows[2].ErrorMessage, "destination duplicates row 3") {
+		t.Fatalf("expected duplicate destination error, got %v", rows[2].ErrorMessage)
+	}
+}
+
+func TestDestinationImportService_DryRunReportsDiffs(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	dest := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Erawan Falls"), Slug: strPtr("erawan-falls"), City: strPtr("Kanchanaburi")}, admin, domain.DestinationStatusPublished, nil)
+	archived := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Old Pier"), Slug: strPtr("old-pier")}, admin, domain.DestinationStatusPublished, nil)
+
+	workflow := &stubWorkflow{}
+	svc := NewDestinationImportService(newMemoryImportRepo(), destRepo, workflow, &noopStorage{}, DestinationImportServiceConfig{})
+
+	csvData := "action,id,slug,name,status\n" +
+		"update," + dest.ID.String() + ",erawan-waterfall,Erawan Falls,\n" +
+		"delete,,old-pier,,\n" +
+		"update," + uuid.NewString() + ",,Ghost,\n" +
+		"archive,,old-pier,,\n"
+
+	job, rows, err := svc.Import(ctx, admin, "dry.csv", []byte(csvData), true, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(workflow.created) != 0 || job.ChangesCreated != 0 {
+		t.Fatal("dry run should not draft changes")
+	}
+
+	rename := rows[0]
+	if rename.Status != domain.DestinationImportRowStatusSkipped || rename.Diff == nil {
+		t.Fatalf("expected skipped row with diff, got %+v", rename)
+	}
+	if len(rename.Diff.Fields) != 1 || rename.Diff.Fields[0].Field != "slug" || rename.Diff.Fields[0].Old != "erawan-falls" || rename.Diff.Fields[0].New != "erawan-waterfall" {
+		t.Fatalf("expected slug diff, got %+v", rename.Diff.Fields)
+	}
+	if rename.Diff.DestinationID == nil || *rename.Diff.DestinationID != dest.ID {
+		t.Fatalf("expected diff against %s, got %v", dest.ID, rename.Diff.DestinationID)
+	}
+
+	remove := rows[1]
+	if remove.Diff == nil || len(remove.Diff.Fields) != 1 || remove.Diff.Fields[0].New != string(domain.DestinationStatusArchived) {
+		t.Fatalf("expected delete to archive %s, got %+v", archived.ID, remove.Diff)
+	}
+
+	for idx, want := range map[int]string{2: "destination not found", 3: "action must be create, update, or delete"} {
+		if rows[idx].Status != domain.DestinationImportRowStatusFailed || rows[idx].ErrorMessage == nil || !strings.Contains(*rows[idx].ErrorMessage, want) {
+			t.Fatalf("row %d: expected %q, got %+v", rows[idx].RowNumber, want, rows[idx])
+		}
+	}
+}
+
 type stubWorkflow struct {
 	created []*domain.DestinationChangeRequest
 }
 
 func (s *stubWorkflow) CreateDraft(ctx context.Context, authorID uuid.UUID, input DestinationDraftInput) (*domain.DestinationChangeRequest, error) {
 	req := &domain.DestinationChangeRequest{
-		ID:          uuid.New(),
-		SubmittedBy: authorID,
-		Payload:     input.Fields,
+		ID:            uuid.New(),
+		DestinationID: input.DestinationID,
+		Action:        input.Action,
+		SubmittedBy:   authorID,
+		Payload:       input.Fields,
 	}
 	s.created = append(s.created, req)
 	return req, nil
@@ -144,7 +254,7 @@ func (s *stubWorkflow) SubmitDraft(ctx context.Context, changeID uuid.UUID, auth
 	}, nil
 }
 
-func (s *stubWorkflow) ValidateFields(action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error {
+func (s *stubWorkflow) ValidateFields(ctx context.Context, action domain.DestinationChangeAction, fields domain.DestinationChangeFields, requireAll bool) error {
 	return nil
 }
 
@@ -159,12 +269,27 @@ func (s *stubDestinationLookup) FindBySlug(ctx context.Context, slug string) (*d
 	return nil, sql.ErrNoRows
 }
 
+func (s *stubDestinationLookup) FindSlugOwner(ctx context.Context, slug string) (uuid.UUID, error) {
+	if s.slugs[strings.ToLower(strings.TrimSpace(slug))] {
+		return uuid.New(), nil
+	}
+	return uuid.Nil, sql.ErrNoRows
+}
+
+func (s *stubDestinationLookup) FindByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error) {
+	return nil, sql.ErrNoRows
+}
+
 type noopStorage struct{}
 
 func (n *noopStorage) Upload(ctx context.Context, bucket, objectName, contentType string, reader io.Reader, size int64) (string, error) {
 	return objectName, nil
 }
 
+func (n *noopStorage) Delete(ctx context.Context, bucket, objectName string) error {
+	return nil
+}
+
 type memoryImportRepo struct {
 	job  *domain.DestinationImportJob
 	rows []domain.DestinationImportRow
@@ -219,3 +344,36 @@ func (m *memoryImportRepo) ListRowsByJob(ctx context.Context, jobID uuid.UUID) (
 	copy(out, m.rows)
 	return out, nil
 }
+
+func TestBuildChangeFieldsTranslationsAndHours(t *testing.T) {
+	fields, errs := buildChangeFields(map[string]string{
+		"name":                 "Chatuchak Market",
+		"name_th":              "ตลาดนัดจตุจักร",
+		"description_th":       "ตลาดนัดสุดสัปดาห์",
+		"gallery_1_url":        "https://cdn.example/jj-1.jpg",
+		"gallery_1_caption":    "Clock tower",
+		"gallery_1_caption_th": "หอนาฬิกา",
+		"timezone":             "Asia/Bangkok",
+		"opening_hours":        "sat,sun 09:00-18:00; fri 18:00-00:00",
+	})
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if fields.Translations == nil {
+		t.Fatal("expected translations")
+	}
+	th := (*fields.Translations)["th"]
+	if th.Name == nil || *th.Name != "ตลาดนัดจตุจักร" || th.Description == nil {
+		t.Fatalf("unexpected thai translation: %+v", th)
+	}
+	if th.Captions["https://cdn.example/jj-1.jpg"] != "หอนาฬิกา" {
+		t.Fatalf("expected caption keyed by gallery url, got %v", th.Captions)
+	}
+	if fields.OpeningHours == nil || len(fields.OpeningHours.Weekly) != 3 {
+		t.Fatalf("expected three weekly periods, got %+v", fields.OpeningHours)
+	}
+
+	if _, errs = buildChangeFields(map[string]string{"opening_hours": "weekdays 9-5"}); len(errs) == 0 {
+		t.Fatal("expected opening_hours error")
+	}
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+const defaultDestinationLocale = "en"
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(?:-[a-z0-9]{2,8})*$`)
+
+// localeSet describes the locales destination content can be served in. The
+// default locale is the language of the destination's own fields; supported
+// lists the others accepted as translations (any valid tag when empty).
+type localeSet struct {
+	defaultLocale string
+	supported     map[string]struct{}
+}
+
+func newLocaleSet(defaultLocale string, supported []string) localeSet {
+	set := localeSet{defaultLocale: normalizeLocale(defaultLocale)}
+	if set.defaultLocale == "" {
+		set.defaultLocale = defaultDestinationLocale
+	}
+	set.supported = make(map[string]struct{}, len(supported))
+	for _, raw := range supported {
+		locale := normalizeLocale(raw)
+		if locale == "" {
+			continue
+		}
+		set.supported[locale] = struct{}{}
+	}
+	if len(set.supported) > 0 {
+		set.supported[set.defaultLocale] = struct{}{}
+	}
+	return set
+}
+
+func normalizeLocale(raw string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(raw), "_", "-"))
+}
+
+func (l localeSet) accepts(locale string) bool {
+	if !localePattern.MatchString(locale) {
+		return false
+	}
+	if len(l.supported) == 0 {
+		return true
+	}
+	_, ok := l.supported[locale]
+	return ok
+}
+
+// match finds the served locale for a requested tag, trying the full tag first
+// and then its primary language ("th-TH" -> "th").
+func (l localeSet) match(tag string) (string, bool) {
+	tag = normalizeLocale(tag)
+	if tag == "" || tag == "*" {
+		return "", false
+	}
+	if l.accepts(tag) {
+		return tag, true
+	}
+	if primary, _, found := strings.Cut(tag, "-"); found && l.accepts(primary) {
+		return primary, true
+	}
+	return "", false
+}
+
+// resolve picks the locale from an explicit ?lang= value, then the
+// Accept-Language header by quality, then the default locale.
+func (l localeSet) resolve(lang, acceptLanguage string) string {
+	if locale, ok := l.match(lang); ok {
+		return locale
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if locale, ok := l.match(tag); ok {
+			return locale
+		}
+	}
+	return l.defaultLocale
+}
+
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var entries []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		entries = append(entries, weighted{tag: tag, quality: quality})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].quality > entries[j].quality })
+	tags := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		tags = append(tags, entry.tag)
+	}
+	return tags
+}
+
+func (l localeSet) validateTranslations(translations domain.DestinationTranslations) []string {
+	var problems []string
+	locales := make([]string, 0, len(translations))
+	for locale := range translations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	for _, locale := range locales {
+		translation := translations[locale]
+		switch {
+		case locale != normalizeLocale(locale) || !l.accepts(locale):
+			problems = append(problems, fmt.Sprintf("translations locale %q is not supported", locale))
+			continue
+		case locale == l.defaultLocale:
+			problems = append(problems, fmt.Sprintf("translations cannot override the default locale %q", locale))
+			continue
+		}
+		if translation.Name != nil && strings.TrimSpace(*translation.Name) == "" {
+			problems = append(problems, fmt.Sprintf("translations.%s.name cannot be empty", locale))
+		}
+		for url := range translation.Captions {
+			if strings.TrimSpace(url) == "" {
+				problems = append(problems, fmt.Sprintf("translations.%s.captions keys must be gallery urls", locale))
+				break
+			}
+		}
+	}
+	return problems
+}
+
+// localize returns a copy of dest with name, description and gallery captions
+// taken from the locale's translation where present, falling back to the
+// default-locale values field by field. The translation map is dropped.
+func (l localeSet) localize(dest *domain.Destination, locale string) *domain.Destination {
+	if dest == nil {
+		return nil
+	}
+	out := *dest
+	out.Translations = nil
+	translation, ok := dest.Translations[locale]
+	if !ok || locale == l.defaultLocale {
+		return &out
+	}
+	if translation.Name != nil && strings.TrimSpace(*translation.Name) != "" {
+		out.Name = *translation.Name
+	}
+	if translation.Description != nil && strings.TrimSpace(*translation.Description) != "" {
+		out.Description = stringPtr(*translation.Description)
+		// The search headline quotes the default-locale description.
+		out.SearchHeadline = nil
+	}
+	if len(translation.Captions) > 0 && len(dest.Gallery) > 0 {
+		out.Gallery = cloneGallery(dest.Gallery)
+		for idx := range out.Gallery {
+			if caption, ok := translation.Captions[out.Gallery[idx].URL]; ok && strings.TrimSpace(caption) != "" {
+				out.Gallery[idx].Caption = stringPtr(caption)
+			}
+		}
+	}
+	return &out
+}
+
+// cloneTranslations deep-copies translations; an empty map becomes nil.
+func cloneTranslations(src domain.DestinationTranslations) domain.DestinationTranslations {
+	if len(src) == 0 {
+		return nil
+	}
+	out := make(domain.DestinationTranslations, len(src))
+	for locale, translation := range src {
+		copied := domain.DestinationTranslation{}
+		if translation.Name != nil {
+			copied.Name = stringPtr(*translation.Name)
+		}
+		if translation.Description != nil {
+			copied.Description = stringPtr(*translation.Description)
+		}
+		if len(translation.Captions) > 0 {
+			copied.Captions = make(map[string]string, len(translation.Captions))
+			for url, caption := range translation.Captions {
+				copied.Captions[url] = caption
+			}
+		}
+		out[locale] = copied
+	}
+	return out
+}
+
+// translationsOrEmpty returns a copy of translations, or an empty map so that
+// an update built from a snapshot clears them.
+func translationsOrEmpty(src domain.DestinationTranslations) *domain.DestinationTranslations {
+	out := cloneTranslations(src)
+	if out == nil {
+		out = domain.DestinationTranslations{}
+	}
+	return &out
+}
+
+// sameTranslations compares translations, treating nil and empty as equal.
+func sameTranslations(a, b domain.DestinationTranslations) bool {
+	return reflect.DeepEqual(cloneTranslations(a), cloneTranslations(b))
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationService_ResolveLocale(t *testing.T) {
+	svc := NewDestinationService(newMemoryDestinationRepo(time.Now()), DestinationServiceConfig{
+		DefaultLocale:    "en",
+		SupportedLocales: []string{"en", "th"},
+	})
+
+	cases := []struct {
+		lang   string
+		header string
+		want   string
+	}{
+		{"", "", "en"},
+		{"th", "en-US", "th"},
+		{"TH_th", "", "th"},
+		{"fr", "th-TH,th;q=0.9,en;q=0.8", "th"},
+		{"", "fr-FR, en;q=0.5, th;q=0.7", "th"},
+		{"", "th;q=0, en", "en"},
+		{"", "*", "en"},
+	}
+	for _, tc := range cases {
+		if got := svc.ResolveLocale(tc.lang, tc.header); got != tc.want {
+			t.Errorf("ResolveLocale(%q, %q) = %q, want %q", tc.lang, tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestDestinationWorkflowService_Translations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC)
+	destRepo := newMemoryDestinationRepo(now)
+	locales := []string{"en", "th"}
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		DefaultLocale:    "en",
+		SupportedLocales: locales,
+	})
+	svc.SetClock(func() time.Time { return now })
+	admin := uuid.New()
+
+	for _, locale := range []string{"fr", "en", "TH"} {
+		_, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{
+				Name:         strPtr("Grand Palace"),
+				Translations: &domain.DestinationTranslations{locale: {Name: strPtr("พระบรมมหาราชวัง")}},
+			},
+		})
+		if !errors.Is(err, ErrDestinationChangeValidation) {
+			t.Fatalf("expected validation error for locale %q, got %v", locale, err)
+		}
+	}
+
+	gallery := domain.DestinationGallery{
+		{URL: "https://cdn.example/palace-1.jpg", Caption: strPtr("Main hall")},
+		{URL: "https://cdn.example/palace-2.jpg", Caption: strPtr("Courtyard")},
+	}
+	change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{
+			Name:        strPtr("Grand Palace"),
+			Description: strPtr("Former royal residence"),
+			Gallery:     &gallery,
+			Translations: &domain.DestinationTranslations{"th": {
+				Name:     strPtr("พระบรมมหาราชวัง"),
+				Captions: map[string]string{"https://cdn.example/palace-1.jpg": "พระที่นั่ง"},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	diff, err := svc.DiffChange(ctx, change.ID)
+	if err != nil {
+		t.Fatalf("DiffChange: %v", err)
+	}
+	if !diffHasField(diff, "translations") {
+		t.Fatalf("expected translations in diff, got %+v", diff.Fields)
+	}
+	if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+		t.Fatalf("SubmitDraft: %v", err)
+	}
+	_, dest, err := svc.Approve(ctx, change.ID, uuid.New(), "")
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if _, ok := dest.Translations["th"]; !ok {
+		t.Fatalf("expected thai translation to be published")
+	}
+
+	public := NewDestinationService(destRepo, DestinationServiceConfig{DefaultLocale: "en", SupportedLocales: locales})
+	localized := public.Localize(dest, "th")
+	if localized.Name != "พระบรมมหาราชวัง" {
+		t.Fatalf("expected thai name, got %q", localized.Name)
+	}
+	if localized.Description == nil || *localized.Description != "Former royal residence" {
+		t.Fatalf("expected description to fall back to default locale")
+	}
+	if *localized.Gallery[0].Caption != "พระที่นั่ง" || *localized.Gallery[1].Caption != "Courtyard" {
+		t.Fatalf("unexpected captions: %q, %q", *localized.Gallery[0].Caption, *localized.Gallery[1].Caption)
+	}
+	if localized.Translations != nil || *dest.Gallery[0].Caption != "Main hall" {
+		t.Fatalf("localize must not leak translations or mutate the source")
+	}
+
+	found, err := public.ListPublished(ctx, 10, 0, domain.DestinationListFilter{Search: "พระบรม"})
+	if err != nil {
+		t.Fatalf("ListPublished: %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("expected search to match thai name, got %d", len(found))
+	}
+	suggestions, err := public.Autocomplete(ctx, "พระบรม", 5)
+	if err != nil {
+		t.Fatalf("Autocomplete: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0] != "พระบรมมหาราชวัง" {
+		t.Fatalf("unexpected suggestions: %v", suggestions)
+	}
+}
+
+func diffHasField(diff *domain.DestinationDiff, field string) bool {
+	for _, change := range diff.Fields {
+		if change.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+var ErrInvalidMapQuery = errors.New("invalid map query")
+
+const (
+	MaxMapZoom = 22
+
+	// mapPointZoom is the first zoom level that returns individual
+	// destinations instead of clusters.
+	mapPointZoom = 14
+	// mapCellsPerTile splits each 256px web-map tile into a grid of this many
+	// cells per side, so clusters are roughly 64px apart on screen.
+	mapCellsPerTile = 4
+	mapMarkerLimit  = 500
+)
+
+// DestinationMap is the marker set for one map viewport.
+type DestinationMap struct {
+	Zoom      int
+	Clustered bool
+	Markers   []domain.DestinationMapCluster
+}
+
+// Map returns the published destinations inside filter.BBox as markers for a
+// web map at zoom. Below mapPointZoom nearby destinations are grouped into
+// grid clusters with a count and centroid; from mapPointZoom on every
+// destination is its own marker.
+func (s *DestinationService) Map(ctx context.Context, filter domain.DestinationListFilter, zoom int) (*DestinationMap, error) {
+	if filter.BBox == nil {
+		return nil, fmt.Errorf("%w: bbox is required", ErrInvalidMapQuery)
+	}
+	if zoom < 0 || zoom > MaxMapZoom {
+		return nil, fmt.Errorf("%w: zoom must be between 0 and %d", ErrInvalidMapQuery, MaxMapZoom)
+	}
+	filter, err := s.prepareFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DestinationMap{Zoom: zoom, Clustered: zoom < mapPointZoom}
+	if result.Clustered {
+		result.Markers, err = s.destinations.ClusterPublished(ctx, filter, mapCellDegrees(zoom), mapMarkerLimit)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	found, err := s.destinations.ListPublished(ctx, mapMarkerLimit, 0, filter)
+	if err != nil {
+		return nil, err
+	}
+	result.Markers = make([]domain.DestinationMapCluster, 0, len(found))
+	for idx := range found {
+		dest := found[idx]
+		if dest.Latitude == nil || dest.Longitude == nil {
+			continue
+		}
+		result.Markers = append(result.Markers, domain.DestinationMapCluster{
+			Latitude:      *dest.Latitude,
+			Longitude:     *dest.Longitude,
+			Count:         1,
+			DestinationID: &dest.ID,
+			Name:          &dest.Name,
+			Slug:          dest.Slug,
+		})
+	}
+	return result, nil
+}
+
+// mapCellDegrees is the cluster cell size at zoom: a web-map tile spans
+// 360/2^zoom degrees of longitude.
+func mapCellDegrees(zoom int) float64 {
+	return 360 / math.Exp2(float64(zoom)) / mapCellsPerTile
+}
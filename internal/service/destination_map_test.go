@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationService_Map(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	create := func(name string, lat, lng float64) {
+		destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+			Name:      strPtr(name),
+			Latitude:  &lat,
+			Longitude: &lng,
+		}, admin, domain.DestinationStatusPublished, nil)
+	}
+	create("Grand Palace", 13.7500, 100.4913)
+	create("Wat Pho", 13.7465, 100.4930)
+	create("Wat Arun", 13.7437, 100.4889)
+	create("Chatuchak Market", 13.7999, 100.5502)
+	create("Warorot Market", 18.7903, 98.9997)
+	create("Taveuni", -16.8, 179.9)
+	destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Somewhere")}, admin, domain.DestinationStatusPublished, nil)
+
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{})
+	thailand := &domain.DestinationBoundingBox{MinLat: 5, MinLng: 97, MaxLat: 21, MaxLng: 106}
+
+	if _, err := svc.Map(ctx, domain.DestinationListFilter{}, 5); !errors.Is(err, ErrInvalidMapQuery) {
+		t.Fatalf("expected bbox to be required, got %v", err)
+	}
+	if _, err := svc.Map(ctx, domain.DestinationListFilter{BBox: thailand}, MaxMapZoom+1); !errors.Is(err, ErrInvalidMapQuery) {
+		t.Fatalf("expected zoom to be bounded, got %v", err)
+	}
+
+	country, err := svc.Map(ctx, domain.DestinationListFilter{BBox: thailand}, 5)
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	if !country.Clustered || len(country.Markers) != 2 {
+		t.Fatalf("expected Bangkok and Chiang Mai clusters, got %+v", country.Markers)
+	}
+	bangkok, chiangMai := country.Markers[0], country.Markers[1]
+	if bangkok.Count != 4 || bangkok.DestinationID != nil {
+		t.Fatalf("expected four destinations around Bangkok, got %+v", bangkok)
+	}
+	if bangkok.Latitude < 13.74 || bangkok.Latitude > 13.77 {
+		t.Fatalf("expected centroid latitude near Bangkok, got %v", bangkok.Latitude)
+	}
+	if chiangMai.Count != 1 || chiangMai.Name == nil || *chiangMai.Name != "Warorot Market" {
+		t.Fatalf("expected single-destination cluster to name it, got %+v", chiangMai)
+	}
+
+	city, err := svc.Map(ctx, domain.DestinationListFilter{BBox: &domain.DestinationBoundingBox{MinLat: 13.7, MinLng: 100.45, MaxLat: 13.76, MaxLng: 100.5}}, 15)
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	if city.Clustered || len(city.Markers) != 3 {
+		t.Fatalf("expected three individual points, got %+v", city.Markers)
+	}
+
+	pacific, err := svc.Map(ctx, domain.DestinationListFilter{BBox: &domain.DestinationBoundingBox{MinLat: -20, MinLng: 170, MaxLat: -10, MaxLng: -170}}, 4)
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	if len(pacific.Markers) != 1 || pacific.Markers[0].Count != 1 {
+		t.Fatalf("expected antimeridian box to find Taveuni, got %+v", pacific.Markers)
+	}
+}
+
+func TestDestinationService_EachPublishedPagesThroughMatches(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr(name), Category: strPtr("Park")}, admin, domain.DestinationStatusPublished, nil)
+	}
+	destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("F"), Category: strPtr("Museum")}, admin, domain.DestinationStatusPublished, nil)
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{})
+
+	var names []string
+	err := svc.EachPublished(ctx, domain.DestinationListFilter{Categories: []string{"park"}, Sort: domain.DestinationSortNameAsc}, 2, func(dest *domain.Destination) error {
+		names = append(names, dest.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachPublished: %v", err)
+	}
+	if len(names) != 5 || names[0] != "A" || names[4] != "E" {
+		t.Fatalf("expected every park once, got %v", names)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = svc.EachPublished(ctx, domain.DestinationListFilter{}, 2, func(*domain.Destination) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("expected to stop at the first error, got %v after %d calls", err, calls)
+	}
+}
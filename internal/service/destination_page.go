@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// DestinationPage is one page of published destinations.
+type DestinationPage struct {
+	Destinations []domain.Destination
+	// NextCursor continues the listing after the last destination; it is
+	// empty on the last page.
+	NextCursor string
+	// Total counts all matches. Pages fetched by cursor skip the count and
+	// leave it nil.
+	Total *int
+}
+
+// ListPublishedPage lists published destinations by offset or, when cursor
+// is set, by keyset after the destination the cursor was issued for. Keyset
+// pages stay stable while ratings and other sort keys change between
+// requests. Both modes return the cursor for the following page.
+func (s *DestinationService) ListPublishedPage(ctx context.Context, limit, offset int, cursor string, filter domain.DestinationListFilter) (*DestinationPage, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	filter, err := s.prepareFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != "" {
+		after, err := DecodeDestinationCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.Sort != filter.Sort {
+			return nil, fmt.Errorf("%w: cursor was issued for sort %s", ErrInvalidCursor, after.Sort)
+		}
+		filter.After = after
+		offset = 0
+	}
+
+	// One extra row tells whether another page follows.
+	destinations, err := s.destinations.ListPublished(ctx, limit+1, offset, filter)
+	if err != nil {
+		return nil, err
+	}
+	page := &DestinationPage{Destinations: destinations}
+	if len(destinations) > limit {
+		page.Destinations = destinations[:limit]
+		page.NextCursor = EncodeDestinationCursor(domain.CursorAfter(filter.Sort, page.Destinations[limit-1]))
+	}
+	if filter.After == nil {
+		total := 0
+		if len(destinations) > 0 {
+			total = destinations[0].TotalCount
+		}
+		page.Total = &total
+	}
+	return page, nil
+}
+
+// EncodeDestinationCursor renders cursor as the opaque token clients pass
+// back.
+func EncodeDestinationCursor(cursor domain.DestinationListCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeDestinationCursor(token string) (*domain.DestinationListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+	var cursor domain.DestinationListCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidCursor)
+	}
+	if !cursor.Sort.IsValid() {
+		return nil, fmt.Errorf("%w: unknown sort", ErrInvalidCursor)
+	}
+	return &cursor, nil
+}
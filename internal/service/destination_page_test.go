@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationService_ListPublishedPageByCursor(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 20, 9, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	ids := make(map[string]uuid.UUID)
+	for name, rating := range map[string]float64{"Ayutthaya": 4.8, "Bang Krachao": 4.5, "Chinatown": 4.5, "Damnoen Saduak": 3.9, "Erawan Falls": 3.1} {
+		dest := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr(name)}, admin, domain.DestinationStatusPublished, nil)
+		destRepo.store[dest.ID].AverageRating = rating
+		ids[name] = dest.ID
+	}
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{})
+	filter := domain.DestinationListFilter{Sort: domain.DestinationSortRatingDesc}
+
+	first, err := svc.ListPublishedPage(ctx, 2, 0, "", filter)
+	if err != nil {
+		t.Fatalf("ListPublishedPage: %v", err)
+	}
+	if first.Total == nil || *first.Total != 5 || first.NextCursor == "" {
+		t.Fatalf("expected total and next cursor on the first page, got %+v", first)
+	}
+	if names := destinationNames(first.Destinations); names[0] != "Ayutthaya" || names[1] != "Bang Krachao" {
+		t.Fatalf("unexpected first page %v", names)
+	}
+
+	// A row already seen jumps in rating and a row not yet seen drops; the
+	// keyset continues after Bang Krachao regardless.
+	destRepo.store[ids["Ayutthaya"]].AverageRating = 2.0
+	destRepo.store[ids["Damnoen Saduak"]].AverageRating = 4.9
+
+	second, err := svc.ListPublishedPage(ctx, 2, 0, first.NextCursor, filter)
+	if err != nil {
+		t.Fatalf("ListPublishedPage second: %v", err)
+	}
+	if second.Total != nil {
+		t.Fatalf("expected cursor pages to skip the total, got %d", *second.Total)
+	}
+	if names := destinationNames(second.Destinations); len(names) != 2 || names[0] != "Chinatown" || names[1] != "Erawan Falls" {
+		t.Fatalf("unexpected second page %v", names)
+	}
+	third, err := svc.ListPublishedPage(ctx, 2, 0, second.NextCursor, filter)
+	if err != nil {
+		t.Fatalf("ListPublishedPage third: %v", err)
+	}
+	if names := destinationNames(third.Destinations); len(names) != 1 || names[0] != "Ayutthaya" || third.NextCursor != "" {
+		t.Fatalf("expected the demoted row on the last page, got %v (next %q)", names, third.NextCursor)
+	}
+
+	byName := domain.DestinationListFilter{Sort: domain.DestinationSortNameAsc}
+	if _, err := svc.ListPublishedPage(ctx, 2, 0, first.NextCursor, byName); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected cursor from another sort to be rejected, got %v", err)
+	}
+	if _, err := svc.ListPublishedPage(ctx, 2, 0, "not-a-cursor", filter); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected malformed cursor to be rejected, got %v", err)
+	}
+
+	// Offset pages hand out a cursor too, so clients can switch modes.
+	offsetPage, err := svc.ListPublishedPage(ctx, 2, 2, "", byName)
+	if err != nil {
+		t.Fatalf("ListPublishedPage offset: %v", err)
+	}
+	next, err := svc.ListPublishedPage(ctx, 2, 0, offsetPage.NextCursor, byName)
+	if err != nil {
+		t.Fatalf("ListPublishedPage after offset: %v", err)
+	}
+	if names := destinationNames(next.Destinations); len(names) != 1 || names[0] != "Erawan Falls" {
+		t.Fatalf("expected the cursor to continue after the offset page, got %v", names)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+var ErrDestinationLocationMissing = errors.New("destination has no coordinates")
+
+const (
+	earthRadiusKM = 6371.0
+
+	// similarCandidateRadiusKM bounds the proximity part of the candidate
+	// search; similarCandidateLimit caps how many candidates are ranked.
+	similarCandidateRadiusKM = 50.0
+	similarCandidateLimit    = 200
+
+	similarCategoryWeight   = 3.0
+	similarTagWeight        = 1.0
+	similarMaxSharedTags    = 5
+	similarRatingWeight     = 1.0
+	similarProximityWeight  = 2.0
+	similarProximityScaleKM = 10.0
+)
+
+// SimilarDestination is a recommendation with the score it was ranked by.
+type SimilarDestination struct {
+	Destination domain.Destination
+	Score       float64
+}
+
+// Nearby lists published destinations within radiusKM of dest, closest
+// first. dest itself is never included.
+func (s *DestinationService) Nearby(ctx context.Context, dest *domain.Destination, radiusKM float64, limit int) ([]domain.Destination, error) {
+	if dest.Latitude == nil || dest.Longitude == nil {
+		return nil, ErrDestinationLocationMissing
+	}
+	filter := domain.DestinationListFilter{
+		Latitude:      dest.Latitude,
+		Longitude:     dest.Longitude,
+		MaxDistanceKM: &radiusKM,
+		Sort:          domain.DestinationSortDistanceAsc,
+	}
+	found, err := s.destinations.ListPublished(ctx, limit+1, 0, filter)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.Destination, 0, len(found))
+	for _, candidate := range found {
+		if candidate.ID == dest.ID {
+			continue
+		}
+		if candidate.DistanceKM == nil && candidate.Latitude != nil && candidate.Longitude != nil {
+			distance := haversineKM(*dest.Latitude, *dest.Longitude, *candidate.Latitude, *candidate.Longitude)
+			candidate.DistanceKM = &distance
+		}
+		out = append(out, candidate)
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// Similar ranks published destinations by how much they have in common with
+// dest: a shared category, shared tags, their rating and how close they are.
+func (s *DestinationService) Similar(ctx context.Context, dest *domain.Destination, limit int) ([]SimilarDestination, error) {
+	candidates, err := s.destinations.ListSimilarCandidates(ctx, dest, similarCandidateRadiusKM, similarCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	ranked := make([]SimilarDestination, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.ID == dest.ID {
+			continue
+		}
+		if dest.Latitude != nil && dest.Longitude != nil && candidate.Latitude != nil && candidate.Longitude != nil {
+			distance := haversineKM(*dest.Latitude, *dest.Longitude, *candidate.Latitude, *candidate.Longitude)
+			candidate.DistanceKM = &distance
+		}
+		ranked = append(ranked, SimilarDestination{Destination: candidate, Score: similarityScore(dest, &candidate)})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return strings.ToLower(ranked[i].Destination.Name) < strings.ToLower(ranked[j].Destination.Name)
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked, nil
+}
+
+// similarityScore weighs a shared category, up to similarMaxSharedTags shared
+// tags, the candidate's average rating and a proximity bonus that halves at
+// similarProximityScaleKM.
+func similarityScore(base, candidate *domain.Destination) float64 {
+	score := 0.0
+	if base.Category != nil && candidate.Category != nil && strings.EqualFold(strings.TrimSpace(*base.Category), strings.TrimSpace(*candidate.Category)) {
+		score += similarCategoryWeight
+	}
+
+	shared := 0
+	tags := make(map[string]struct{}, len(base.Tags))
+	for _, tag := range base.Tags {
+		tags[tag] = struct{}{}
+	}
+	for _, tag := range candidate.Tags {
+		if _, ok := tags[tag]; ok {
+			shared++
+		}
+	}
+	score += similarTagWeight * float64(min(shared, similarMaxSharedTags))
+
+	score += similarRatingWeight * math.Max(0, math.Min(candidate.AverageRating, 5)) / 5
+
+	if candidate.DistanceKM != nil {
+		score += similarProximityWeight / (1 + *candidate.DistanceKM/similarProximityScaleKM)
+	}
+	return score
+}
+
+// haversineKM is the great-circle distance between two coordinates.
+func haversineKM(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationService_NearbyAndSimilar(t *testing.T) {
+	ctx := context.Background()
+	destRepo := newMemoryDestinationRepo(time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC))
+	admin := uuid.New()
+	create := func(name, category string, lat, lng, rating float64, tags ...string) *domain.Destination {
+		list := domain.DestinationTags(tags)
+		dest := destRepo.mustCreate(ctx, domain.DestinationChangeFields{
+			Name:      strPtr(name),
+			Category:  strPtr(category),
+			Latitude:  &lat,
+			Longitude: &lng,
+			Tags:      &list,
+		}, admin, domain.DestinationStatusPublished, nil)
+		destRepo.store[dest.ID].AverageRating = rating
+		return dest
+	}
+	palace := create("Grand Palace", "Temple", 13.7500, 100.4913, 4.5, "history", "dress-code")
+	create("Wat Pho", "Temple", 13.7465, 100.4930, 4, "history")
+	create("Wat Arun", "Temple", 13.7437, 100.4889, 0)
+	create("Chatuchak Market", "Market", 13.7999, 100.5502, 4, "shopping")
+	create("Ayutthaya Historical Park", "Temple", 14.3532, 100.5689, 5, "history", "dress-code")
+	create("Warorot Market", "Market", 18.7903, 98.9997, 5, "shopping")
+	unlocated := destRepo.mustCreate(ctx, domain.DestinationChangeFields{Name: strPtr("Somewhere")}, admin, domain.DestinationStatusPublished, nil)
+
+	svc := NewDestinationService(destRepo, DestinationServiceConfig{})
+
+	nearby, err := svc.Nearby(ctx, palace, 5, 10)
+	if err != nil {
+		t.Fatalf("Nearby: %v", err)
+	}
+	if names := destinationNames(nearby); len(names) != 2 || names[0] != "Wat Pho" || names[1] != "Wat Arun" {
+		t.Fatalf("unexpected nearby order: %v", names)
+	}
+	if nearby[0].DistanceKM == nil || *nearby[0].DistanceKM > 1 {
+		t.Fatalf("expected distance under 1km, got %v", nearby[0].DistanceKM)
+	}
+	wide, err := svc.Nearby(ctx, palace, 20, 10)
+	if err != nil {
+		t.Fatalf("Nearby: %v", err)
+	}
+	if names := destinationNames(wide); len(names) != 3 || names[2] != "Chatuchak Market" {
+		t.Fatalf("expected chatuchak within 20km, got %v", names)
+	}
+	if _, err = svc.Nearby(ctx, unlocated, 5, 10); !errors.Is(err, ErrDestinationLocationMissing) {
+		t.Fatalf("expected missing location error, got %v", err)
+	}
+
+	similar, err := svc.Similar(ctx, palace, 3)
+	if err != nil {
+		t.Fatalf("Similar: %v", err)
+	}
+	names := make([]string, 0, len(similar))
+	for _, item := range similar {
+		names = append(names, item.Destination.Name)
+	}
+	if len(names) != 3 || names[0] != "Wat Pho" || names[1] != "Ayutthaya Historical Park" || names[2] != "Wat Arun" {
+		t.Fatalf("unexpected similar ranking: %v", names)
+	}
+	if similar[0].Score <= similar[1].Score || similar[1].Score <= similar[2].Score {
+		t.Fatalf("expected descending scores, got %+v", similar)
+	}
+}
+
+func TestHaversineKM(t *testing.T) {
+	// Bangkok to Chiang Mai is roughly 580km as the crow flies.
+	if got := haversineKM(13.7563, 100.5018, 18.7883, 98.9853); got < 570 || got > 590 {
+		t.Fatalf("unexpected distance %f", got)
+	}
+	if got := haversineKM(13.75, 100.49, 13.75, 100.49); got != 0 {
+		t.Fatalf("expected zero distance, got %f", got)
+	}
+}
+
+func destinationNames(destinations []domain.Destination) []string {
+	names := make([]string, 0, len(destinations))
+	for _, dest := range destinations {
+		names = append(names, dest.Name)
+	}
+	return names
+}
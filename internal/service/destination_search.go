@@ -0,0 +1,43 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// maxSearchTerms bounds how many terms a query may combine.
+const maxSearchTerms = 16
+
+// parseSearchQuery splits a search query into terms. Text in double quotes is
+// a phrase; a word ending in * is a prefix. Words are lowercased and cut at
+// punctuation, and words joined by punctuation, like chiang-mai, stay a
+// phrase. Everything else is dropped, so the terms are safe to render into a
+// tsquery.
+func parseSearchQuery(raw string) []domain.DestinationSearchTerm {
+	terms := make([]domain.DestinationSearchTerm, 0)
+	add := func(text string, phrase bool) {
+		prefix := !phrase && strings.HasSuffix(text, "*")
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r)
+		})
+		if len(words) == 0 || len(terms) >= maxSearchTerms {
+			return
+		}
+		terms = append(terms, domain.DestinationSearchTerm{Words: words, Prefix: prefix})
+	}
+
+	for idx, part := range strings.Split(raw, `"`) {
+		// Odd parts sit between quotes. An unclosed quote still yields a
+		// phrase for the rest of the query.
+		if idx%2 == 1 {
+			add(part, true)
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			add(field, false)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want []domain.DestinationSearchTerm
+	}{
+		{raw: "  ", want: []domain.DestinationSearchTerm{}},
+		{raw: "Grand Palace", want: []domain.DestinationSearchTerm{
+			{Words: []string{"grand"}},
+			{Words: []string{"palace"}},
+		}},
+		{raw: `"floating market" wat*`, want: []domain.DestinationSearchTerm{
+			{Words: []string{"floating", "market"}},
+			{Words: []string{"wat"}, Prefix: true},
+		}},
+		{raw: `chiang-mai* "night bazaar`, want: []domain.DestinationSearchTerm{
+			{Words: []string{"chiang", "mai"}, Prefix: true},
+			{Words: []string{"night", "bazaar"}},
+		}},
+		{raw: `it's a & b | !c <-> 'd':*`, want: []domain.DestinationSearchTerm{
+			{Words: []string{"it", "s"}},
+			{Words: []string{"a"}},
+			{Words: []string{"b"}},
+			{Words: []string{"c"}},
+			{Words: []string{"d"}, Prefix: true},
+		}},
+		{raw: "วัดพระแก้ว", want: []domain.DestinationSearchTerm{
+			{Words: []string{"วัดพระแก้ว"}},
+		}},
+	}
+	for _, tc := range cases {
+		if got := parseSearchQuery(tc.raw); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("parseSearchQuery(%q) = %+v, want %+v", tc.raw, got, tc.want)
+		}
+	}
+}
+
+func TestDestinationWorkflowService_ReindexesApprovedChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 22, 9, 0, 0, 0, time.UTC)
+	index := &recordingSearchIndex{fail: true}
+	svc := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		SearchIndex: index,
+	})
+	svc.SetClock(func() time.Time { return now })
+	admin := uuid.New()
+	approve := func(input DestinationDraftInput) *domain.Destination {
+		t.Helper()
+		change, err := svc.CreateDraft(ctx, admin, input)
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		_, dest, err := svc.Approve(ctx, change.ID, uuid.New(), "")
+		if err != nil {
+			t.Fatalf("Approve: %v", err)
+		}
+		return dest
+	}
+
+	// Index failures are logged, never fail the approval.
+	dest := approve(DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Wat Pho")},
+	})
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionDelete,
+		DestinationID: &dest.ID,
+	})
+	if len(index.reindexed) != 2 || index.reindexed[0] != dest.ID || index.reindexed[1] != dest.ID {
+		t.Fatalf("expected the create and the delete to reindex %s, got %v", dest.ID, index.reindexed)
+	}
+}
+
+// recordingSearchIndex is a local stand-in for the Elasticsearch index.
+type recordingSearchIndex struct {
+	fail      bool
+	reindexed []uuid.UUID
+}
+
+func (r *recordingSearchIndex) Reindex(_ context.Context, id uuid.UUID) error {
+	r.reindexed = append(r.reindexed, id)
+	if r.fail {
+		return errors.New("index unavailable")
+	}
+	return nil
+}
+
+func (r *recordingSearchIndex) Rebuild(context.Context) (int, error) {
+	return 0, nil
+}
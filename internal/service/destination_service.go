@@ -9,19 +9,86 @@ import (
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
 )
 
+type DestinationServiceConfig struct {
+	DefaultLocale    string
+	SupportedLocales []string
+	// Categories lets category filters include subcategories; optional.
+	Categories ports.DestinationCategoryRepository
+}
+
 type DestinationService struct {
 	destinations ports.DestinationRepository
+	categories   ports.DestinationCategoryRepository
+	locales      localeSet
 }
 
-func NewDestinationService(destRepo ports.DestinationRepository) *DestinationService {
-	return &DestinationService{destinations: destRepo}
+func NewDestinationService(destRepo ports.DestinationRepository, cfg DestinationServiceConfig) *DestinationService {
+	return &DestinationService{
+		destinations: destRepo,
+		categories:   cfg.Categories,
+		locales:      newLocaleSet(cfg.DefaultLocale, cfg.SupportedLocales),
+	}
 }
 
 func (s *DestinationService) ListPublished(ctx context.Context, limit, offset int, filter domain.DestinationListFilter) ([]domain.Destination, error) {
+	filter, err := s.prepareFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.destinations.ListPublished(ctx, limit, offset, filter)
+}
+
+// EachPublished calls fn for every published destination matching filter,
+// loading them batchSize at a time so exports never hold the whole catalogue
+// in memory. Batches are fetched by keyset, so deep exports stay cheap. It
+// stops at the first error fn returns.
+func (s *DestinationService) EachPublished(ctx context.Context, filter domain.DestinationListFilter, batchSize int, fn func(dest *domain.Destination) error) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	filter, err := s.prepareFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+	for {
+		batch, err := s.destinations.ListPublished(ctx, batchSize, 0, filter)
+		if err != nil {
+			return err
+		}
+		for idx := range batch {
+			if err := fn(&batch[idx]); err != nil {
+				return err
+			}
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		after := domain.CursorAfter(filter.Sort, batch[len(batch)-1])
+		filter.After = &after
+	}
+}
+
+// prepareFilter fills in defaults, parses the search query, expands
+// categories to their subcategories and normalizes tags.
+func (s *DestinationService) prepareFilter(ctx context.Context, filter domain.DestinationListFilter) (domain.DestinationListFilter, error) {
 	if !filter.Sort.IsValid() {
 		filter.Sort = domain.DestinationSortUpdatedAtDesc
 	}
-	return s.destinations.ListPublished(ctx, limit, offset, filter)
+	filter.SearchTerms = parseSearchQuery(filter.Search)
+	if len(filter.Categories) > 0 && s.categories != nil {
+		managed, err := s.categories.List(ctx)
+		if err != nil {
+			return filter, err
+		}
+		filter.Categories = expandCategories(managed, filter.Categories)
+	}
+	if len(filter.Tags) > 0 {
+		filter.Tags = normalizeTags(filter.Tags)
+	}
+	if filter.TagMatch != domain.DestinationTagMatchAll {
+		filter.TagMatch = domain.DestinationTagMatchAny
+	}
+	return filter, nil
 }
 
 func (s *DestinationService) GetPublishedByID(ctx context.Context, id uuid.UUID) (*domain.Destination, error) {
@@ -43,6 +110,34 @@ func (s *DestinationService) GetPublishedBySlug(ctx context.Context, slug string
 	return dest, nil
 }
 
+// CatalogVersion reports the version of the published catalogue, which
+// changes whenever any public listing could.
+func (s *DestinationService) CatalogVersion(ctx context.Context) (domain.DestinationCatalogVersion, error) {
+	return s.destinations.CatalogVersion(ctx)
+}
+
+// CacheStats reports the read cache's counters, and false when reads are not
+// cached.
+func (s *DestinationService) CacheStats() (domain.DestinationCacheStats, bool) {
+	cache, ok := s.destinations.(ports.DestinationCache)
+	if !ok {
+		return domain.DestinationCacheStats{}, false
+	}
+	return cache.Stats(), true
+}
+
 func (s *DestinationService) Autocomplete(ctx context.Context, query string, limit int) ([]string, error) {
 	return s.destinations.Autocomplete(ctx, query, limit)
 }
+
+// ResolveLocale picks the content locale for a request from the ?lang= value
+// and the Accept-Language header, falling back to the default locale.
+func (s *DestinationService) ResolveLocale(lang, acceptLanguage string) string {
+	return s.locales.resolve(lang, acceptLanguage)
+}
+
+// Localize returns a copy of dest rendered in locale. Fields without a
+// translation keep their default-locale value.
+func (s *DestinationService) Localize(dest *domain.Destination, locale string) *domain.Destination {
+	return s.locales.localize(dest, locale)
+}
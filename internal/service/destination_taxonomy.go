@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+var (
+	ErrCategoryNotFound   = errors.New("category not found")
+	ErrCategoryValidation = errors.New("category validation failed")
+	ErrCategoryConflict   = errors.New("category conflict")
+)
+
+const (
+	maxDestinationTags   = 20
+	maxDestinationTagLen = 40
+	maxCategoryNameLen   = 80
+)
+
+var (
+	tagAllowed      = regexp.MustCompile(`^[\p{L}\p{M}\p{N}]+(?:-[\p{L}\p{M}\p{N}]+)*$`)
+	tagSeparators   = regexp.MustCompile(`[\s_]+`)
+	slugUnsafeChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type DestinationCategoryInput struct {
+	Name         *string
+	Slug         *string
+	ParentID     *uuid.UUID
+	ClearParent  bool
+	Icon         *string
+	DisplayOrder *int
+}
+
+// DestinationCategoryService manages the category taxonomy destinations are
+// filed under.
+type DestinationCategoryService struct {
+	categories ports.DestinationCategoryRepository
+}
+
+func NewDestinationCategoryService(categories ports.DestinationCategoryRepository) *DestinationCategoryService {
+	return &DestinationCategoryService{categories: categories}
+}
+
+// List returns every category ordered by display order, then name.
+func (s *DestinationCategoryService) List(ctx context.Context) ([]domain.DestinationCategory, error) {
+	return s.categories.List(ctx)
+}
+
+// Tree returns the root categories with their descendants nested in Children.
+func (s *DestinationCategoryService) Tree(ctx context.Context) ([]domain.DestinationCategory, error) {
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+func (s *DestinationCategoryService) Create(ctx context.Context, input DestinationCategoryInput) (*domain.DestinationCategory, error) {
+	category := &domain.DestinationCategory{}
+	if input.Name == nil {
+		return nil, fmt.Errorf("%w: name is required", ErrCategoryValidation)
+	}
+	if err := s.apply(ctx, category, input); err != nil {
+		return nil, err
+	}
+	created, err := s.categories.Create(ctx, category)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: slug %q already exists", ErrCategoryConflict, category.Slug)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *DestinationCategoryService) Update(ctx context.Context, id uuid.UUID, input DestinationCategoryInput) (*domain.DestinationCategory, error) {
+	category, err := s.categories.FindByID(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	if err := s.apply(ctx, category, input); err != nil {
+		return nil, err
+	}
+	updated, err := s.categories.Update(ctx, category)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrCategoryNotFound
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: slug %q already exists", ErrCategoryConflict, category.Slug)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete removes a category that has no children. Destinations keep their
+// category text; it simply stops being offered.
+func (s *DestinationCategoryService) Delete(ctx context.Context, id uuid.UUID) error {
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, category := range categories {
+		if category.ID == id {
+			found = true
+		}
+		if category.ParentID != nil && *category.ParentID == id {
+			return fmt.Errorf("%w: category has subcategories", ErrCategoryConflict)
+		}
+	}
+	if !found {
+		return ErrCategoryNotFound
+	}
+	return s.categories.Delete(ctx, id)
+}
+
+func (s *DestinationCategoryService) apply(ctx context.Context, category *domain.DestinationCategory, input DestinationCategoryInput) error {
+	var problems []string
+	if input.Name != nil {
+		category.Name = strings.TrimSpace(*input.Name)
+	}
+	switch {
+	case category.Name == "":
+		problems = append(problems, "name is required")
+	case utf8.RuneCountInString(category.Name) > maxCategoryNameLen:
+		problems = append(problems, fmt.Sprintf("name must be at most %d characters", maxCategoryNameLen))
+	}
+
+	if input.Slug != nil {
+		category.Slug = strings.TrimSpace(*input.Slug)
+	}
+	if category.Slug == "" {
+		category.Slug = categorySlug(category.Name)
+	}
+	if !slugAllowed.MatchString(category.Slug) {
+		problems = append(problems, "slug must contain lowercase letters, numbers, and hyphens only")
+	}
+
+	if input.Icon != nil {
+		category.Icon = nil
+		if icon := strings.TrimSpace(*input.Icon); icon != "" {
+			category.Icon = &icon
+		}
+	}
+	if input.DisplayOrder != nil {
+		category.DisplayOrder = *input.DisplayOrder
+	}
+
+	if input.ClearParent {
+		category.ParentID = nil
+	} else if input.ParentID != nil {
+		parentID := *input.ParentID
+		if err := s.checkParent(ctx, category.ID, parentID); err != nil {
+			return err
+		}
+		category.ParentID = &parentID
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrCategoryValidation, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkParent rejects unknown parents and parents that would create a cycle.
+func (s *DestinationCategoryService) checkParent(ctx context.Context, id, parentID uuid.UUID) error {
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]domain.DestinationCategory, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+	if _, ok := byID[parentID]; !ok {
+		return fmt.Errorf("%w: parent category not found", ErrCategoryValidation)
+	}
+	if id == uuid.Nil {
+		return nil
+	}
+	for current := &parentID; current != nil; {
+		if *current == id {
+			return fmt.Errorf("%w: a category cannot be nested under itself or its subcategories", ErrCategoryValidation)
+		}
+		current = byID[*current].ParentID
+	}
+	return nil
+}
+
+func buildCategoryTree(categories []domain.DestinationCategory) []domain.DestinationCategory {
+	children := make(map[uuid.UUID][]domain.DestinationCategory)
+	known := make(map[uuid.UUID]struct{}, len(categories))
+	for _, category := range categories {
+		known[category.ID] = struct{}{}
+	}
+	var roots []domain.DestinationCategory
+	for _, category := range categories {
+		if category.ParentID != nil {
+			if _, ok := known[*category.ParentID]; ok {
+				children[*category.ParentID] = append(children[*category.ParentID], category)
+				continue
+			}
+		}
+		roots = append(roots, category)
+	}
+	var attach func(nodes []domain.DestinationCategory) []domain.DestinationCategory
+	attach = func(nodes []domain.DestinationCategory) []domain.DestinationCategory {
+		sortCategories(nodes)
+		for idx := range nodes {
+			if kids := children[nodes[idx].ID]; len(kids) > 0 {
+				nodes[idx].Children = attach(kids)
+			}
+		}
+		return nodes
+	}
+	return attach(roots)
+}
+
+func sortCategories(categories []domain.DestinationCategory) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].DisplayOrder != categories[j].DisplayOrder {
+			return categories[i].DisplayOrder < categories[j].DisplayOrder
+		}
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
+}
+
+func categorySlug(name string) string {
+	return strings.Trim(slugUnsafeChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// findCategory matches a destination's category text against the taxonomy by
+// name or slug, ignoring case.
+func findCategory(categories []domain.DestinationCategory, value string) (domain.DestinationCategory, bool) {
+	value = strings.TrimSpace(value)
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, value) || strings.EqualFold(category.Slug, value) {
+			return category, true
+		}
+	}
+	return domain.DestinationCategory{}, false
+}
+
+// expandCategories adds the names and slugs of every subcategory of the
+// requested categories, so filtering by a parent also returns its children.
+// Values that are not managed categories are kept as given.
+func expandCategories(categories []domain.DestinationCategory, values []string) []string {
+	children := make(map[uuid.UUID][]domain.DestinationCategory)
+	for _, category := range categories {
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+	seen := make(map[string]struct{})
+	out := make([]string, 0, len(values))
+	add := func(value string) {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key == "" {
+			return
+		}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		out = append(out, value)
+	}
+	visited := make(map[uuid.UUID]struct{})
+	var walk func(category domain.DestinationCategory)
+	walk = func(category domain.DestinationCategory) {
+		if _, ok := visited[category.ID]; ok {
+			return
+		}
+		visited[category.ID] = struct{}{}
+		add(category.Name)
+		add(category.Slug)
+		for _, child := range children[category.ID] {
+			walk(child)
+		}
+	}
+	for _, value := range values {
+		add(value)
+		if category, ok := findCategory(categories, value); ok {
+			walk(category)
+		}
+	}
+	return out
+}
+
+// normalizeTags lowercases tags, turns spaces and underscores into hyphens and
+// drops blanks and duplicates while keeping the given order.
+func normalizeTags(tags []string) domain.DestinationTags {
+	out := make(domain.DestinationTags, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, raw := range tags {
+		tag := strings.Trim(tagSeparators.ReplaceAllString(strings.ToLower(strings.TrimSpace(raw)), "-"), "-")
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		out = append(out, tag)
+	}
+	return out
+}
+
+func normalizeTagsPtr(tags *domain.DestinationTags) *domain.DestinationTags {
+	if tags == nil {
+		return nil
+	}
+	normalized := normalizeTags(*tags)
+	return &normalized
+}
+
+func validateTags(tags domain.DestinationTags) []string {
+	var problems []string
+	if len(tags) > maxDestinationTags {
+		problems = append(problems, fmt.Sprintf("at most %d tags are allowed", maxDestinationTags))
+	}
+	for _, tag := range tags {
+		if !tagAllowed.MatchString(tag) || utf8.RuneCountInString(tag) > maxDestinationTagLen {
+			problems = append(problems, fmt.Sprintf("tag %q must be at most %d letters, numbers or hyphens", tag, maxDestinationTagLen))
+		}
+	}
+	return problems
+}
+
+func cloneTags(src domain.DestinationTags) domain.DestinationTags {
+	if len(src) == 0 {
+		return nil
+	}
+	return append(domain.DestinationTags(nil), src...)
+}
+
+// tagsOrEmpty returns a copy of tags, or an empty list so that an update built
+// from a snapshot clears them.
+func tagsOrEmpty(src domain.DestinationTags) *domain.DestinationTags {
+	out := cloneTags(src)
+	if out == nil {
+		out = domain.DestinationTags{}
+	}
+	return &out
+}
+
+// sameTags compares tag sets, ignoring order.
+func sameTags(a, b domain.DestinationTags) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	left := append([]string(nil), a...)
+	right := append([]string(nil), b...)
+	sort.Strings(left)
+	sort.Strings(right)
+	for idx := range left {
+		if left[idx] != right[idx] {
+			return false
+		}
+	}
+	return true
+}
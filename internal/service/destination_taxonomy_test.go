@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+func TestDestinationCategoryService(t *testing.T) {
+	ctx := context.Background()
+	svc := NewDestinationCategoryService(newMemoryCategoryRepo())
+
+	nature, err := svc.Create(ctx, DestinationCategoryInput{Name: strPtr("Nature & Parks"), Icon: strPtr("tree")})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if nature.Slug != "nature-parks" {
+		t.Fatalf("expected generated slug, got %q", nature.Slug)
+	}
+	order := -1
+	waterfalls, err := svc.Create(ctx, DestinationCategoryInput{Name: strPtr("Waterfalls"), ParentID: &nature.ID, DisplayOrder: &order})
+	if err != nil {
+		t.Fatalf("Create child: %v", err)
+	}
+	if _, err = svc.Create(ctx, DestinationCategoryInput{Name: strPtr("Museums")}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err = svc.Create(ctx, DestinationCategoryInput{Name: strPtr("Nature Parks"), Slug: strPtr("nature-parks")}); !errors.Is(err, ErrCategoryConflict) {
+		t.Fatalf("expected slug conflict, got %v", err)
+	}
+	if _, err = svc.Update(ctx, nature.ID, DestinationCategoryInput{ParentID: &waterfalls.ID}); !errors.Is(err, ErrCategoryValidation) {
+		t.Fatalf("expected cycle to be rejected, got %v", err)
+	}
+	missing := uuid.New()
+	if _, err = svc.Create(ctx, DestinationCategoryInput{Name: strPtr("Orphan"), ParentID: &missing}); !errors.Is(err, ErrCategoryValidation) {
+		t.Fatalf("expected unknown parent to be rejected, got %v", err)
+	}
+
+	tree, err := svc.Tree(ctx)
+	if err != nil {
+		t.Fatalf("Tree: %v", err)
+	}
+	if len(tree) != 2 || tree[0].Name != "Museums" || tree[1].Name != "Nature & Parks" {
+		t.Fatalf("unexpected roots: %+v", tree)
+	}
+	if len(tree[1].Children) != 1 || tree[1].Children[0].ID != waterfalls.ID {
+		t.Fatalf("expected waterfalls nested under nature, got %+v", tree[1].Children)
+	}
+
+	if err = svc.Delete(ctx, nature.ID); !errors.Is(err, ErrCategoryConflict) {
+		t.Fatalf("expected delete with children to conflict, got %v", err)
+	}
+	moved, err := svc.Update(ctx, waterfalls.ID, DestinationCategoryInput{ClearParent: true})
+	if err != nil || moved.ParentID != nil {
+		t.Fatalf("expected waterfalls moved to root, got %+v, %v", moved, err)
+	}
+	if err = svc.Delete(ctx, nature.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err = svc.Update(ctx, nature.ID, DestinationCategoryInput{Name: strPtr("Nature")}); !errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected ErrCategoryNotFound for a deleted category, got %v", err)
+	}
+
+	failing := NewDestinationCategoryService(failingCategoryRepo{newMemoryCategoryRepo()})
+	if _, err = failing.Update(ctx, waterfalls.ID, DestinationCategoryInput{Name: strPtr("Falls")}); err == nil || errors.Is(err, ErrCategoryNotFound) {
+		t.Fatalf("expected the lookup failure passed through, got %v", err)
+	}
+}
+
+// failingCategoryRepo fails lookups the way an unreachable database does.
+type failingCategoryRepo struct {
+	*memoryCategoryRepo
+}
+
+func (failingCategoryRepo) FindByID(context.Context, uuid.UUID) (*domain.DestinationCategory, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestDestinationWorkflowService_CategoriesAndTags(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 15, 9, 0, 0, 0, time.UTC)
+	categoryRepo := newMemoryCategoryRepo()
+	categories := NewDestinationCategoryService(categoryRepo)
+	nature, err := categories.Create(ctx, DestinationCategoryInput{Name: strPtr("Nature")})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err = categories.Create(ctx, DestinationCategoryInput{Name: strPtr("Waterfalls"), ParentID: &nature.ID}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	destRepo := newMemoryDestinationRepo(now)
+	svc := NewDestinationWorkflowService(destRepo, newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		AllowedCategories: []string{"Beach"},
+		Categories:        categoryRepo,
+	})
+	svc.SetClock(func() time.Time { return now })
+	admin := uuid.New()
+
+	_, err = svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Patong"), Category: strPtr("Beach")},
+	})
+	if !errors.Is(err, ErrDestinationChangeValidation) {
+		t.Fatalf("expected managed categories to replace the allow-list, got %v", err)
+	}
+	badTags := domain.DestinationTags{"ok", "no/slashes"}
+	_, err = svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Erawan"), Tags: &badTags},
+	})
+	if !errors.Is(err, ErrDestinationChangeValidation) {
+		t.Fatalf("expected invalid tag to be rejected, got %v", err)
+	}
+
+	publish := func(name, category string, tags ...string) *domain.Destination {
+		t.Helper()
+		list := domain.DestinationTags(tags)
+		change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+			Action: domain.DestinationChangeActionCreate,
+			Fields: domain.DestinationChangeFields{Name: strPtr(name), Category: strPtr(category), Tags: &list},
+		})
+		if err != nil {
+			t.Fatalf("CreateDraft %s: %v", name, err)
+		}
+		if _, err = svc.SubmitDraft(ctx, change.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft %s: %v", name, err)
+		}
+		_, dest, err := svc.Approve(ctx, change.ID, uuid.New(), "")
+		if err != nil {
+			t.Fatalf("Approve %s: %v", name, err)
+		}
+		return dest
+	}
+	erawan := publish("Erawan Falls", "waterfalls", "Parking", " Wheelchair Access", "parking", "showers")
+	publish("Khao Yai", "Nature", "parking")
+
+	if got := []string(erawan.Tags); len(got) != 3 || got[0] != "parking" || got[1] != "wheelchair-access" || got[2] != "showers" {
+		t.Fatalf("expected normalized tags, got %v", got)
+	}
+
+	public := NewDestinationService(destRepo, DestinationServiceConfig{Categories: categoryRepo})
+	cases := []struct {
+		name   string
+		filter domain.DestinationListFilter
+		want   int
+	}{
+		{"parent category includes children", domain.DestinationListFilter{Categories: []string{"nature"}}, 2},
+		{"child category only", domain.DestinationListFilter{Categories: []string{"Waterfalls"}}, 1},
+		{"any tag", domain.DestinationListFilter{Tags: []string{"Showers", "parking"}}, 2},
+		{"all tags", domain.DestinationListFilter{Tags: []string{"showers", "parking"}, TagMatch: domain.DestinationTagMatchAll}, 1},
+		{"unknown tag", domain.DestinationListFilter{Tags: []string{"sauna"}}, 0},
+	}
+	for _, tc := range cases {
+		found, err := public.ListPublished(ctx, 10, 0, tc.filter)
+		if err != nil {
+			t.Fatalf("%s: ListPublished: %v", tc.name, err)
+		}
+		if len(found) != tc.want {
+			t.Errorf("%s: expected %d results, got %d", tc.name, tc.want, len(found))
+		}
+	}
+
+	removeTags := domain.DestinationTags{}
+	change, err := svc.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &erawan.ID,
+		Fields:        domain.DestinationChangeFields{Tags: &removeTags},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft update: %v", err)
+	}
+	diff, err := svc.DiffChange(ctx, change.ID)
+	if err != nil {
+		t.Fatalf("DiffChange: %v", err)
+	}
+	if !diffHasField(diff, "tags") {
+		t.Fatalf("expected tags in diff, got %+v", diff.Fields)
+	}
+}
+
+type memoryCategoryRepo struct {
+	mu    sync.Mutex
+	store map[uuid.UUID]domain.DestinationCategory
+}
+
+func newMemoryCategoryRepo() *memoryCategoryRepo {
+	return &memoryCategoryRepo{store: make(map[uuid.UUID]domain.DestinationCategory)}
+}
+
+func (m *memoryCategoryRepo) Create(ctx context.Context, category *domain.DestinationCategory) (*domain.DestinationCategory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, existing := range m.store {
+		if existing.Slug == category.Slug {
+			return nil, &pgconn.PgError{Code: "23505"}
+		}
+	}
+	created := *category
+	created.ID = uuid.New()
+	created.CreatedAt = time.Now()
+	created.UpdatedAt = created.CreatedAt
+	m.store[created.ID] = created
+	return &created, nil
+}
+
+func (m *memoryCategoryRepo) Update(ctx context.Context, category *domain.DestinationCategory) (*domain.DestinationCategory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.store[category.ID]; !ok {
+		return nil, sql.ErrNoRows
+	}
+	for id, existing := range m.store {
+		if id != category.ID && existing.Slug == category.Slug {
+			return nil, &pgconn.PgError{Code: "23505"}
+		}
+	}
+	updated := *category
+	updated.UpdatedAt = time.Now()
+	m.store[updated.ID] = updated
+	return &updated, nil
+}
+
+func (m *memoryCategoryRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.store[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(m.store, id)
+	return nil
+}
+
+func (m *memoryCategoryRepo) FindByID(ctx context.Context, id uuid.UUID) (*domain.DestinationCategory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	category, ok := m.store[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &category, nil
+}
+
+func (m *memoryCategoryRepo) List(ctx context.Context) ([]domain.DestinationCategory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.DestinationCategory, 0, len(m.store))
+	for _, category := range m.store {
+		out = append(out, category)
+	}
+	sortCategories(out)
+	return out, nil
+}
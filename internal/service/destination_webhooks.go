@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+// destinationWebhookData is the data of destination events. Destination is
+// omitted for hard deletes.
+type destinationWebhookData struct {
+	DestinationID   uuid.UUID                      `json:"destination_id"`
+	ChangeRequestID uuid.UUID                      `json:"change_request_id"`
+	Action          domain.DestinationChangeAction `json:"action"`
+	Destination     *webhookDestination            `json:"destination,omitempty"`
+}
+
+// webhookDestination is the destination partners receive: the fields the
+// public API shows, without who edited it or other internal state.
+type webhookDestination struct {
+	ID            uuid.UUID                      `json:"id"`
+	Name          string                         `json:"name"`
+	Slug          *string                        `json:"slug,omitempty"`
+	Status        domain.DestinationStatus       `json:"status"`
+	Version       int64                          `json:"version"`
+	City          *string                        `json:"city,omitempty"`
+	Country       *string                        `json:"country,omitempty"`
+	Category      *string                        `json:"category,omitempty"`
+	Description   *string                        `json:"description,omitempty"`
+	Latitude      *float64                       `json:"latitude,omitempty"`
+	Longitude     *float64                       `json:"longitude,omitempty"`
+	Contact       *string                        `json:"contact,omitempty"`
+	OpeningTime   *string                        `json:"opening_time,omitempty"`
+	ClosingTime   *string                        `json:"closing_time,omitempty"`
+	Timezone      *string                        `json:"timezone,omitempty"`
+	OpeningHours  *domain.OpeningHours           `json:"opening_hours,omitempty"`
+	Translations  domain.DestinationTranslations `json:"translations,omitempty"`
+	Tags          domain.DestinationTags         `json:"tags,omitempty"`
+	Gallery       domain.DestinationGallery      `json:"gallery,omitempty"`
+	HeroImage     *string                        `json:"hero_image_url,omitempty"`
+	AverageRating float64                        `json:"average_rating"`
+	ReviewCount   int                            `json:"review_count"`
+	CreatedAt     time.Time                      `json:"created_at"`
+	UpdatedAt     time.Time                      `json:"updated_at"`
+}
+
+func newWebhookDestination(dest *domain.Destination) *webhookDestination {
+	if dest == nil {
+		return nil
+	}
+	out := &webhookDestination{
+		ID:            dest.ID,
+		Name:          dest.Name,
+		Slug:          dest.Slug,
+		Status:        dest.Status,
+		Version:       dest.Version,
+		City:          dest.City,
+		Country:       dest.Country,
+		Category:      dest.Category,
+		Description:   dest.Description,
+		Latitude:      dest.Latitude,
+		Longitude:     dest.Longitude,
+		Contact:       dest.Contact,
+		OpeningTime:   dest.OpeningTime,
+		ClosingTime:   dest.ClosingTime,
+		Timezone:      dest.Timezone,
+		Translations:  dest.Translations,
+		Tags:          dest.Tags,
+		Gallery:       dest.Gallery,
+		HeroImage:     dest.HeroImage,
+		AverageRating: dest.AverageRating,
+		ReviewCount:   dest.ReviewCount,
+		CreatedAt:     dest.CreatedAt,
+		UpdatedAt:     dest.UpdatedAt,
+	}
+	if dest.OpeningHours != nil && !dest.OpeningHours.IsEmpty() {
+		out.OpeningHours = dest.OpeningHours
+	}
+	return out
+}
+
+// reviewWebhookData is the data of review events.
+type reviewWebhookData struct {
+	DestinationID uuid.UUID               `json:"destination_id"`
+	Review        *domain.Review          `json:"review"`
+	Aggregate     *domain.ReviewAggregate `json:"aggregate,omitempty"`
+}
+
+// webhookSubject loads the destination a change edits before it is applied,
+// so the event can tell how its visibility moved. It is nil for creates and
+// when no webhooks are configured.
+func (s *DestinationWorkflowService) webhookSubject(ctx context.Context, change *domain.DestinationChangeRequest) *domain.Destination {
+	if s.webhooks == nil || change.DestinationID == nil {
+		return nil
+	}
+	dest, err := s.destinations.FindByID(ctx, *change.DestinationID)
+	if err != nil {
+		return nil
+	}
+	return dest
+}
+
+// publishChange queues the webhook event for an applied change. It runs in
+// the approval's transaction, so the event is queued exactly when the change
+// commits; a failure to queue it fails the approval.
+func (s *DestinationWorkflowService) publishChange(ctx context.Context, change *domain.DestinationChangeRequest, before, after *domain.Destination) error {
+	if s.webhooks == nil {
+		return nil
+	}
+	event, ok := destinationWebhookEvent(before, after)
+	if !ok {
+		return nil
+	}
+	data := destinationWebhookData{ChangeRequestID: change.ID, Action: change.Action, Destination: newWebhookDestination(after)}
+	switch {
+	case after != nil:
+		data.DestinationID = after.ID
+	case change.DestinationID != nil:
+		data.DestinationID = *change.DestinationID
+	}
+	if err := s.webhooks.Publish(ctx, event, data); err != nil {
+		return fmt.Errorf("queue %s webhook: %w", event, err)
+	}
+	return nil
+}
+
+// destinationWebhookEvent maps a destination's state before and after a
+// change to the event partners see. Changes that neither start nor end
+// published have no event, except hard deletes.
+func destinationWebhookEvent(before, after *domain.Destination) (domain.WebhookEvent, bool) {
+	if after == nil {
+		return domain.WebhookEventDestinationDeleted, true
+	}
+	wasPublished := before != nil && before.IsPublished()
+	switch {
+	case after.IsPublished() && wasPublished:
+		return domain.WebhookEventDestinationUpdated, true
+	case after.IsPublished():
+		return domain.WebhookEventDestinationPublished, true
+	case wasPublished:
+		return domain.WebhookEventDestinationArchived, true
+	default:
+		return "", false
+	}
+}
+
+// publishReview emits a review event. Failures are logged, as the review is
+// already stored.
+func (s *ReviewService) publishReview(ctx context.Context, event domain.WebhookEvent, review *domain.Review, aggregate *domain.ReviewAggregate) {
+	if s.webhooks == nil {
+		return
+	}
+	data := reviewWebhookData{DestinationID: review.DestinationID, Review: review, Aggregate: aggregate}
+	if err := s.webhooks.Publish(ctx, event, data); err != nil {
+		log.Printf("review %s: publish %s webhook: %v", review.ID, event, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"path/filepath"
 	"strings"
 	"time"
@@ -31,6 +32,14 @@ type ReviewServiceConfig struct {
 	ImageProcessor    media.Processor
 	ImageMaxDimension int
 	PublicBaseURL     string
+	// Webhooks, when set, is told about posted and deleted reviews; optional.
+	Webhooks WebhookPublisher
+	// SearchIndex, when set, is refreshed for the reviewed destination, whose
+	// rating and review count its documents hold; optional.
+	SearchIndex ports.DestinationSearchIndex
+	// Cache, when set, is invalidated for the reviewed destination, whose
+	// rating and review count it holds; optional.
+	Cache ports.DestinationCache
 }
 
 type ReviewImageUpload struct {
@@ -62,6 +71,9 @@ type ReviewService struct {
 	now               func() time.Time
 	imageProcessor    media.Processor
 	imageMaxDimension int
+	webhooks          WebhookPublisher
+	searchIndex       ports.DestinationSearchIndex
+	cache             ports.DestinationCache
 }
 
 const (
@@ -118,6 +130,9 @@ func NewReviewService(
 		now:               time.Now,
 		imageProcessor:    cfg.ImageProcessor,
 		imageMaxDimension: maxDimension,
+		webhooks:          cfg.Webhooks,
+		searchIndex:       cfg.SearchIndex,
+		cache:             cfg.Cache,
 	}
 }
 
@@ -182,6 +197,8 @@ func (s *ReviewService) CreateReview(ctx context.Context, userID, destinationID
 		return nil, nil, err
 	}
 
+	s.refreshDestination(ctx, destinationID)
+	s.publishReview(ctx, domain.WebhookEventReviewPosted, review, aggregate)
 	return review, aggregate, nil
 }
 
@@ -248,9 +265,28 @@ func (s *ReviewService) DeleteReview(ctx context.Context, reviewID, requesterID
 		}
 		return err
 	}
+	s.refreshDestination(ctx, review.DestinationID)
+	s.publishReview(ctx, domain.WebhookEventReviewDeleted, review, nil)
 	return nil
 }
 
+// refreshDestination reindexes a destination whose reviews changed, then
+// drops its cached copies, so the cache cannot refill from a stale search
+// document. Failures are logged; the next review, a rebuild or the cache TTL
+// catches up.
+func (s *ReviewService) refreshDestination(ctx context.Context, destinationID uuid.UUID) {
+	if s.searchIndex != nil {
+		if err := s.searchIndex.Reindex(ctx, destinationID); err != nil {
+			log.Printf("review: reindex destination %s: %v", destinationID, err)
+		}
+	}
+	if s.cache != nil {
+		if err := s.cache.Invalidate(ctx, destinationID); err != nil {
+			log.Printf("review: invalidate cached destination %s: %v", destinationID, err)
+		}
+	}
+}
+
 func (s *ReviewService) validateImages(images []ReviewImageUpload) error {
 	if len(images) == 0 {
 		return nil
@@ -241,6 +241,49 @@ func TestReviewService_DeleteReview_Permissions(t *testing.T) {
 	}
 }
 
+func TestReviewService_RefreshesDestinationReads(t *testing.T) {
+	ctx := context.Background()
+	destID := uuid.New()
+	userID := uuid.New()
+
+	repo := newMemoryReviewRepository()
+	destRepo := &reviewDestinationRepo{
+		items: map[uuid.UUID]*domain.Destination{
+			destID: {ID: destID, Status: domain.DestinationStatusPublished},
+		},
+	}
+	index := &recordingSearchIndex{}
+	cache := &recordingDestinationCache{}
+	svc := NewReviewService(repo, newMemoryMediaRepository(), destRepo, &reviewStorage{}, ReviewServiceConfig{SearchIndex: index, Cache: cache})
+
+	review, _, err := svc.CreateReview(ctx, userID, destID, ReviewCreateInput{Rating: 5})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	if err := svc.DeleteReview(ctx, review.ID, userID, false); err != nil {
+		t.Fatalf("DeleteReview: %v", err)
+	}
+	if len(cache.invalidated) != 2 || cache.invalidated[0] != destID || cache.invalidated[1] != destID {
+		t.Fatalf("expected the destination invalidated on create and delete, got %v", cache.invalidated)
+	}
+	if len(index.reindexed) != 2 || index.reindexed[0] != destID || index.reindexed[1] != destID {
+		t.Fatalf("expected the destination reindexed on create and delete, got %v", index.reindexed)
+	}
+}
+
+type recordingDestinationCache struct {
+	invalidated []uuid.UUID
+}
+
+func (c *recordingDestinationCache) Invalidate(ctx context.Context, id uuid.UUID) error {
+	c.invalidated = append(c.invalidated, id)
+	return nil
+}
+
+func (c *recordingDestinationCache) Stats() domain.DestinationCacheStats {
+	return domain.DestinationCacheStats{}
+}
+
 func TestReviewService_UsesPublicBaseForMediaURLs(t *testing.T) {
 	ctx := context.Background()
 	destID := uuid.New()
@@ -498,6 +541,10 @@ func (s *reviewStorage) Upload(_ context.Context, _ string, objectName, _ string
 	return "https://example.com/" + objectName, nil
 }
 
+func (s *reviewStorage) Delete(_ context.Context, _ string, _ string) error {
+	return nil
+}
+
 type reviewDestinationRepo struct {
 	items map[uuid.UUID]*domain.Destination
 }
@@ -514,6 +561,10 @@ func (m *reviewDestinationRepo) Archive(context.Context, uuid.UUID, uuid.UUID) (
 	return nil, errors.New("not implemented")
 }
 
+func (m *reviewDestinationRepo) Restore(context.Context, uuid.UUID, uuid.UUID, domain.DestinationStatus, *string) (*domain.Destination, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (m *reviewDestinationRepo) HardDelete(context.Context, uuid.UUID) error {
 	return errors.New("not implemented")
 }
@@ -534,6 +585,10 @@ func (m *reviewDestinationRepo) FindPublishedByID(_ context.Context, id uuid.UUI
 	return &cloned, nil
 }
 
+func (m *reviewDestinationRepo) FindSlugOwner(context.Context, string) (uuid.UUID, error) {
+	return uuid.Nil, errors.New("not implemented")
+}
+
 func (m *reviewDestinationRepo) FindBySlug(context.Context, string) (*domain.Destination, error) {
 	return nil, errors.New("not implemented")
 }
@@ -542,10 +597,34 @@ func (m *reviewDestinationRepo) ListPublished(context.Context, int, int, domain.
 	return nil, errors.New("not implemented")
 }
 
+func (m *reviewDestinationRepo) ClusterPublished(context.Context, domain.DestinationListFilter, float64, int) ([]domain.DestinationMapCluster, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *reviewDestinationRepo) CountPublishedFacet(context.Context, domain.DestinationListFilter, domain.DestinationFacet, int) ([]domain.DestinationFacetCount, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *reviewDestinationRepo) ListSimilarCandidates(context.Context, *domain.Destination, float64, int) ([]domain.Destination, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *reviewDestinationRepo) CatalogVersion(context.Context) (domain.DestinationCatalogVersion, error) {
+	return domain.DestinationCatalogVersion{}, errors.New("not implemented")
+}
+
 func (m *reviewDestinationRepo) Autocomplete(context.Context, string, int) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
+func (m *reviewDestinationRepo) ListForExport(context.Context, domain.DestinationExportFilter, int) ([]domain.Destination, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *reviewDestinationRepo) ExportLayout(context.Context, domain.DestinationExportFilter) (domain.DestinationExportLayout, error) {
+	return domain.DestinationExportLayout{}, errors.New("not implemented")
+}
+
 var _ ports.ReviewRepository = (*memoryReviewRepository)(nil)
 var _ ports.ReviewMediaRepository = (*memoryMediaRepository)(nil)
 var _ ports.DestinationRepository = (*reviewDestinationRepo)(nil)
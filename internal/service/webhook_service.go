@@ -0,0 +1,466 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/repository/ports"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrWebhookValidation       = errors.New("webhook validation failed")
+)
+
+const (
+	maxWebhookDescriptionLength = 500
+	// maxWebhookErrorLength bounds the response excerpt kept in the delivery
+	// log.
+	maxWebhookErrorLength  = 1000
+	webhookSignaturePrefix = "sha256="
+)
+
+// WebhookPublisher hands events to webhook subscribers. Publish joins the
+// transaction carried by ctx, so an event queued inside one is only delivered
+// once it commits.
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event domain.WebhookEvent, data any) error
+}
+
+type WebhookServiceConfig struct {
+	// MaxAttempts is how often a delivery is tried before it is marked
+	// failed; it can still be replayed afterwards.
+	MaxAttempts int
+	// BaseBackoff doubles after every failed attempt, up to MaxBackoff.
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+	// BatchSize is how many due deliveries one dispatch pass claims.
+	BatchSize  int
+	HTTPClient *http.Client
+}
+
+type WebhookSubscriptionInput struct {
+	URL         string
+	Description *string
+	Events      []domain.WebhookEvent
+	Active      *bool
+	// RotateSecret replaces the signing secret on update.
+	RotateSecret bool
+}
+
+// webhookEnvelope is the body of every delivery. The ID identifies the event,
+// so retries and replays of it carry the same ID and receivers can dedupe.
+type webhookEnvelope struct {
+	ID        uuid.UUID           `json:"id"`
+	Type      domain.WebhookEvent `json:"type"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      any                 `json:"data"`
+}
+
+type WebhookService struct {
+	subscriptions ports.WebhookSubscriptionRepository
+	deliveries    ports.WebhookDeliveryRepository
+
+	client         *http.Client
+	maxAttempts    int
+	baseBackoff    time.Duration
+	maxBackoff     time.Duration
+	requestTimeout time.Duration
+	batchSize      int
+	now            func() time.Time
+}
+
+func NewWebhookService(subscriptions ports.WebhookSubscriptionRepository, deliveries ports.WebhookDeliveryRepository, cfg WebhookServiceConfig) *WebhookService {
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 8
+	}
+	baseBackoff := cfg.BaseBackoff
+	if baseBackoff <= 0 {
+		baseBackoff = 30 * time.Second
+	}
+	maxBackoff := cfg.MaxBackoff
+	if maxBackoff < baseBackoff {
+		maxBackoff = max(time.Hour, baseBackoff)
+	}
+	requestTimeout := cfg.RequestTimeout
+	if requestTimeout <= 0 {
+		requestTimeout = 10 * time.Second
+	}
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 20
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &WebhookService{
+		subscriptions:  subscriptions,
+		deliveries:     deliveries,
+		client:         client,
+		maxAttempts:    maxAttempts,
+		baseBackoff:    baseBackoff,
+		maxBackoff:     maxBackoff,
+		requestTimeout: requestTimeout,
+		batchSize:      batchSize,
+		now:            time.Now,
+	}
+}
+
+func (s *WebhookService) SetClock(now func() time.Time) {
+	if now != nil {
+		s.now = now
+	}
+}
+
+// CreateSubscription registers an endpoint and returns its signing secret,
+// which is not shown again.
+func (s *WebhookService) CreateSubscription(ctx context.Context, actorID uuid.UUID, input WebhookSubscriptionInput) (*domain.WebhookSubscription, string, error) {
+	subscription := &domain.WebhookSubscription{Active: true, CreatedBy: actorID}
+	if err := applyWebhookInput(subscription, input); err != nil {
+		return nil, "", err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	subscription.Secret = secret
+	created, err := s.subscriptions.Create(ctx, subscription)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, secret, nil
+}
+
+// UpdateSubscription replaces the endpoint's settings. The secret is only
+// returned when it was rotated.
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, input WebhookSubscriptionInput) (*domain.WebhookSubscription, string, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := applyWebhookInput(subscription, input); err != nil {
+		return nil, "", err
+	}
+	var secret string
+	if input.RotateSecret {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, "", err
+		}
+		subscription.Secret = secret
+	}
+	updated, err := s.subscriptions.Update(ctx, subscription)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, "", ErrWebhookNotFound
+		}
+		return nil, "", err
+	}
+	return updated, secret, nil
+}
+
+// DeleteSubscription removes the endpoint together with its delivery log.
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		if isNotFound(err) {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.FindByID(ctx, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return s.subscriptions.List(ctx)
+}
+
+// ListDeliveries returns the subscription's delivery log, newest first.
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit, offset int) ([]domain.WebhookDelivery, error) {
+	if _, err := s.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.deliveries.ListBySubscription(ctx, subscriptionID, limit, offset)
+}
+
+// Replay queues the payload of a past delivery again as a new delivery, with
+// a fresh attempt budget. The original stays in the log unchanged.
+func (s *WebhookService) Replay(ctx context.Context, deliveryID uuid.UUID) (*domain.WebhookDelivery, error) {
+	original, err := s.deliveries.FindByID(ctx, deliveryID)
+	if err != nil || original == nil {
+		if err == nil || isNotFound(err) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.GetSubscription(ctx, original.SubscriptionID); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	return s.deliveries.Create(ctx, &domain.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         domain.WebhookDeliveryStatusPending,
+		NextAttemptAt:  &now,
+		ReplayOf:       &original.ID,
+	})
+}
+
+// Publish queues event for every active subscription that asked for it. The
+// dispatcher sends it on its next pass.
+func (s *WebhookService) Publish(ctx context.Context, event domain.WebhookEvent, data any) error {
+	if !event.IsValid() {
+		return fmt.Errorf("%w: unknown event %q", ErrWebhookValidation, event)
+	}
+	subscriptions, err := s.subscriptions.ListActiveForEvent(ctx, event)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	now := s.now()
+	payload, err := json.Marshal(webhookEnvelope{
+		ID:        uuid.New(),
+		Type:      event,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, subscription := range subscriptions {
+		if _, err := s.deliveries.Create(ctx, &domain.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			Event:          event,
+			Payload:        payload,
+			Status:         domain.WebhookDeliveryStatusPending,
+			NextAttemptAt:  &now,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("queue %s for subscription %s: %w", event, subscription.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunDispatcher sends due deliveries every interval until ctx is done.
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DispatchDue(ctx); err != nil {
+				log.Printf("webhook dispatcher: %v", err)
+			}
+		}
+	}
+}
+
+// DispatchDue claims one batch of due deliveries, attempts each and records
+// the outcome. It returns how many deliveries were attempted.
+func (s *WebhookService) DispatchDue(ctx context.Context) (int, error) {
+	// The lease outlasts a batch of timed-out requests, so a delivery is not
+	// claimed twice while it is in flight.
+	lease := time.Duration(s.batchSize)*s.requestTimeout + time.Minute
+	due, err := s.deliveries.ClaimDue(ctx, s.now(), lease, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i := range due {
+		if err := s.attempt(ctx, &due[i]); err != nil {
+			log.Printf("webhook delivery %s: record attempt: %v", due[i].ID, err)
+		}
+	}
+	return len(due), nil
+}
+
+// attempt sends delivery once and schedules the retry when it fails.
+func (s *WebhookService) attempt(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	subscription, err := s.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	var status int
+	switch {
+	case err != nil && !isNotFound(err):
+		return err
+	case err != nil || subscription == nil:
+		err = errors.New("subscription no longer exists")
+	case !subscription.Active:
+		err = errors.New("subscription is inactive")
+	default:
+		status, err = s.send(ctx, subscription, delivery)
+	}
+
+	now := s.now()
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	switch {
+	case err == nil:
+		delivery.Status = domain.WebhookDeliveryStatusSucceeded
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+	case delivery.Attempts >= s.maxAttempts || subscription == nil || !subscription.Active:
+		message := truncateWebhookError(err.Error())
+		delivery.Status = domain.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = &message
+	default:
+		message := truncateWebhookError(err.Error())
+		next := now.Add(s.backoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.LastError = &message
+	}
+	_, err = s.deliveries.Update(ctx, delivery)
+	return err
+}
+
+// send posts the payload signed with the subscription's secret. Any 2xx
+// response counts as delivered.
+func (s *WebhookService) send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FitCity-Webhooks/1.0")
+	req.Header.Set("X-FitCity-Event", string(delivery.Event))
+	req.Header.Set("X-FitCity-Delivery", delivery.ID.String())
+	req.Header.Set("X-FitCity-Timestamp", timestamp)
+	req.Header.Set("X-FitCity-Signature", SignWebhookPayload(subscription.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorLength))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %d: %s", resp.StatusCode, strings.TrimSpace(string(excerpt)))
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff is the wait after the given number of failed attempts.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	wait := s.baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= s.maxBackoff {
+			return s.maxBackoff
+		}
+	}
+	return wait
+}
+
+// SignWebhookPayload is the X-FitCity-Signature value for body sent at
+// timestamp: the hex HMAC-SHA256 of "<timestamp>.<body>". Signing the
+// timestamp lets receivers reject replayed requests.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func applyWebhookInput(subscription *domain.WebhookSubscription, input WebhookSubscriptionInput) error {
+	target := strings.TrimSpace(input.URL)
+	parsed, err := url.Parse(target)
+	if target == "" || err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrWebhookValidation)
+	}
+	if len(input.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrWebhookValidation)
+	}
+	events := make(domain.WebhookEventList, 0, len(input.Events))
+	for _, event := range input.Events {
+		event = domain.WebhookEvent(strings.ToLower(strings.TrimSpace(string(event))))
+		if !event.IsValid() {
+			return fmt.Errorf("%w: unknown event %q", ErrWebhookValidation, event)
+		}
+		if !events.Contains(event) {
+			events = append(events, event)
+		}
+	}
+	description := normalizeString(input.Description)
+	if description != nil && len(*description) > maxWebhookDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", ErrWebhookValidation, maxWebhookDescriptionLength)
+	}
+
+	subscription.URL = target
+	subscription.Events = events
+	subscription.Description = description
+	if input.Active != nil {
+		subscription.Active = *input.Active
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+func truncateWebhookError(message string) string {
+	if len(message) <= maxWebhookErrorLength {
+		return message
+	}
+	return message[:maxWebhookErrorLength]
+}
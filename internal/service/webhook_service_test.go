@@ -0,0 +1,507 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+type memoryWebhookSubscriptionRepo struct {
+	mu    sync.Mutex
+	items map[uuid.UUID]domain.WebhookSubscription
+}
+
+func newMemoryWebhookSubscriptionRepo() *memoryWebhookSubscriptionRepo {
+	return &memoryWebhookSubscriptionRepo{items: make(map[uuid.UUID]domain.WebhookSubscription)}
+}
+
+func (r *memoryWebhookSubscriptionRepo) Create(_ context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	created := *subscription
+	created.ID = uuid.New()
+	r.items[created.ID] = created
+	return &created, nil
+}
+
+func (r *memoryWebhookSubscriptionRepo) Update(_ context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[subscription.ID]; !ok {
+		return nil, sql.ErrNoRows
+	}
+	updated := *subscription
+	r.items[updated.ID] = updated
+	return &updated, nil
+}
+
+func (r *memoryWebhookSubscriptionRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.items, id)
+	return nil
+}
+
+func (r *memoryWebhookSubscriptionRepo) FindByID(_ context.Context, id uuid.UUID) (*domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, ok := r.items[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &subscription, nil
+}
+
+func (r *memoryWebhookSubscriptionRepo) List(context.Context) ([]domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]domain.WebhookSubscription, 0, len(r.items))
+	for _, subscription := range r.items {
+		out = append(out, subscription)
+	}
+	return out, nil
+}
+
+func (r *memoryWebhookSubscriptionRepo) ListActiveForEvent(_ context.Context, event domain.WebhookEvent) ([]domain.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]domain.WebhookSubscription, 0)
+	for _, subscription := range r.items {
+		if subscription.Active && subscription.Events.Contains(event) {
+			out = append(out, subscription)
+		}
+	}
+	return out, nil
+}
+
+type memoryWebhookDeliveryRepo struct {
+	mu    sync.Mutex
+	items map[uuid.UUID]domain.WebhookDelivery
+	order []uuid.UUID
+}
+
+func newMemoryWebhookDeliveryRepo() *memoryWebhookDeliveryRepo {
+	return &memoryWebhookDeliveryRepo{items: make(map[uuid.UUID]domain.WebhookDelivery)}
+}
+
+func (r *memoryWebhookDeliveryRepo) Create(_ context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	created := *delivery
+	created.ID = uuid.New()
+	r.items[created.ID] = created
+	r.order = append(r.order, created.ID)
+	return &created, nil
+}
+
+func (r *memoryWebhookDeliveryRepo) ClaimDue(_ context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]domain.WebhookDelivery, 0)
+	for _, id := range r.order {
+		delivery := r.items[id]
+		if len(out) == limit || delivery.Status != domain.WebhookDeliveryStatusPending || delivery.NextAttemptAt == nil || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		leased := now.Add(lease)
+		delivery.NextAttemptAt = &leased
+		r.items[id] = delivery
+		out = append(out, delivery)
+	}
+	return out, nil
+}
+
+func (r *memoryWebhookDeliveryRepo) Update(_ context.Context, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.items[delivery.ID]; !ok {
+		return nil, sql.ErrNoRows
+	}
+	updated := *delivery
+	r.items[updated.ID] = updated
+	return &updated, nil
+}
+
+func (r *memoryWebhookDeliveryRepo) FindByID(_ context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery, ok := r.items[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &delivery, nil
+}
+
+func (r *memoryWebhookDeliveryRepo) ListBySubscription(_ context.Context, subscriptionID uuid.UUID, limit, offset int) ([]domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]domain.WebhookDelivery, 0)
+	for i := len(r.order) - 1; i >= 0; i-- {
+		if delivery := r.items[r.order[i]]; delivery.SubscriptionID == subscriptionID {
+			out = append(out, delivery)
+		}
+	}
+	if offset >= len(out) {
+		return []domain.WebhookDelivery{}, nil
+	}
+	out = out[offset:]
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// recordingPublisher remembers published events in order.
+type recordingPublisher struct {
+	fail   bool
+	events []domain.WebhookEvent
+	data   []any
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event domain.WebhookEvent, data any) error {
+	if p.fail {
+		return errors.New("queue unavailable")
+	}
+	p.events = append(p.events, event)
+	p.data = append(p.data, data)
+	return nil
+}
+
+func TestWebhookService_DeliversSignedPayloadsWithRetries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 24, 10, 0, 0, 0, time.UTC)
+
+	type received struct {
+		headers http.Header
+		body    []byte
+	}
+	var (
+		mu       sync.Mutex
+		requests []received
+		failures = 1
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, received{headers: r.Header.Clone(), body: body})
+		if failures > 0 {
+			failures--
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscriptions := newMemoryWebhookSubscriptionRepo()
+	deliveries := newMemoryWebhookDeliveryRepo()
+	svc := NewWebhookService(subscriptions, deliveries, WebhookServiceConfig{
+		MaxAttempts: 2,
+		BaseBackoff: time.Minute,
+		HTTPClient:  server.Client(),
+	})
+	svc.SetClock(func() time.Time { return now })
+
+	if _, _, err := svc.CreateSubscription(ctx, uuid.New(), WebhookSubscriptionInput{URL: "ftp://partner.example", Events: []domain.WebhookEvent{domain.WebhookEventReviewPosted}}); !errors.Is(err, ErrWebhookValidation) {
+		t.Fatalf("expected non-http URLs to be rejected, got %v", err)
+	}
+	if _, _, err := svc.CreateSubscription(ctx, uuid.New(), WebhookSubscriptionInput{URL: server.URL, Events: []domain.WebhookEvent{"destination.renamed"}}); !errors.Is(err, ErrWebhookValidation) {
+		t.Fatalf("expected unknown events to be rejected, got %v", err)
+	}
+	subscription, secret, err := svc.CreateSubscription(ctx, uuid.New(), WebhookSubscriptionInput{
+		URL:    server.URL,
+		Events: []domain.WebhookEvent{domain.WebhookEventDestinationPublished, " Destination.Published "},
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	if secret == "" || len(subscription.Events) != 1 || !subscription.Active {
+		t.Fatalf("unexpected subscription %+v with secret %q", subscription, secret)
+	}
+
+	if err := svc.Publish(ctx, domain.WebhookEventReviewPosted, map[string]string{"ignored": "yes"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if err := svc.Publish(ctx, domain.WebhookEventDestinationPublished, map[string]string{"name": "Wat Pho"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if n, err := svc.DispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected one due delivery, got %d, %v", n, err)
+	}
+	log, _ := svc.ListDeliveries(ctx, subscription.ID, 10, 0)
+	if len(log) != 1 || log[0].Status != domain.WebhookDeliveryStatusPending || log[0].Attempts != 1 || *log[0].ResponseStatus != http.StatusServiceUnavailable {
+		t.Fatalf("expected a failed attempt to stay pending, got %+v", log)
+	}
+	if !log[0].NextAttemptAt.Equal(now.Add(time.Minute)) || log[0].LastError == nil {
+		t.Fatalf("expected a retry after the base backoff, got %+v", log[0])
+	}
+	if n, _ := svc.DispatchDue(ctx); n != 0 {
+		t.Fatalf("expected nothing due before the backoff elapsed, got %d", n)
+	}
+
+	now = now.Add(time.Minute)
+	if n, err := svc.DispatchDue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected the retry to be due, got %d, %v", n, err)
+	}
+	log, _ = svc.ListDeliveries(ctx, subscription.ID, 10, 0)
+	if log[0].Status != domain.WebhookDeliveryStatusSucceeded || log[0].Attempts != 2 || log[0].DeliveredAt == nil || log[0].LastError != nil {
+		t.Fatalf("expected the retry to succeed, got %+v", log[0])
+	}
+
+	last := requests[len(requests)-1]
+	timestamp := last.headers.Get("X-FitCity-Timestamp")
+	if got := last.headers.Get("X-FitCity-Signature"); got != SignWebhookPayload(secret, timestamp, last.body) {
+		t.Fatalf("signature %q does not verify", got)
+	}
+	if last.headers.Get("X-FitCity-Event") != string(domain.WebhookEventDestinationPublished) || last.headers.Get("X-FitCity-Delivery") != log[0].ID.String() {
+		t.Fatalf("unexpected headers %v", last.headers)
+	}
+	var envelope struct {
+		ID   uuid.UUID         `json:"id"`
+		Type string            `json:"type"`
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(last.body, &envelope); err != nil || envelope.Type != "destination.published" || envelope.Data["name"] != "Wat Pho" {
+		t.Fatalf("unexpected body %s: %v", last.body, err)
+	}
+
+	replay, err := svc.Replay(ctx, log[0].ID)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if replay.ReplayOf == nil || *replay.ReplayOf != log[0].ID || replay.Status != domain.WebhookDeliveryStatusPending {
+		t.Fatalf("unexpected replay %+v", replay)
+	}
+	if n, _ := svc.DispatchDue(ctx); n != 1 {
+		t.Fatalf("expected the replay to be sent")
+	}
+	if first, replayed := requests[1].body, requests[2].body; string(first) != string(replayed) {
+		t.Fatalf("expected the replay to resend the same event")
+	}
+	if _, err := svc.Replay(ctx, uuid.New()); !errors.Is(err, ErrWebhookDeliveryNotFound) {
+		t.Fatalf("expected ErrWebhookDeliveryNotFound, got %v", err)
+	}
+}
+
+func TestWebhookService_GivesUpAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 24, 10, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	deliveries := newMemoryWebhookDeliveryRepo()
+	svc := NewWebhookService(newMemoryWebhookSubscriptionRepo(), deliveries, WebhookServiceConfig{
+		MaxAttempts: 3,
+		BaseBackoff: time.Minute,
+		MaxBackoff:  90 * time.Second,
+		HTTPClient:  server.Client(),
+	})
+	svc.SetClock(func() time.Time { return now })
+	subscription, _, err := svc.CreateSubscription(ctx, uuid.New(), WebhookSubscriptionInput{URL: server.URL, Events: []domain.WebhookEvent{domain.WebhookEventReviewDeleted}})
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	if err := svc.Publish(ctx, domain.WebhookEventReviewDeleted, nil); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	var waits []time.Duration
+	for attempt := 0; attempt < 3; attempt++ {
+		if n, _ := svc.DispatchDue(ctx); n != 1 {
+			t.Fatalf("attempt %d: expected a due delivery", attempt+1)
+		}
+		log, _ := svc.ListDeliveries(ctx, subscription.ID, 10, 0)
+		if log[0].NextAttemptAt != nil {
+			waits = append(waits, log[0].NextAttemptAt.Sub(now))
+			now = *log[0].NextAttemptAt
+		}
+	}
+	if len(waits) != 2 || waits[0] != time.Minute || waits[1] != 90*time.Second {
+		t.Fatalf("expected capped exponential backoff, got %v", waits)
+	}
+	log, _ := svc.ListDeliveries(ctx, subscription.ID, 10, 0)
+	if log[0].Status != domain.WebhookDeliveryStatusFailed || log[0].Attempts != 3 {
+		t.Fatalf("expected the delivery to fail after three attempts, got %+v", log[0])
+	}
+}
+
+func TestDestinationWorkflowService_PublishesWebhookEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 7, 24, 10, 0, 0, 0, time.UTC)
+	publisher := &recordingPublisher{}
+	workflow := NewDestinationWorkflowService(newMemoryDestinationRepo(now), newMemoryChangeRepo(), newMemoryVersionRepo(), newMemoryApprovalRepo(), &memoryStorage{}, DestinationWorkflowConfig{
+		Webhooks: publisher,
+	})
+	workflow.SetClock(func() time.Time { return now })
+	admin := uuid.New()
+	approve := func(input DestinationDraftInput) *domain.Destination {
+		t.Helper()
+		change, err := workflow.CreateDraft(ctx, admin, input)
+		if err != nil {
+			t.Fatalf("CreateDraft: %v", err)
+		}
+		if _, err = workflow.SubmitDraft(ctx, change.ID, admin); err != nil {
+			t.Fatalf("SubmitDraft: %v", err)
+		}
+		_, dest, err := workflow.Approve(ctx, change.ID, uuid.New(), "")
+		if err != nil {
+			t.Fatalf("Approve: %v", err)
+		}
+		return dest
+	}
+
+	draft := domain.DestinationStatusDraft
+	hidden := approve(DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Hidden Garden"), Status: &draft},
+	})
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &hidden.ID,
+		Fields:        domain.DestinationChangeFields{Name: strPtr("Hidden Garden Cafe")},
+	})
+	if len(publisher.events) != 0 {
+		t.Fatalf("expected unpublished drafts to stay silent, got %v", publisher.events)
+	}
+
+	dest := approve(DestinationDraftInput{
+		Action: domain.DestinationChangeActionCreate,
+		Fields: domain.DestinationChangeFields{Name: strPtr("Wat Pho")},
+	})
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &dest.ID,
+		Fields:        domain.DestinationChangeFields{Name: strPtr("Wat Pho Temple")},
+	})
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionDelete,
+		DestinationID: &dest.ID,
+	})
+	approve(DestinationDraftInput{
+		Action:        domain.DestinationChangeActionRestore,
+		DestinationID: &dest.ID,
+	})
+
+	want := []domain.WebhookEvent{
+		domain.WebhookEventDestinationPublished,
+		domain.WebhookEventDestinationUpdated,
+		domain.WebhookEventDestinationArchived,
+		domain.WebhookEventDestinationPublished,
+	}
+	if len(publisher.events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, publisher.events)
+	}
+	for i, event := range want {
+		if publisher.events[i] != event {
+			t.Fatalf("expected events %v, got %v", want, publisher.events)
+		}
+	}
+	updated := publisher.data[1].(destinationWebhookData)
+	if updated.DestinationID != dest.ID || updated.Action != domain.DestinationChangeActionUpdate || updated.Destination.Name != "Wat Pho Temple" {
+		t.Fatalf("unexpected update data %+v", updated)
+	}
+	encoded, err := json.Marshal(updated)
+	if err != nil {
+		t.Fatalf("marshal data: %v", err)
+	}
+	if strings.Contains(string(encoded), "updated_by") || strings.Contains(string(encoded), "deleted_at") {
+		t.Fatalf("expected internal fields left out of the partner payload, got %s", encoded)
+	}
+
+	// The event is queued in the approval's transaction, so failing to queue
+	// it fails the approval instead of losing the event.
+	publisher.fail = true
+	change, err := workflow.CreateDraft(ctx, admin, DestinationDraftInput{
+		Action:        domain.DestinationChangeActionUpdate,
+		DestinationID: &dest.ID,
+		Fields:        domain.DestinationChangeFields{Name: strPtr("Wat Pho Monastery")},
+	})
+	if err != nil {
+		t.Fatalf("CreateDraft: %v", err)
+	}
+	if _, err = workflow.SubmitDraft(ctx, change.ID, admin); err != nil {
+		t.Fatalf("SubmitDraft: %v", err)
+	}
+	if _, _, err := workflow.Approve(ctx, change.ID, uuid.New(), ""); err == nil {
+		t.Fatalf("expected the approval to fail when its event cannot be queued")
+	}
+}
+
+func TestDestinationWebhookEvent(t *testing.T) {
+	published := &domain.Destination{Status: domain.DestinationStatusPublished}
+	draft := &domain.Destination{Status: domain.DestinationStatusDraft}
+	archived := &domain.Destination{Status: domain.DestinationStatusArchived}
+	cases := []struct {
+		before, after *domain.Destination
+		want          domain.WebhookEvent
+	}{
+		{nil, published, domain.WebhookEventDestinationPublished},
+		{nil, draft, ""},
+		{draft, published, domain.WebhookEventDestinationPublished},
+		{published, published, domain.WebhookEventDestinationUpdated},
+		{published, draft, domain.WebhookEventDestinationArchived},
+		{published, archived, domain.WebhookEventDestinationArchived},
+		{draft, archived, ""},
+		{draft, nil, domain.WebhookEventDestinationDeleted},
+	}
+	for _, tc := range cases {
+		got, ok := destinationWebhookEvent(tc.before, tc.after)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Fatalf("destinationWebhookEvent(%v, %v) = %q, %v; want %q", tc.before, tc.after, got, ok, tc.want)
+		}
+	}
+}
+
+func TestReviewService_PublishesWebhookEvents(t *testing.T) {
+	ctx := context.Background()
+	destID := uuid.New()
+	userID := uuid.New()
+	publisher := &recordingPublisher{}
+	destRepo := &reviewDestinationRepo{
+		items: map[uuid.UUID]*domain.Destination{
+			destID: {ID: destID, Status: domain.DestinationStatusPublished},
+		},
+	}
+	svc := NewReviewService(newMemoryReviewRepository(), newMemoryMediaRepository(), destRepo, &reviewStorage{}, ReviewServiceConfig{
+		Webhooks: publisher,
+	})
+
+	if _, _, err := svc.CreateReview(ctx, userID, destID, ReviewCreateInput{Rating: 9}); err == nil {
+		t.Fatalf("expected an invalid rating to be rejected")
+	}
+	review, _, err := svc.CreateReview(ctx, userID, destID, ReviewCreateInput{Rating: 4})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	if err := svc.DeleteReview(ctx, review.ID, userID, false); err != nil {
+		t.Fatalf("DeleteReview: %v", err)
+	}
+
+	got := publisher.events
+	if len(got) != 2 || got[0] != domain.WebhookEventReviewPosted || got[1] != domain.WebhookEventReviewDeleted {
+		t.Fatalf("expected posted and deleted events, got %v", publisher.events)
+	}
+	posted := publisher.data[0].(reviewWebhookData)
+	if posted.DestinationID != destID || posted.Review.ID != review.ID || posted.Aggregate == nil || posted.Aggregate.TotalReviews != 1 {
+		t.Fatalf("unexpected posted data %+v", posted)
+	}
+}
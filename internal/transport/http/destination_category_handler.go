@@ -0,0 +1,150 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/service"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/util"
+)
+
+type DestinationCategoryHandler struct {
+	categories *service.DestinationCategoryService
+}
+
+func RegisterDestinationCategories(e *echo.Echo, auth *service.AuthService, categories *service.DestinationCategoryService) {
+	if categories == nil {
+		return
+	}
+	handler := &DestinationCategoryHandler{categories: categories}
+
+	e.GET("/api/v1/destination-categories", handler.tree)
+
+	admin := e.Group("/api/v1/admin/destination-categories", RequireAuth(auth), RequireAdmin(auth))
+	admin.GET("", handler.list)
+	admin.POST("", handler.create)
+	admin.PUT("/:id", handler.update)
+	admin.DELETE("/:id", handler.delete)
+}
+
+type destinationCategoryRequest struct {
+	Name         *string `json:"name"`
+	Slug         *string `json:"slug"`
+	ParentID     *string `json:"parent_id"`
+	Icon         *string `json:"icon"`
+	DisplayOrder *int    `json:"display_order"`
+}
+
+// toInput maps the request; an empty parent_id moves the category to the root.
+func (r destinationCategoryRequest) toInput() (service.DestinationCategoryInput, error) {
+	input := service.DestinationCategoryInput{
+		Name:         r.Name,
+		Slug:         r.Slug,
+		Icon:         r.Icon,
+		DisplayOrder: r.DisplayOrder,
+	}
+	if r.ParentID != nil {
+		raw := strings.TrimSpace(*r.ParentID)
+		if raw == "" {
+			input.ClearParent = true
+		} else {
+			parentID, err := uuid.Parse(raw)
+			if err != nil {
+				return input, errors.New("invalid parent_id")
+			}
+			input.ParentID = &parentID
+		}
+	}
+	return input, nil
+}
+
+func (h *DestinationCategoryHandler) tree(c echo.Context) error {
+	categories, err := h.categories.Tree(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, util.Error("could not load categories"))
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"categories": categories,
+	})
+}
+
+func (h *DestinationCategoryHandler) list(c echo.Context) error {
+	categories, err := h.categories.List(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, util.Error("could not load categories"))
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"categories": categories,
+	})
+}
+
+func (h *DestinationCategoryHandler) create(c echo.Context) error {
+	var req destinationCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+	input, err := req.toInput()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	}
+	category, err := h.categories.Create(c.Request().Context(), input)
+	if err != nil {
+		return writeCategoryError(c, err)
+	}
+	return c.JSON(http.StatusCreated, util.Envelope{
+		"category": category,
+	})
+}
+
+func (h *DestinationCategoryHandler) update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid category id"))
+	}
+	var req destinationCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+	input, err := req.toInput()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	}
+	category, err := h.categories.Update(c.Request().Context(), id, input)
+	if err != nil {
+		return writeCategoryError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"category": category,
+	})
+}
+
+func (h *DestinationCategoryHandler) delete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid category id"))
+	}
+	if err := h.categories.Delete(c.Request().Context(), id); err != nil {
+		return writeCategoryError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"success": true,
+		"message": "Category deleted",
+	})
+}
+
+func writeCategoryError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound):
+		return c.JSON(http.StatusNotFound, util.Error("category not found"))
+	case errors.Is(err, service.ErrCategoryValidation):
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	case errors.Is(err, service.ErrCategoryConflict):
+		return c.JSON(http.StatusConflict, util.Error(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, util.Error("internal error"))
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/util"
+)
+
+// exportCSV streams destinations of any status as CSV in the bulk import
+// layout, so the file can be edited and uploaded again. Filters: status and
+// category (comma separated or repeated) and updated_since (RFC 3339 or
+// YYYY-MM-DD).
+func (h *DestinationHandler) exportCSV(c echo.Context) error {
+	filter, err := parseDestinationExportFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="destinations-export.csv"`)
+	if err := h.destinations.ExportCSV(c.Request().Context(), res, filter); err != nil {
+		if !res.Committed {
+			return c.JSON(http.StatusInternalServerError, util.Error("could not export destinations"))
+		}
+		// Rows already went out; the truncated file is all that can be sent.
+		log.Printf("destination csv export: %v", err)
+	}
+	if !res.Committed {
+		res.WriteHeader(http.StatusOK)
+	}
+	return nil
+}
+
+func parseDestinationExportFilter(c echo.Context) (domain.DestinationExportFilter, error) {
+	var filter domain.DestinationExportFilter
+	for _, raw := range queryList(c, "status") {
+		status := domain.DestinationStatus(strings.ToLower(raw))
+		switch status {
+		case domain.DestinationStatusDraft, domain.DestinationStatusPublished, domain.DestinationStatusArchived:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return filter, fmt.Errorf("status must be draft, published, or archived")
+		}
+	}
+	filter.Categories = queryList(c, "category")
+	if raw := strings.TrimSpace(c.QueryParam("updated_since")); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			if since, err = time.Parse("2006-01-02", raw); err != nil {
+				return filter, fmt.Errorf("updated_since must be an RFC 3339 timestamp or YYYY-MM-DD date")
+			}
+		}
+		filter.UpdatedSince = &since
+	}
+	return filter, nil
+}
+
+// queryList collects a parameter given as comma separated values, repeated,
+// or both.
+func queryList(c echo.Context, name string) []string {
+	var values []string
+	for _, raw := range c.QueryParams()[name] {
+		for _, part := range strings.Split(raw, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				values = append(values, trimmed)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,201 @@
+package http
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/util"
+)
+
+const (
+	geoJSONContentType = "application/geo+json"
+	kmlContentType     = "application/vnd.google-earth.kml+xml"
+	geoExportBatchSize = 500
+)
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties util.Envelope   `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// exportGeoJSON streams the published destinations matching the list filters
+// as a GeoJSON FeatureCollection. Destinations without coordinates are left
+// out.
+func (h *DestinationHandler) exportGeoJSON(c echo.Context) error {
+	return h.streamGeoExport(c, geoJSONContentType, "destinations.geojson", geoJSONWriter{})
+}
+
+// exportKML is the KML variant of exportGeoJSON, one Placemark per
+// destination.
+func (h *DestinationHandler) exportKML(c echo.Context) error {
+	return h.streamGeoExport(c, kmlContentType, "destinations.kml", kmlWriter{})
+}
+
+// geoFormatWriter writes one export format. Feature is only called for
+// destinations with coordinates.
+type geoFormatWriter interface {
+	Header(w io.Writer) error
+	Feature(w io.Writer, first bool, dest *domain.Destination) error
+	Footer(w io.Writer) error
+}
+
+func (h *DestinationHandler) streamGeoExport(c echo.Context, contentType, fileName string, format geoFormatWriter) error {
+	if !h.features.View {
+		return c.JSON(http.StatusNotFound, util.Error("resource not found"))
+	}
+	filter, err := parseDestinationListFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	}
+	locale := h.resolveLocale(c)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	res.WriteHeader(http.StatusOK)
+
+	if err := format.Header(res); err != nil {
+		return err
+	}
+	first := true
+	err = h.destinations.EachPublished(c.Request().Context(), filter, geoExportBatchSize, func(dest *domain.Destination) error {
+		if dest.Latitude == nil || dest.Longitude == nil {
+			return nil
+		}
+		if err := format.Feature(res, first, h.destinations.Localize(dest, locale)); err != nil {
+			return err
+		}
+		first = false
+		res.Flush()
+		return nil
+	})
+	if err != nil {
+		// The status line is already sent. Closing the document would pass a
+		// partial export off as complete, so the transfer is aborted instead
+		// and clients see it fail.
+		log.Printf("destination %s export: %v", fileName, err)
+		panic(http.ErrAbortHandler)
+	}
+	return format.Footer(res)
+}
+
+type geoJSONWriter struct{}
+
+func (geoJSONWriter) Header(w io.Writer) error {
+	_, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`)
+	return err
+}
+
+func (geoJSONWriter) Feature(w io.Writer, first bool, dest *domain.Destination) error {
+	if !first {
+		if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(geoJSONFeature{
+		Type: "Feature",
+		ID:   dest.ID.String(),
+		Geometry: geoJSONGeometry{
+			Type:        "Point",
+			Coordinates: [2]float64{*dest.Longitude, *dest.Latitude},
+		},
+		Properties: geoExportProperties(dest),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (geoJSONWriter) Footer(w io.Writer) error {
+	_, err := io.WriteString(w, "]}\n")
+	return err
+}
+
+type kmlWriter struct{}
+
+func (kmlWriter) Header(w io.Writer) error {
+	_, err := io.WriteString(w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>Fit City destinations</name>`+"\n")
+	return err
+}
+
+func (kmlWriter) Feature(w io.Writer, _ bool, dest *domain.Destination) error {
+	if _, err := fmt.Fprintf(w, `<Placemark id="%s"><name>`, dest.ID); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(w, []byte(dest.Name)); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "</name><ExtendedData>"); err != nil {
+		return err
+	}
+	props := geoExportProperties(dest)
+	for _, key := range geoExportKeys {
+		value, ok := props[key]
+		if !ok || key == "name" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, `<Data name="%s"><value>`, key); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(w, []byte(fmt.Sprint(value))); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "</value></Data>"); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "</ExtendedData><Point><coordinates>%s,%s</coordinates></Point></Placemark>\n",
+		strconv.FormatFloat(*dest.Longitude, 'f', -1, 64),
+		strconv.FormatFloat(*dest.Latitude, 'f', -1, 64))
+	return err
+}
+
+func (kmlWriter) Footer(w io.Writer) error {
+	_, err := io.WriteString(w, "</Document></kml>\n")
+	return err
+}
+
+// geoExportKeys fixes the order KML ExtendedData is written in.
+var geoExportKeys = []string{"id", "name", "slug", "category", "city", "country", "average_rating", "review_count", "hero_image_url"}
+
+func geoExportProperties(dest *domain.Destination) util.Envelope {
+	props := util.Envelope{
+		"id":             dest.ID,
+		"name":           dest.Name,
+		"average_rating": dest.AverageRating,
+		"review_count":   dest.ReviewCount,
+	}
+	if dest.Slug != nil {
+		props["slug"] = *dest.Slug
+	}
+	if dest.Category != nil {
+		props["category"] = *dest.Category
+	}
+	if dest.City != nil {
+		props["city"] = *dest.City
+	}
+	if dest.Country != nil {
+		props["country"] = *dest.Country
+	}
+	if dest.HeroImage != nil {
+		props["hero_image_url"] = *dest.HeroImage
+	}
+	return props
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"io"
+	"log"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,6 +22,13 @@ import (
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/util"
 )
 
+const (
+	defaultNearbyRadiusKM = 10.0
+	maxNearbyRadiusKM     = 200.0
+	defaultRelatedLimit   = 10
+	maxRelatedLimit       = 50
+)
+
 type DestinationFeatures struct {
 	View   bool
 	Create bool
@@ -27,37 +38,66 @@ type DestinationFeatures struct {
 
 type DestinationHandler struct {
 	workflow     *service.DestinationWorkflowService
+	comments     *service.DestinationCommentService
 	destinations *service.DestinationService
 	auth         *service.AuthService
 	features     DestinationFeatures
 }
 
-func RegisterDestinations(e *echo.Echo, auth *service.AuthService, destService *service.DestinationService, workflow *service.DestinationWorkflowService, features DestinationFeatures) {
+func RegisterDestinations(e *echo.Echo, auth *service.AuthService, destService *service.DestinationService, workflow *service.DestinationWorkflowService, comments *service.DestinationCommentService, features DestinationFeatures) {
 	handler := &DestinationHandler{
 		workflow:     workflow,
+		comments:     comments,
 		destinations: destService,
 		auth:         auth,
 		features:     features,
 	}
 
 	if features.View {
+		e.GET("/api/v1/destinations.geojson", handler.exportGeoJSON)
+		e.GET("/api/v1/destinations.kml", handler.exportKML)
 		public := e.Group("/api/v1/destinations")
 		public.GET("/autocomplete", handler.Autocomplete)
 		public.GET("", handler.listPublished)
+		public.GET("/map", handler.mapDestinations)
 		public.GET("/:id", handler.getDestination)
+		public.GET("/:id/nearby", handler.nearbyDestinations)
+		public.GET("/:id/similar", handler.similarDestinations)
 	}
 
 	if features.Create || features.Update || features.Delete {
 		admin := e.Group("/api/v1/admin/destination-changes", RequireAuth(auth), RequireAdmin(auth))
 		admin.POST("", handler.createChange)
+		admin.POST("/bulk", handler.bulkReview)
 		admin.PUT("/:id", handler.updateChange)
 		admin.POST("/:id/submit", handler.submitChange)
+		admin.POST("/:id/withdraw", handler.withdrawChange)
+		admin.POST("/:id/reopen", handler.reopenChange)
+		admin.DELETE("/:id", handler.discardChange)
 		admin.POST("/:id/approve", handler.approveChange)
 		admin.POST("/:id/reject", handler.rejectChange)
 		admin.GET("", handler.listChanges)
 		admin.GET("/:id", handler.getChange)
+		admin.GET("/:id/diff", handler.diffChange)
+		admin.POST("/:id/rebase", handler.rebaseChange)
+		admin.GET("/:id/comments", handler.listComments)
+		admin.POST("/:id/comments", handler.addComment)
+		admin.POST("/:id/comments/:commentId/resolve", handler.resolveComment)
+		admin.POST("/:id/comments/:commentId/unresolve", handler.unresolveComment)
 		admin.POST("/:id/hero-image", handler.uploadHeroImage)
 		admin.POST("/:id/gallery", handler.uploadGalleryImages)
+		admin.PUT("/:id/gallery/order", handler.reorderGallery)
+		admin.PUT("/:id/gallery/caption", handler.captionGalleryItem)
+		admin.PUT("/:id/gallery/hero", handler.setGalleryHero)
+		admin.DELETE("/:id/gallery", handler.removeGalleryItem)
+	}
+
+	adminDestinations := e.Group("/api/v1/admin/destinations", RequireAuth(auth), RequireAdmin(auth))
+	adminDestinations.GET("/export", handler.exportCSV)
+	if features.Update {
+		adminDestinations.GET("/:id/versions", handler.listVersions)
+		adminDestinations.GET("/:id/versions/diff", handler.diffVersions)
+		adminDestinations.POST("/:id/rollback", handler.rollbackDestination)
 	}
 }
 
@@ -168,6 +208,70 @@ func (h *DestinationHandler) submitChange(c echo.Context) error {
 	})
 }
 
+func (h *DestinationHandler) withdrawChange(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+
+	change, err := h.workflow.WithdrawSubmission(c.Request().Context(), changeID, user.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, util.Envelope{
+		"change_request": buildChangeResponse(change),
+		"message":        "Submission withdrawn",
+	})
+}
+
+func (h *DestinationHandler) reopenChange(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+
+	change, err := h.workflow.ReopenRejected(c.Request().Context(), changeID, user.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, util.Envelope{
+		"change_request": buildChangeResponse(change),
+		"message":        "Change reopened as draft",
+	})
+}
+
+func (h *DestinationHandler) discardChange(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+
+	if err := h.workflow.DiscardDraft(c.Request().Context(), changeID, user.ID); err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"success": true,
+		"message": "Change request discarded",
+	})
+}
+
 func (h *DestinationHandler) approveChange(c echo.Context) error {
 	user, ok := CurrentUser(c)
 	if !ok || user == nil {
@@ -188,11 +292,31 @@ func (h *DestinationHandler) approveChange(c echo.Context) error {
 		return c.JSON(http.StatusForbidden, util.Error("feature disabled for this action"))
 	}
 
-	change, destination, err := h.workflow.Approve(c.Request().Context(), changeID, user.ID)
+	var req struct {
+		Comment string `json:"comment"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+
+	change, destination, err := h.workflow.Approve(c.Request().Context(), changeID, user.ID, req.Comment)
 	if err != nil {
 		return h.writeChangeError(c, err)
 	}
 
+	if destination == nil && change.Status == domain.DestinationChangeStatusPendingReview {
+		approvals, err := h.workflow.ListApprovals(c.Request().Context(), change.ID)
+		if err != nil {
+			return h.writeChangeError(c, err)
+		}
+		return c.JSON(http.StatusAccepted, util.Envelope{
+			"change_request":     buildChangeResponse(change),
+			"approvals":          approvals,
+			"approvals_required": h.workflow.RequiredApprovals(change.Action),
+			"message":            "Approval recorded; awaiting further reviewers",
+		})
+	}
+
 	resp := util.Envelope{
 		"change_request": buildChangeResponse(change),
 		"message":        "Destination updated successfully",
@@ -255,11 +379,208 @@ func (h *DestinationHandler) getChange(c echo.Context) error {
 	if err := h.addChangeUserDetails(c.Request().Context(), []util.Envelope{resp}, []*domain.DestinationChangeRequest{change}); err != nil {
 		return c.JSON(http.StatusInternalServerError, util.Error("unable to load change metadata"))
 	}
+	approvals, err := h.workflow.ListApprovals(c.Request().Context(), change.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	resp["approvals"] = approvals
+	resp["approvals_required"] = h.workflow.RequiredApprovals(change.Action)
+	comments, err := h.comments.ListComments(c.Request().Context(), change.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	resp["comments"] = comments
 	return c.JSON(http.StatusOK, util.Envelope{
 		"change_request": resp,
 	})
 }
 
+func (h *DestinationHandler) diffChange(c echo.Context) error {
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+	diff, err := h.workflow.DiffChange(c.Request().Context(), changeID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"change_request_id": changeID,
+		"diff":              diff,
+	})
+}
+
+func (h *DestinationHandler) rebaseChange(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+
+	change, conflicts, err := h.workflow.RebaseDraft(c.Request().Context(), changeID, user.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, util.Envelope{
+		"change_request": buildChangeResponse(change),
+		"conflicts":      conflicts,
+	})
+}
+
+func (h *DestinationHandler) listComments(c echo.Context) error {
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+	comments, err := h.comments.ListComments(c.Request().Context(), changeID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"comments": comments,
+	})
+}
+
+func (h *DestinationHandler) addComment(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+
+	var req struct {
+		Body     string     `json:"body"`
+		Field    *string    `json:"field"`
+		ParentID *uuid.UUID `json:"parent_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+
+	comment, err := h.comments.AddComment(c.Request().Context(), changeID, user.ID, service.ChangeCommentInput{
+		Body:     req.Body,
+		Field:    req.Field,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusCreated, util.Envelope{
+		"comment": comment,
+	})
+}
+
+func (h *DestinationHandler) resolveComment(c echo.Context) error {
+	return h.setCommentResolved(c, true)
+}
+
+func (h *DestinationHandler) unresolveComment(c echo.Context) error {
+	return h.setCommentResolved(c, false)
+}
+
+func (h *DestinationHandler) setCommentResolved(c echo.Context, resolved bool) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid comment id"))
+	}
+
+	var comment *domain.DestinationChangeComment
+	if resolved {
+		comment, err = h.comments.ResolveThread(c.Request().Context(), changeID, commentID, user.ID)
+	} else {
+		comment, err = h.comments.UnresolveThread(c.Request().Context(), changeID, commentID)
+	}
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"comment": comment,
+	})
+}
+
+func (h *DestinationHandler) bulkReview(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	var req struct {
+		ChangeIDs []uuid.UUID `json:"change_ids"`
+		Filter    struct {
+			ImportJobID *uuid.UUID `json:"import_job_id"`
+			SubmittedBy *uuid.UUID `json:"submitted_by"`
+			Action      string     `json:"action"`
+		} `json:"filter"`
+		Decision string `json:"decision"`
+		Message  string `json:"message"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+
+	filter := domain.DestinationChangeFilter{
+		ImportJobID: req.Filter.ImportJobID,
+		SubmittedBy: req.Filter.SubmittedBy,
+	}
+	if strings.TrimSpace(req.Filter.Action) != "" {
+		action, err := parseChangeAction(req.Filter.Action)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, util.Error("invalid action filter"))
+		}
+		filter.Actions = []domain.DestinationChangeAction{action}
+	}
+
+	enabled := make([]domain.DestinationChangeAction, 0, 3)
+	for _, action := range []domain.DestinationChangeAction{
+		domain.DestinationChangeActionCreate,
+		domain.DestinationChangeActionUpdate,
+		domain.DestinationChangeActionDelete,
+		domain.DestinationChangeActionRestore,
+	} {
+		if h.isActionEnabled(action) {
+			enabled = append(enabled, action)
+		}
+	}
+
+	result, err := h.workflow.BulkReview(c.Request().Context(), user.ID, service.BulkReviewInput{
+		ChangeIDs:      req.ChangeIDs,
+		Filter:         filter,
+		Decision:       service.BulkReviewDecision(strings.ToLower(strings.TrimSpace(req.Decision))),
+		Message:        req.Message,
+		EnabledActions: enabled,
+	})
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, util.Envelope{
+		"results":       result.Items,
+		"succeeded":     result.Succeeded,
+		"failed":        result.Failed,
+		"all_succeeded": result.AllSucceeded,
+		"truncated":     result.Truncated,
+		"remaining":     result.Remaining,
+	})
+}
+
 func (h *DestinationHandler) listChanges(c echo.Context) error {
 	statusFilters := strings.Split(strings.TrimSpace(c.QueryParam("status")), ",")
 	statuses := make([]domain.DestinationChangeStatus, 0)
@@ -284,11 +605,44 @@ func (h *DestinationHandler) listChanges(c echo.Context) error {
 		destinationID = &parsed
 	}
 
+	var submittedBy *uuid.UUID
+	if id := strings.TrimSpace(c.QueryParam("submitted_by")); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, util.Error("submitted_by must be a valid UUID"))
+		}
+		submittedBy = &parsed
+	}
+
+	var importJobID *uuid.UUID
+	if id := strings.TrimSpace(c.QueryParam("import_job_id")); id != "" {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, util.Error("import_job_id must be a valid UUID"))
+		}
+		importJobID = &parsed
+	}
+
+	actions := make([]domain.DestinationChangeAction, 0)
+	for _, raw := range strings.Split(strings.TrimSpace(c.QueryParam("action")), ",") {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		action, err := parseChangeAction(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, util.Error("invalid action filter"))
+		}
+		actions = append(actions, action)
+	}
+
 	limit, offset := parsePagination(c, 50, 0)
 
 	changes, err := h.workflow.ListChanges(c.Request().Context(), domain.DestinationChangeFilter{
 		DestinationID: destinationID,
+		SubmittedBy:   submittedBy,
+		ImportJobID:   importJobID,
 		Statuses:      statuses,
+		Actions:       actions,
 		Limit:         limit,
 		Offset:        offset,
 	})
@@ -454,6 +808,171 @@ func (h *DestinationHandler) uploadGalleryImages(c echo.Context) error {
 	})
 }
 
+func (h *DestinationHandler) reorderGallery(c echo.Context) error {
+	var req struct {
+		URLs []string `json:"urls"`
+	}
+	return h.editGallery(c, &req, func(ctx context.Context, changeID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error) {
+		return h.workflow.ReorderGallery(ctx, changeID, authorID, req.URLs)
+	})
+}
+
+func (h *DestinationHandler) captionGalleryItem(c echo.Context) error {
+	var req struct {
+		URL     string `json:"url"`
+		Caption string `json:"caption"`
+	}
+	return h.editGallery(c, &req, func(ctx context.Context, changeID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error) {
+		return h.workflow.CaptionGalleryItem(ctx, changeID, authorID, req.URL, req.Caption)
+	})
+}
+
+func (h *DestinationHandler) setGalleryHero(c echo.Context) error {
+	var req struct {
+		URL string `json:"url"`
+	}
+	return h.editGallery(c, &req, func(ctx context.Context, changeID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error) {
+		return h.workflow.SetGalleryHero(ctx, changeID, authorID, req.URL)
+	})
+}
+
+// removeGalleryItem takes the item's URL from ?url=.
+func (h *DestinationHandler) removeGalleryItem(c echo.Context) error {
+	url := strings.TrimSpace(c.QueryParam("url"))
+	if url == "" {
+		return c.JSON(http.StatusBadRequest, util.Error("url is required"))
+	}
+	return h.editGallery(c, nil, func(ctx context.Context, changeID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error) {
+		return h.workflow.RemoveGalleryItem(ctx, changeID, authorID, url)
+	})
+}
+
+// editGallery runs a gallery edit on the change named by :id after binding
+// req, when given, and checking the feature flag for the change's action.
+func (h *DestinationHandler) editGallery(c echo.Context, req any, edit func(ctx context.Context, changeID, authorID uuid.UUID) (*domain.DestinationChangeRequest, error)) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	changeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid change id"))
+	}
+	if req != nil {
+		if err := c.Bind(req); err != nil {
+			return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+		}
+	}
+
+	change, err := h.workflow.GetChange(c.Request().Context(), changeID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	if !h.isActionEnabled(change.Action) {
+		return c.JSON(http.StatusForbidden, util.Error("feature disabled for this action"))
+	}
+
+	updated, err := edit(c.Request().Context(), change.ID, user.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"change_request": buildChangeResponse(updated),
+	})
+}
+
+func (h *DestinationHandler) listVersions(c echo.Context) error {
+	destinationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid destination id"))
+	}
+	limit, _ := parsePagination(c, 50, 0)
+
+	versions, err := h.workflow.ListVersions(c.Request().Context(), destinationID, limit)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+
+	payload := make([]util.Envelope, 0, len(versions))
+	for i := range versions {
+		payload = append(payload, buildVersionResponse(&versions[i]))
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"versions": payload,
+		"meta": util.Envelope{
+			"limit": limit,
+			"count": len(payload),
+		},
+	})
+}
+
+func (h *DestinationHandler) diffVersions(c echo.Context) error {
+	destinationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid destination id"))
+	}
+	from, err := strconv.ParseInt(strings.TrimSpace(c.QueryParam("from")), 10, 64)
+	if err != nil || from <= 0 {
+		return c.JSON(http.StatusBadRequest, util.Error("from must be a positive version number"))
+	}
+	to, err := strconv.ParseInt(strings.TrimSpace(c.QueryParam("to")), 10, 64)
+	if err != nil || to <= 0 {
+		return c.JSON(http.StatusBadRequest, util.Error("to must be a positive version number"))
+	}
+
+	diff, err := h.workflow.DiffVersions(c.Request().Context(), destinationID, from, to)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"diff": diff,
+	})
+}
+
+func (h *DestinationHandler) rollbackDestination(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+
+	destinationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid destination id"))
+	}
+
+	var req struct {
+		Version int64 `json:"version"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+	if req.Version <= 0 {
+		return c.JSON(http.StatusBadRequest, util.Error("version must be a positive integer"))
+	}
+
+	change, destination, err := h.workflow.Rollback(c.Request().Context(), destinationID, req.Version, user.ID)
+	if err != nil {
+		return h.writeChangeError(c, err)
+	}
+
+	if destination == nil {
+		return c.JSON(http.StatusAccepted, util.Envelope{
+			"change_request": buildChangeResponse(change),
+			"message":        "Rollback submitted for review",
+		})
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"change_request": buildChangeResponse(change),
+		"destination":    buildDestinationResponse(destination),
+		"message":        "Destination rolled back successfully",
+	})
+}
+
+// listPublished pages by ?offset= or, when ?cursor= is given, by keyset after
+// the cursor. Either way meta.next_cursor continues the listing. ?facets=
+// adds counts per category, country, city or rating band. Responses carry an
+// ETag over the catalogue version and query, so unchanged polls get a 304.
 func (h *DestinationHandler) listPublished(c echo.Context) error {
 	if !h.features.View {
 		return c.JSON(http.StatusNotFound, util.Error("resource not found"))
@@ -463,25 +982,135 @@ func (h *DestinationHandler) listPublished(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
 	}
-	destinations, err := h.destinations.ListPublished(c.Request().Context(), limit, offset, filter)
+	facets, err := parseFacets(c.QueryParam("facets"))
 	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	}
+	locale := h.resolveLocale(c)
+	// The catalogue version is far cheaper than the listing, so polling
+	// clients with a current copy are answered before any search runs. Open
+	// filters depend on the clock as well as the catalogue, so they are never
+	// answered from it.
+	if filter.OpenAt == nil {
+		version, err := h.destinations.CatalogVersion(c.Request().Context())
+		if err != nil {
+			log.Printf("destination catalogue version: %v", err)
+		} else if notModified(c, catalogETag(version, c.QueryParams(), locale), version.UpdatedAt, catalogCacheControl) {
+			return c.NoContent(http.StatusNotModified)
+		}
+	}
+	cursor := strings.TrimSpace(c.QueryParam("cursor"))
+	page, err := h.destinations.ListPublishedPage(c.Request().Context(), limit, offset, cursor, filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+		}
 		return c.JSON(http.StatusInternalServerError, util.Error("unable to list destinations"))
 	}
-	payload := make([]util.Envelope, 0, len(destinations))
-	for i := range destinations {
-		payload = append(payload, buildDestinationResponse(&destinations[i]))
+	payload := make([]util.Envelope, 0, len(page.Destinations))
+	for i := range page.Destinations {
+		payload = append(payload, buildDestinationResponse(h.destinations.Localize(&page.Destinations[i], locale)))
+	}
+	meta := util.Envelope{
+		"limit":       limit,
+		"count":       len(payload),
+		"locale":      locale,
+		"next_cursor": nil,
+	}
+	if page.NextCursor != "" {
+		meta["next_cursor"] = page.NextCursor
+	}
+	if cursor == "" {
+		meta["offset"] = offset
+	}
+	if page.Total != nil {
+		meta["total"] = *page.Total
+	}
+	response := util.Envelope{
+		"destinations": payload,
+		"meta":         meta,
+	}
+	if len(facets) > 0 {
+		counts, err := h.destinations.Facets(c.Request().Context(), filter, facets)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, util.Error("unable to count facets"))
+		}
+		facetPayload := util.Envelope{}
+		for facet, values := range counts {
+			facetPayload[string(facet)] = values
+		}
+		response["facets"] = facetPayload
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// highlightHTML turns a search headline into HTML: the description text is
+// escaped and matched words are wrapped in <mark>. Headlines without a match
+// yield "".
+func highlightHTML(headline *string) string {
+	if headline == nil || !strings.Contains(*headline, domain.SearchHighlightStart) {
+		return ""
+	}
+	return strings.NewReplacer(
+		domain.SearchHighlightStart, "<mark>",
+		domain.SearchHighlightStop, "</mark>",
+	).Replace(html.EscapeString(*headline))
+}
+
+// parseFacets reads the comma-separated ?facets= list.
+func parseFacets(raw string) ([]domain.DestinationFacet, error) {
+	facets := make([]domain.DestinationFacet, 0)
+	for _, part := range strings.Split(raw, ",") {
+		trimmed := strings.ToLower(strings.TrimSpace(part))
+		if trimmed == "" {
+			continue
+		}
+		facet := domain.DestinationFacet(trimmed)
+		if !facet.IsValid() {
+			return nil, fmt.Errorf("unknown facet %q; use category, country, city or rating", trimmed)
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
+// mapDestinations returns map markers for the ?bbox= viewport at ?zoom=. It
+// accepts the same filters as the list endpoint.
+func (h *DestinationHandler) mapDestinations(c echo.Context) error {
+	if !h.features.View {
+		return c.JSON(http.StatusNotFound, util.Error("resource not found"))
+	}
+	filter, err := parseDestinationListFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	}
+	zoom, err := strconv.Atoi(strings.TrimSpace(c.QueryParam("zoom")))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("zoom must be an integer"))
+	}
+	result, err := h.destinations.Map(c.Request().Context(), filter, zoom)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMapQuery) {
+			return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, util.Error("unable to load map"))
+	}
+	mode := "points"
+	if result.Clustered {
+		mode = "clusters"
 	}
 	total := 0
-	if len(destinations) > 0 {
-		total = destinations[0].TotalCount
+	for _, marker := range result.Markers {
+		total += marker.Count
 	}
 	return c.JSON(http.StatusOK, util.Envelope{
-		"destinations": payload,
+		"markers": result.Markers,
 		"meta": util.Envelope{
-			"limit":  limit,
-			"offset": offset,
-			"count":  len(payload),
-			"total":  total,
+			"zoom":  result.Zoom,
+			"mode":  mode,
+			"count": len(result.Markers),
+			"total": total,
+			"bbox":  []float64{filter.BBox.MinLat, filter.BBox.MinLng, filter.BBox.MaxLat, filter.BBox.MaxLng},
 		},
 	})
 }
@@ -490,9 +1119,108 @@ func (h *DestinationHandler) getDestination(c echo.Context) error {
 	if !h.features.View {
 		return c.JSON(http.StatusNotFound, util.Error("resource not found"))
 	}
+	dest, err := h.loadPublished(c)
+	if dest == nil {
+		return err
+	}
+	locale := h.resolveLocale(c)
+	// updated_at misses new reviews and open_now, so the etag alone validates.
+	if notModified(c, destinationETag(dest, locale, time.Now()), time.Time{}, destinationCacheControl) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"destination": buildDestinationResponse(h.destinations.Localize(dest, locale)),
+		"locale":      locale,
+	})
+}
+
+func (h *DestinationHandler) nearbyDestinations(c echo.Context) error {
+	if !h.features.View {
+		return c.JSON(http.StatusNotFound, util.Error("resource not found"))
+	}
+	radiusKM := defaultNearbyRadiusKM
+	if v := strings.TrimSpace(c.QueryParam("radius_km")); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 || parsed > maxNearbyRadiusKM {
+			return c.JSON(http.StatusBadRequest, util.Error(fmt.Sprintf("radius_km must be greater than 0 and at most %g", maxNearbyRadiusKM)))
+		}
+		radiusKM = parsed
+	}
+	limit := relatedLimit(c)
+
+	dest, err := h.loadPublished(c)
+	if dest == nil {
+		return err
+	}
+	nearby, err := h.destinations.Nearby(c.Request().Context(), dest, radiusKM, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrDestinationLocationMissing) {
+			return c.JSON(http.StatusUnprocessableEntity, util.Error("destination has no coordinates"))
+		}
+		return c.JSON(http.StatusInternalServerError, util.Error("unable to list nearby destinations"))
+	}
+	locale := h.resolveLocale(c)
+	payload := make([]util.Envelope, 0, len(nearby))
+	for i := range nearby {
+		item := buildDestinationResponse(h.destinations.Localize(&nearby[i], locale))
+		if nearby[i].DistanceKM != nil {
+			item["distance_km"] = math.Round(*nearby[i].DistanceKM*100) / 100
+		}
+		payload = append(payload, item)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"destinations": payload,
+		"meta": util.Envelope{
+			"destination_id": dest.ID,
+			"radius_km":      radiusKM,
+			"count":          len(payload),
+			"locale":         locale,
+		},
+	})
+}
+
+func (h *DestinationHandler) similarDestinations(c echo.Context) error {
+	if !h.features.View {
+		return c.JSON(http.StatusNotFound, util.Error("resource not found"))
+	}
+	limit := relatedLimit(c)
+
+	dest, err := h.loadPublished(c)
+	if dest == nil {
+		return err
+	}
+	similar, err := h.destinations.Similar(c.Request().Context(), dest, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, util.Error("unable to list similar destinations"))
+	}
+	locale := h.resolveLocale(c)
+	payload := make([]util.Envelope, 0, len(similar))
+	for i := range similar {
+		item := buildDestinationResponse(h.destinations.Localize(&similar[i].Destination, locale))
+		item["similarity_score"] = math.Round(similar[i].Score*1000) / 1000
+		if similar[i].Destination.DistanceKM != nil {
+			item["distance_km"] = math.Round(*similar[i].Destination.DistanceKM*100) / 100
+		}
+		payload = append(payload, item)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"destinations": payload,
+		"meta": util.Envelope{
+			"destination_id": dest.ID,
+			"count":          len(payload),
+			"locale":         locale,
+		},
+	})
+}
+
+// loadPublished finds the published destination named by the :id parameter,
+// which may be a UUID or a slug. A retired slug is answered with a redirect to
+// the canonical one. When it returns nil the response has already been
+// written.
+func (h *DestinationHandler) loadPublished(c echo.Context) (*domain.Destination, error) {
 	key := strings.TrimSpace(c.Param("id"))
 	if key == "" {
-		return c.JSON(http.StatusBadRequest, util.Error("identifier required"))
+		return nil, c.JSON(http.StatusBadRequest, util.Error("identifier required"))
 	}
 
 	var (
@@ -506,25 +1234,98 @@ func (h *DestinationHandler) getDestination(c echo.Context) error {
 	}
 	if err != nil {
 		if errors.Is(err, service.ErrDestinationNotFound) {
-			return c.JSON(http.StatusNotFound, util.Error("destination not found"))
+			return nil, c.JSON(http.StatusNotFound, util.Error("destination not found"))
 		}
-		return c.JSON(http.StatusInternalServerError, util.Error("unable to load destination"))
+		return nil, c.JSON(http.StatusInternalServerError, util.Error("unable to load destination"))
 	}
-	return c.JSON(http.StatusOK, util.Envelope{
-		"destination": buildDestinationResponse(dest),
+	if dest.RetiredSlug != nil {
+		return nil, redirectToCanonicalSlug(c, dest)
+	}
+	return dest, nil
+}
+
+// redirectToCanonicalSlug answers a request addressed by a retired slug with a
+// 301 to the same path under the destination's current slug. The body carries
+// the canonical slug for clients that do not follow redirects.
+func redirectToCanonicalSlug(c echo.Context, dest *domain.Destination) error {
+	canonical := ""
+	if dest.Slug != nil {
+		canonical = *dest.Slug
+	}
+	location := c.Request().URL.Path
+	if canonical != "" {
+		segments := strings.Split(location, "/")
+		for idx, segment := range segments {
+			if segment == *dest.RetiredSlug {
+				segments[idx] = canonical
+				break
+			}
+		}
+		location = strings.Join(segments, "/")
+	} else {
+		location = strings.Replace(location, "/"+*dest.RetiredSlug, "/"+dest.ID.String(), 1)
+	}
+	if query := c.Request().URL.RawQuery; query != "" {
+		location += "?" + query
+	}
+	c.Response().Header().Set(echo.HeaderLocation, location)
+	return c.JSON(http.StatusMovedPermanently, util.Envelope{
+		"destination_id": dest.ID,
+		"canonical_slug": canonical,
+		"location":       location,
 	})
 }
 
+// relatedLimit reads ?limit= for the nearby and similar rails.
+func relatedLimit(c echo.Context) int {
+	limit, _ := parsePagination(c, defaultRelatedLimit, 0)
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+	return limit
+}
+
+// resolveLocale picks the content language from ?lang= or Accept-Language and
+// advertises it on the response.
+func (h *DestinationHandler) resolveLocale(c echo.Context) string {
+	locale := h.destinations.ResolveLocale(c.QueryParam("lang"), c.Request().Header.Get("Accept-Language"))
+	c.Response().Header().Set("Content-Language", locale)
+	c.Response().Header().Add("Vary", "Accept-Language")
+	return locale
+}
+
 func (h *DestinationHandler) writeChangeError(c echo.Context, err error) error {
+	var conflictErr *service.ChangeConflictError
+	if errors.As(err, &conflictErr) {
+		return c.JSON(http.StatusConflict, util.Envelope{
+			"error":           conflictErr.Error(),
+			"base_version":    conflictErr.BaseVersion,
+			"current_version": conflictErr.CurrentVersion,
+			"conflicts":       conflictErr.Conflicts,
+		})
+	}
+
 	switch {
 	case errors.Is(err, service.ErrDestinationChangeNotFound):
 		return c.JSON(http.StatusNotFound, util.Error("change request not found"))
 	case errors.Is(err, service.ErrDestinationNotFound):
 		return c.JSON(http.StatusNotFound, util.Error("destination not found"))
+	case errors.Is(err, service.ErrGalleryItemNotFound):
+		return c.JSON(http.StatusNotFound, util.Error("gallery item not found"))
+	case errors.Is(err, service.ErrCommentNotFound):
+		return c.JSON(http.StatusNotFound, util.Error("comment not found"))
+	case errors.Is(err, service.ErrCommentValidation):
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	case errors.Is(err, service.ErrDestinationVersionNotFound):
+		return c.JSON(http.StatusNotFound, util.Error("destination version not found"))
 	case errors.Is(err, service.ErrForbidden):
 		return c.JSON(http.StatusForbidden, util.Error("forbidden"))
 	case errors.Is(err, service.ErrInvalidChangeState):
 		return c.JSON(http.StatusConflict, util.Error(err.Error()))
+	case errors.Is(err, service.ErrDestinationSlugTaken):
+		return c.JSON(http.StatusConflict, util.Error(err.Error()))
+	case errors.Is(err, service.ErrAlreadyReviewed):
+		return c.JSON(http.StatusConflict, util.Error(err.Error()))
 	case errors.Is(err, service.ErrReviewerConflict):
 		return c.JSON(http.StatusForbidden, util.Error(err.Error()))
 	case errors.Is(err, service.ErrHardDeleteNotAllowed):
@@ -548,6 +1349,8 @@ func (h *DestinationHandler) isActionEnabled(action domain.DestinationChangeActi
 		return h.features.Update
 	case domain.DestinationChangeActionDelete:
 		return h.features.Delete
+	case domain.DestinationChangeActionRestore:
+		return h.features.Update
 	default:
 		return false
 	}
@@ -600,6 +1403,8 @@ func parseChangeAction(raw string) (domain.DestinationChangeAction, error) {
 		return domain.DestinationChangeActionUpdate, nil
 	case "delete":
 		return domain.DestinationChangeActionDelete, nil
+	case "restore":
+		return domain.DestinationChangeActionRestore, nil
 	default:
 		return "", errors.New("invalid action")
 	}
@@ -647,6 +1452,9 @@ func buildDestinationResponse(dest *domain.Destination) util.Envelope {
 	if dest.Description != nil {
 		resp["description"] = *dest.Description
 	}
+	if snippet := highlightHTML(dest.SearchHeadline); snippet != "" {
+		resp["highlights"] = util.Envelope{"description": snippet}
+	}
 	if dest.Contact != nil {
 		resp["contact"] = *dest.Contact
 	}
@@ -656,6 +1464,19 @@ func buildDestinationResponse(dest *domain.Destination) util.Envelope {
 	if dest.ClosingTime != nil {
 		resp["closing_time"] = *dest.ClosingTime
 	}
+	if dest.Timezone != nil {
+		resp["timezone"] = *dest.Timezone
+	}
+	if dest.OpeningHours != nil && !dest.OpeningHours.IsEmpty() {
+		resp["opening_hours"] = *dest.OpeningHours
+		resp["open_now"] = service.DestinationOpenAt(dest, time.Now())
+	}
+	if len(dest.Translations) > 0 {
+		resp["translations"] = dest.Translations
+	}
+	if len(dest.Tags) > 0 {
+		resp["tags"] = dest.Tags
+	}
 	if dest.Latitude != nil {
 		resp["latitude"] = *dest.Latitude
 	}
@@ -679,6 +1500,31 @@ func buildDestinationResponse(dest *domain.Destination) util.Envelope {
 	return resp
 }
 
+// parseBoundingBox reads "min_lat,min_lng,max_lat,max_lng". min_lng may be
+// greater than max_lng for a viewport crossing the antimeridian.
+func parseBoundingBox(raw string) (*domain.DestinationBoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("bbox must be min_lat,min_lng,max_lat,max_lng")
+	}
+	values := make([]float64, 4)
+	for idx, part := range parts {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, errors.New("bbox must be min_lat,min_lng,max_lat,max_lng")
+		}
+		values[idx] = parsed
+	}
+	box := &domain.DestinationBoundingBox{MinLat: values[0], MinLng: values[1], MaxLat: values[2], MaxLng: values[3]}
+	if box.MinLat < -90 || box.MaxLat > 90 || box.MinLat > box.MaxLat {
+		return nil, errors.New("bbox latitudes must be between -90 and 90 with min_lat <= max_lat")
+	}
+	if box.MinLng < -180 || box.MinLng > 180 || box.MaxLng < -180 || box.MaxLng > 180 {
+		return nil, errors.New("bbox longitudes must be between -180 and 180")
+	}
+	return box, nil
+}
+
 func parseDestinationListFilter(c echo.Context) (domain.DestinationListFilter, error) {
 	filter := domain.DestinationListFilter{
 		Search: strings.TrimSpace(c.QueryParam("query")),
@@ -704,6 +1550,31 @@ func parseDestinationListFilter(c echo.Context) (domain.DestinationListFilter, e
 		filter.Categories = categories
 	}
 
+	tags := make([]string, 0)
+	if raw := c.QueryParam("tags"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			if trimmed := strings.TrimSpace(part); trimmed != "" {
+				tags = append(tags, trimmed)
+			}
+		}
+	}
+	for _, part := range c.QueryParams()["tag"] {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			tags = append(tags, trimmed)
+		}
+	}
+	if len(tags) > 0 {
+		filter.Tags = tags
+	}
+	switch match := strings.ToLower(strings.TrimSpace(c.QueryParam("tag_match"))); match {
+	case "", string(domain.DestinationTagMatchAny):
+		filter.TagMatch = domain.DestinationTagMatchAny
+	case string(domain.DestinationTagMatchAll):
+		filter.TagMatch = domain.DestinationTagMatchAll
+	default:
+		return domain.DestinationListFilter{}, errors.New("tag_match must be any or all")
+	}
+
 	if v := strings.TrimSpace(c.QueryParam("min_rating")); v != "" {
 		parsed, err := strconv.ParseFloat(v, 64)
 		if err != nil {
@@ -741,8 +1612,10 @@ func parseDestinationListFilter(c echo.Context) (domain.DestinationListFilter, e
 			filter.Sort = domain.DestinationSortNameDesc
 		case string(domain.DestinationSortUpdatedAtDesc), "updated", "recent":
 			filter.Sort = domain.DestinationSortUpdatedAtDesc
-		case string(domain.DestinationSortSimilarity), "relevance", "relevant":
+		case string(domain.DestinationSortSimilarity):
 			filter.Sort = domain.DestinationSortSimilarity
+		case string(domain.DestinationSortRelevance), "relevant":
+			filter.Sort = domain.DestinationSortRelevance
 		case string(domain.DestinationSortDistanceAsc), "nearby":
 			filter.Sort = domain.DestinationSortDistanceAsc
 		default:
@@ -791,6 +1664,31 @@ func parseDestinationListFilter(c echo.Context) (domain.DestinationListFilter, e
 		filter.MaxDistanceKM = &parsed
 	}
 
+	if v := strings.TrimSpace(c.QueryParam("bbox")); v != "" {
+		box, err := parseBoundingBox(v)
+		if err != nil {
+			return domain.DestinationListFilter{}, err
+		}
+		filter.BBox = box
+	}
+
+	if v := strings.TrimSpace(c.QueryParam("open_at")); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return domain.DestinationListFilter{}, errors.New("open_at must be an RFC3339 timestamp")
+		}
+		filter.OpenAt = &parsed
+	} else if v := strings.TrimSpace(c.QueryParam("open_now")); v != "" {
+		openNow, err := strconv.ParseBool(v)
+		if err != nil {
+			return domain.DestinationListFilter{}, errors.New("open_now must be true or false")
+		}
+		if openNow {
+			now := time.Now()
+			filter.OpenAt = &now
+		}
+	}
+
 	if (filter.Latitude == nil) != (filter.Longitude == nil) {
 		return domain.DestinationListFilter{}, errors.New("both lat and lng must be provided for geo search")
 	}
@@ -836,6 +1734,9 @@ func buildChangeResponse(change *domain.DestinationChangeRequest) util.Envelope
 	if change.PublishedVersion != nil {
 		resp["published_version"] = *change.PublishedVersion
 	}
+	if change.BaseVersion != nil {
+		resp["base_version"] = *change.BaseVersion
+	}
 	return resp
 }
 
@@ -932,6 +1833,18 @@ func buildChangeFields(fields domain.DestinationChangeFields) util.Envelope {
 	if fields.ClosingTime != nil {
 		resp["closing_time"] = *fields.ClosingTime
 	}
+	if fields.Timezone != nil {
+		resp["timezone"] = *fields.Timezone
+	}
+	if fields.OpeningHours != nil {
+		resp["opening_hours"] = *fields.OpeningHours
+	}
+	if fields.Translations != nil {
+		resp["translations"] = *fields.Translations
+	}
+	if fields.Tags != nil {
+		resp["tags"] = *fields.Tags
+	}
 	if fields.Latitude != nil {
 		resp["latitude"] = *fields.Latitude
 	}
@@ -953,8 +1866,35 @@ func buildChangeFields(fields domain.DestinationChangeFields) util.Envelope {
 	if fields.PublishedHeroImage != nil {
 		resp["published_hero_image"] = *fields.PublishedHeroImage
 	}
+	if len(fields.RemovedMedia) > 0 {
+		resp["removed_media"] = fields.RemovedMedia
+	}
 	if fields.HardDelete != nil {
 		resp["hard_delete"] = *fields.HardDelete
 	}
+	if fields.SourceVersion != nil {
+		resp["source_version"] = *fields.SourceVersion
+	}
+	return resp
+}
+
+func buildVersionResponse(version *domain.DestinationVersion) util.Envelope {
+	if version == nil {
+		return util.Envelope{}
+	}
+	resp := util.Envelope{
+		"id":             version.ID,
+		"destination_id": version.DestinationID,
+		"version":        version.Version,
+		"snapshot":       version.Snapshot,
+		"created_at":     version.CreatedAt,
+		"created_by":     version.CreatedBy,
+	}
+	if version.ChangeRequestID != nil {
+		resp["change_request_id"] = *version.ChangeRequestID
+	}
+	if version.SourceVersion != nil {
+		resp["source_version"] = *version.SourceVersion
+	}
 	return resp
 }
@@ -1,10 +1,17 @@
 package http
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
@@ -65,3 +72,250 @@ func TestParseDestinationListFilterInvalidRating(t *testing.T) {
 		t.Fatal("expected error for invalid rating range, got nil")
 	}
 }
+
+func TestParseDestinationListFilterOpenAt(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/destinations?open_at=2024-07-15T18:00:00%2B07:00&open_now=true", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	filter, err := parseDestinationListFilter(c)
+	if err != nil {
+		t.Fatalf("parseDestinationListFilter returned error: %v", err)
+	}
+	if filter.OpenAt == nil || filter.OpenAt.UTC().Hour() != 11 {
+		t.Fatalf("expected open_at to win, got %v", filter.OpenAt)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/destinations?open_at=tonight", nil)
+	if _, err = parseDestinationListFilter(e.NewContext(req, httptest.NewRecorder())); err == nil {
+		t.Fatal("expected error for invalid open_at")
+	}
+}
+
+func TestParseDestinationListFilterTags(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/destinations?tags=parking,+showers&tag=wifi&tag_match=ALL", nil)
+	filter, err := parseDestinationListFilter(e.NewContext(req, httptest.NewRecorder()))
+	if err != nil {
+		t.Fatalf("parseDestinationListFilter returned error: %v", err)
+	}
+	if len(filter.Tags) != 3 || filter.Tags[1] != "showers" || filter.Tags[2] != "wifi" {
+		t.Fatalf("unexpected tags: %v", filter.Tags)
+	}
+	if filter.TagMatch != domain.DestinationTagMatchAll {
+		t.Fatalf("expected all match, got %q", filter.TagMatch)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/destinations?tags=parking&tag_match=some", nil)
+	if _, err = parseDestinationListFilter(e.NewContext(req, httptest.NewRecorder())); err == nil {
+		t.Fatal("expected error for invalid tag_match")
+	}
+}
+
+func TestParseDestinationListFilterBBox(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/destinations?bbox=-20,170,-10,-170", nil)
+	filter, err := parseDestinationListFilter(e.NewContext(req, httptest.NewRecorder()))
+	if err != nil {
+		t.Fatalf("parseDestinationListFilter returned error: %v", err)
+	}
+	if filter.BBox == nil || filter.BBox.MinLng != 170 || !filter.BBox.CrossesAntimeridian() {
+		t.Fatalf("unexpected bbox: %+v", filter.BBox)
+	}
+
+	for _, raw := range []string{"1,2,3", "10,0,5,1", "0,0,1,200", "a,b,c,d"} {
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/destinations?bbox="+raw, nil)
+		if _, err = parseDestinationListFilter(e.NewContext(req, httptest.NewRecorder())); err == nil {
+			t.Fatalf("expected error for bbox %q", raw)
+		}
+	}
+}
+
+func TestParseFacets(t *testing.T) {
+	facets, err := parseFacets(" Category, rating,,city ")
+	if err != nil {
+		t.Fatalf("parseFacets returned error: %v", err)
+	}
+	if len(facets) != 3 || facets[0] != domain.DestinationFacetCategory || facets[2] != domain.DestinationFacetCity {
+		t.Fatalf("unexpected facets: %v", facets)
+	}
+	if _, err := parseFacets("category,price"); err == nil {
+		t.Fatalf("expected unknown facet to be rejected")
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	headline := "Tea at <b>" + domain.SearchHighlightStart + "Wat" + domain.SearchHighlightStop + "</b> & more"
+	if got := highlightHTML(&headline); got != "Tea at &lt;b&gt;<mark>Wat</mark>&lt;/b&gt; &amp; more" {
+		t.Fatalf("unexpected highlight %q", got)
+	}
+	plain := "No match here"
+	if got := highlightHTML(&plain); got != "" {
+		t.Fatalf("expected headlines without a match to be dropped, got %q", got)
+	}
+	if got := highlightHTML(nil); got != "" {
+		t.Fatalf("expected empty highlight, got %q", got)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	e := echo.New()
+	updated := time.Date(2024, 7, 18, 9, 30, 15, 500, time.UTC)
+	dest := &domain.Destination{ID: uuid.New(), Version: 3, UpdatedAt: updated}
+	etag := destinationETag(dest, "en", updated)
+	if etag == destinationETag(dest, "th", updated) {
+		t.Fatalf("expected locales to have distinct etags")
+	}
+	reviewed := *dest
+	reviewed.ReviewCount, reviewed.AverageRating = 1, 4
+	if destinationETag(&reviewed, "en", updated) == etag {
+		t.Fatalf("expected a new review to change the destination etag")
+	}
+	hours := domain.OpeningHours{Weekly: []domain.OpeningPeriod{{Day: "thu", Open: "09:00", Close: "17:00"}}}
+	withHours := *dest
+	withHours.OpeningHours = &hours
+	morning, evening := time.Date(2024, 7, 18, 10, 0, 0, 0, time.UTC), time.Date(2024, 7, 18, 18, 0, 0, 0, time.UTC)
+	if destinationETag(&withHours, "en", morning) == destinationETag(&withHours, "en", evening) {
+		t.Fatalf("expected open_now changing to change the etag")
+	}
+	if destinationETag(dest, "en", morning) != destinationETag(dest, "en", evening) {
+		t.Fatalf("expected the etag of a destination without hours not to depend on the time")
+	}
+
+	check := func(header, value string) (bool, http.Header) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/destinations/x", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		return notModified(e.NewContext(req, rec), etag, updated, destinationCacheControl), rec.Header()
+	}
+
+	fresh, headers := check("", "")
+	if fresh || headers.Get("ETag") != etag || headers.Get("Cache-Control") != destinationCacheControl {
+		t.Fatalf("expected validators without preconditions, got %v %v", fresh, headers)
+	}
+	if headers.Get("Last-Modified") != "Thu, 18 Jul 2024 09:30:15 GMT" {
+		t.Fatalf("unexpected Last-Modified %q", headers.Get("Last-Modified"))
+	}
+	if fresh, _ := check("If-None-Match", `"stale", `+strings.TrimPrefix(etag, "W/")); !fresh {
+		t.Fatalf("expected weak comparison to match the strong form")
+	}
+	if fresh, _ := check("If-None-Match", `W/"stale"`); fresh {
+		t.Fatalf("expected a different etag to miss")
+	}
+	if fresh, _ := check("If-Modified-Since", "Thu, 18 Jul 2024 09:30:15 GMT"); !fresh {
+		t.Fatalf("expected an unchanged date to match")
+	}
+	if fresh, _ := check("If-Modified-Since", "Thu, 18 Jul 2024 09:30:14 GMT"); fresh {
+		t.Fatalf("expected an earlier date to miss")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/destinations/x", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 18 Jul 2024 09:30:15 GMT")
+	rec := httptest.NewRecorder()
+	if notModified(e.NewContext(req, rec), etag, time.Time{}, destinationCacheControl) || rec.Header().Get("Last-Modified") != "" {
+		t.Fatalf("expected etag-only validation to ignore If-Modified-Since and send no Last-Modified")
+	}
+
+	version := domain.DestinationCatalogVersion{UpdatedAt: updated, Destinations: 10}
+	query := url.Values{"sort": {"rating"}, "limit": {"5"}}
+	reordered := url.Values{"limit": {"5"}, "sort": {"rating"}}
+	before := catalogETag(version, query, "en")
+	if before != catalogETag(version, reordered, "en") {
+		t.Fatalf("expected parameter order not to matter")
+	}
+	version.Reviews++
+	if catalogETag(version, query, "en") == before {
+		t.Fatalf("expected a new review to change the listing etag")
+	}
+}
+
+func TestGeoExportWriters(t *testing.T) {
+	lat, lng := 13.75, 100.4913
+	slug, hero := "grand-palace", "https://cdn.local/palace.jpg"
+	dests := []*domain.Destination{
+		{ID: uuid.New(), Name: "Grand Palace", Slug: &slug, Latitude: &lat, Longitude: &lng, HeroImage: &hero, AverageRating: 4.5, ReviewCount: 2},
+		{ID: uuid.New(), Name: "Tom & Jerry's <Cafe>", Latitude: &lat, Longitude: &lng},
+	}
+	write := func(format geoFormatWriter) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := format.Header(&buf); err != nil {
+			t.Fatalf("Header: %v", err)
+		}
+		for idx, dest := range dests {
+			if err := format.Feature(&buf, idx == 0, dest); err != nil {
+				t.Fatalf("Feature: %v", err)
+			}
+		}
+		if err := format.Footer(&buf); err != nil {
+			t.Fatalf("Footer: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(write(geoJSONWriter{}), &collection); err != nil {
+		t.Fatalf("invalid GeoJSON: %v", err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("unexpected collection: %+v", collection)
+	}
+	first := collection.Features[0]
+	if first.Geometry.Coordinates[0] != lng || first.Geometry.Coordinates[1] != lat {
+		t.Fatalf("expected [lng, lat], got %v", first.Geometry.Coordinates)
+	}
+	if first.Properties["slug"] != slug || first.Properties["hero_image_url"] != hero || first.Properties["average_rating"] != 4.5 {
+		t.Fatalf("unexpected properties: %v", first.Properties)
+	}
+
+	var kml struct {
+		Placemarks []struct {
+			Name        string `xml:"name"`
+			Coordinates string `xml:"Point>coordinates"`
+		} `xml:"Document>Placemark"`
+	}
+	if err := xml.Unmarshal(write(kmlWriter{}), &kml); err != nil {
+		t.Fatalf("invalid KML: %v", err)
+	}
+	if len(kml.Placemarks) != 2 || kml.Placemarks[1].Name != dests[1].Name || kml.Placemarks[0].Coordinates != "100.4913,13.75" {
+		t.Fatalf("unexpected placemarks: %+v", kml.Placemarks)
+	}
+}
+
+func TestParseDestinationExportFilter(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/destinations/export?status=draft,Archived&category=Nature&category=Museum,%20Park&updated_since=2024-07-01", nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	filter, err := parseDestinationExportFilter(c)
+	if err != nil {
+		t.Fatalf("parseDestinationExportFilter returned error: %v", err)
+	}
+	if len(filter.Statuses) != 2 || filter.Statuses[0] != domain.DestinationStatusDraft || filter.Statuses[1] != domain.DestinationStatusArchived {
+		t.Fatalf("unexpected statuses %v", filter.Statuses)
+	}
+	if strings.Join(filter.Categories, "|") != "Nature|Museum|Park" {
+		t.Fatalf("unexpected categories %v", filter.Categories)
+	}
+	if filter.UpdatedSince == nil || !filter.UpdatedSince.Equal(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected updated_since %v", filter.UpdatedSince)
+	}
+
+	for _, query := range []string{"status=deleted", "updated_since=yesterday"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/destinations/export?"+query, nil)
+		if _, err := parseDestinationExportFilter(e.NewContext(req, httptest.NewRecorder())); err == nil {
+			t.Fatalf("expected error for %s", query)
+		}
+	}
+}
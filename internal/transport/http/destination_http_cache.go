@@ -0,0 +1,98 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/service"
+)
+
+const (
+	// Shared caches may reuse a response briefly; after that clients
+	// revalidate, which costs a 304 when nothing changed.
+	destinationCacheControl = "public, max-age=60, must-revalidate"
+	catalogCacheControl     = "public, max-age=30, must-revalidate"
+)
+
+// destinationETag identifies one destination's representation in locale at
+// now. The version moves on every approved change; the review aggregate and,
+// for destinations with opening hours, open_now change without it.
+func destinationETag(dest *domain.Destination, locale string, now time.Time) string {
+	parts := []string{
+		dest.ID.String(),
+		strconv.FormatInt(dest.Version, 10),
+		dest.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		strconv.FormatFloat(dest.AverageRating, 'g', -1, 64),
+		strconv.Itoa(dest.ReviewCount),
+		locale,
+	}
+	if dest.OpeningHours != nil && !dest.OpeningHours.IsEmpty() {
+		parts = append(parts, "open="+strconv.FormatBool(service.DestinationOpenAt(dest, now)))
+	}
+	return weakETag(parts...)
+}
+
+// catalogETag identifies a listing: the same query over the same catalogue
+// version yields the same page.
+func catalogETag(version domain.DestinationCatalogVersion, query url.Values, locale string) string {
+	return weakETag(
+		version.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		strconv.Itoa(version.Destinations),
+		strconv.Itoa(version.Reviews),
+		strconv.Itoa(version.Categories),
+		query.Encode(),
+		locale,
+	)
+}
+
+// weakETag hashes parts into a weak validator. It is weak because equal tags
+// promise equal content, not byte-identical JSON.
+func weakETag(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified sets the caching headers for a response validated by etag and
+// lastModified, and reports whether the request's If-None-Match or, without
+// it, If-Modified-Since shows the client already has that response. A zero
+// lastModified sends no Last-Modified and validates by etag alone.
+func notModified(c echo.Context, etag string, lastModified time.Time, cacheControl string) bool {
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	if !lastModified.IsZero() {
+		header.Set(echo.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+	}
+	header.Set("Cache-Control", cacheControl)
+
+	req := c.Request()
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		return etagMatches(match, etag)
+	}
+	if since := req.Header.Get(echo.HeaderIfModifiedSince); since != "" && !lastModified.IsZero() {
+		at, err := http.ParseTime(since)
+		// HTTP dates have whole seconds.
+		return err == nil && !lastModified.Truncate(time.Second).After(at)
+	}
+	return false
+}
+
+// etagMatches applies the weak comparison If-None-Match calls for to a list
+// of entity tags.
+func etagMatches(header, etag string) bool {
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -42,16 +42,19 @@ func RegisterDestinationImports(e *echo.Echo, auth *service.AuthService, svc *se
 
 func (h *DestinationImportHandler) template(c echo.Context) error {
 	headers := []string{
-		"slug", "name", "status", "category", "city", "country", "description",
+		"action", "id", "slug", "name", "status", "category", "city", "country", "description",
 		"latitude", "longitude", "contact", "opening_time", "closing_time",
+		"timezone", "opening_hours", "tags", "name_th", "description_th",
 		"hero_image_url", "gallery_1_url", "gallery_1_caption",
 		"gallery_2_url", "gallery_2_caption", "gallery_3_url", "gallery_3_caption",
 		"hero_image_upload_id", "published_hero_image",
 	}
 	sampleRow := []string{
-		"central-park", "Central Park", "published", "Nature", "New York", "USA",
+		"create", "", "central-park", "Central Park", "published", "Nature", "New York", "USA",
 		"Iconic urban park with year-round programming.", "40.785091", "-73.968285",
 		"+1 212-310-6600", "06:00", "22:00",
+		"America/New_York", "mon-sun 06:00-01:00; 2024-12-25 closed", "parking|wheelchair-access|restrooms",
+		"เซ็นทรัลพาร์ก", "สวนสาธารณะกลางเมืองที่มีกิจกรรมตลอดทั้งปี",
 		"https://cdn.fitcity/destinations/central-park/hero.jpg",
 		"https://cdn.fitcity/destinations/central-park/gallery-1.jpg", "Bethesda Fountain",
 		"https://cdn.fitcity/destinations/central-park/gallery-2.jpg", "Bow Bridge",
@@ -244,6 +247,9 @@ func buildImportRows(rows []domain.DestinationImportRow) []util.Envelope {
 		if row.ErrorMessage != nil {
 			item["error"] = *row.ErrorMessage
 		}
+		if row.Diff != nil {
+			item["diff"] = row.Diff
+		}
 		resp = append(resp, item)
 	}
 	return resp
@@ -37,11 +37,27 @@ func RegisterDestinationStats(e *echo.Echo, auth *service.AuthService, destSvc *
 	admin := e.Group("/api/v1/admin/destination-stats", RequireAuth(auth), RequireAdmin(auth))
 	admin.GET("/views", handler.adminDestinationStats)
 	admin.POST("/export", handler.exportDestinationPopularity)
+	admin.GET("/cache", handler.cacheStats)
+}
+
+// cacheStats reports the destination read cache's hit and miss counters for
+// this replica.
+func (h *DestinationStatsHandler) cacheStats(c echo.Context) error {
+	stats, enabled := h.destinations.CacheStats()
+	hitRatio := 0.0
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		hitRatio = float64(stats.Hits) / float64(lookups)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"enabled":   enabled,
+		"stats":     stats,
+		"hit_ratio": hitRatio,
+	})
 }
 
 func (h *DestinationStatsHandler) getDestinationViews(c echo.Context) error {
 	dest, err := h.resolveDestination(c, strings.TrimSpace(c.Param("identifier")))
-	if err != nil {
+	if dest == nil {
 		return err
 	}
 
@@ -167,6 +183,9 @@ func (h *DestinationStatsHandler) exportDestinationPopularity(c echo.Context) er
 	return writer.Error()
 }
 
+// resolveDestination loads the published destination for a UUID or slug. When
+// it returns nil the response (an error or a retired-slug redirect) has
+// already been written.
 func (h *DestinationStatsHandler) resolveDestination(c echo.Context, identifier string) (*domain.Destination, error) {
 	if identifier == "" {
 		return nil, c.JSON(http.StatusBadRequest, util.Error("destination identifier required"))
@@ -189,6 +208,9 @@ func (h *DestinationStatsHandler) resolveDestination(c echo.Context, identifier
 		}
 		return nil, c.JSON(http.StatusInternalServerError, util.Error("unable to load destination"))
 	}
+	if dest.RetiredSlug != nil {
+		return nil, redirectToCanonicalSlug(c, dest)
+	}
 	return dest, nil
 }
 
@@ -32,7 +32,10 @@ func NewRouter(allowOrigins []string) *echo.Echo {
 			echo.HeaderAccept,
 			echo.HeaderOrigin,
 			echo.HeaderXRequestedWith,
+			echo.HeaderIfModifiedSince,
+			"If-None-Match",
 		},
+		ExposeHeaders:    []string{"ETag", echo.HeaderLastModified},
 		AllowCredentials: allowCredentials,
 	}))
 
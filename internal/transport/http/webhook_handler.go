@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/service"
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/util"
+)
+
+type WebhookHandler struct {
+	webhooks *service.WebhookService
+}
+
+func RegisterWebhooks(e *echo.Echo, auth *service.AuthService, webhooks *service.WebhookService) {
+	if webhooks == nil {
+		return
+	}
+	handler := &WebhookHandler{webhooks: webhooks}
+
+	admin := e.Group("/api/v1/admin/webhooks", RequireAuth(auth), RequireAdmin(auth))
+	admin.GET("", handler.list)
+	admin.POST("", handler.create)
+	admin.GET("/events", handler.events)
+	admin.GET("/:id", handler.get)
+	admin.PUT("/:id", handler.update)
+	admin.DELETE("/:id", handler.delete)
+	admin.GET("/:id/deliveries", handler.listDeliveries)
+	admin.POST("/deliveries/:deliveryId/replay", handler.replay)
+}
+
+type webhookRequest struct {
+	URL          string                `json:"url"`
+	Description  *string               `json:"description"`
+	Events       []domain.WebhookEvent `json:"events"`
+	Active       *bool                 `json:"active"`
+	RotateSecret bool                  `json:"rotate_secret"`
+}
+
+func (r webhookRequest) toInput() service.WebhookSubscriptionInput {
+	return service.WebhookSubscriptionInput{
+		URL:          r.URL,
+		Description:  r.Description,
+		Events:       r.Events,
+		Active:       r.Active,
+		RotateSecret: r.RotateSecret,
+	}
+}
+
+func (h *WebhookHandler) events(c echo.Context) error {
+	return c.JSON(http.StatusOK, util.Envelope{
+		"events": domain.WebhookEvents,
+	})
+}
+
+func (h *WebhookHandler) list(c echo.Context) error {
+	subscriptions, err := h.webhooks.ListSubscriptions(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, util.Error("could not load webhooks"))
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"webhooks": subscriptions,
+	})
+}
+
+func (h *WebhookHandler) get(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid webhook id"))
+	}
+	subscription, err := h.webhooks.GetSubscription(c.Request().Context(), id)
+	if err != nil {
+		return writeWebhookError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"webhook": subscription,
+	})
+}
+
+// create returns the signing secret once; it cannot be read back later.
+func (h *WebhookHandler) create(c echo.Context) error {
+	user, ok := CurrentUser(c)
+	if !ok || user == nil {
+		return c.JSON(http.StatusUnauthorized, util.Error("authentication required"))
+	}
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+	subscription, secret, err := h.webhooks.CreateSubscription(c.Request().Context(), user.ID, req.toInput())
+	if err != nil {
+		return writeWebhookError(c, err)
+	}
+	return c.JSON(http.StatusCreated, util.Envelope{
+		"webhook": subscription,
+		"secret":  secret,
+	})
+}
+
+func (h *WebhookHandler) update(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid webhook id"))
+	}
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid request body"))
+	}
+	subscription, secret, err := h.webhooks.UpdateSubscription(c.Request().Context(), id, req.toInput())
+	if err != nil {
+		return writeWebhookError(c, err)
+	}
+	resp := util.Envelope{
+		"webhook": subscription,
+	}
+	if secret != "" {
+		resp["secret"] = secret
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) delete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid webhook id"))
+	}
+	if err := h.webhooks.DeleteSubscription(c.Request().Context(), id); err != nil {
+		return writeWebhookError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"success": true,
+		"message": "Webhook deleted",
+	})
+}
+
+func (h *WebhookHandler) listDeliveries(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid webhook id"))
+	}
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	deliveries, err := h.webhooks.ListDeliveries(c.Request().Context(), id, limit, offset)
+	if err != nil {
+		return writeWebhookError(c, err)
+	}
+	return c.JSON(http.StatusOK, util.Envelope{
+		"deliveries": deliveries,
+	})
+}
+
+func (h *WebhookHandler) replay(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, util.Error("invalid delivery id"))
+	}
+	delivery, err := h.webhooks.Replay(c.Request().Context(), id)
+	if err != nil {
+		return writeWebhookError(c, err)
+	}
+	return c.JSON(http.StatusAccepted, util.Envelope{
+		"delivery": delivery,
+	})
+}
+
+func writeWebhookError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		return c.JSON(http.StatusNotFound, util.Error("webhook not found"))
+	case errors.Is(err, service.ErrWebhookDeliveryNotFound):
+		return c.JSON(http.StatusNotFound, util.Error("webhook delivery not found"))
+	case errors.Is(err, service.ErrWebhookValidation):
+		return c.JSON(http.StatusBadRequest, util.Error(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, util.Error("internal error"))
+	}
+}
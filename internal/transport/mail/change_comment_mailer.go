@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/njprem/Fit_city_APP_BackEnd/internal/domain"
+)
+
+type userLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+// ChangeCommentMailer emails people involved in a destination change request
+// when someone comments on it.
+type ChangeCommentMailer struct {
+	smtp  smtpSender
+	users userLookup
+}
+
+func NewChangeCommentMailer(host, port, username, password, from string, useTLS bool, users userLookup) *ChangeCommentMailer {
+	return &ChangeCommentMailer{
+		smtp:  newSMTPSender(host, port, username, password, from, useTLS),
+		users: users,
+	}
+}
+
+func (m *ChangeCommentMailer) NotifyChangeComment(ctx context.Context, recipientID uuid.UUID, change *domain.DestinationChangeRequest, comment *domain.DestinationChangeComment) error {
+	if m == nil || m.users == nil {
+		return errors.New("mailer not configured")
+	}
+	recipient, err := m.users.FindByID(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	if recipient == nil || strings.TrimSpace(recipient.Email) == "" {
+		return errors.New("recipient has no email address")
+	}
+
+	name := "your destination change"
+	if change.Payload.Name != nil && strings.TrimSpace(*change.Payload.Name) != "" {
+		name = fmt.Sprintf("the change to %q", *change.Payload.Name)
+	}
+	subject := "New comment on a FitCity destination change"
+	body := strings.Builder{}
+	body.WriteString(fmt.Sprintf("There is a new comment on %s (change %s).\n\n", name, change.ID))
+	if comment.Field != nil {
+		body.WriteString(fmt.Sprintf("Field: %s\n", *comment.Field))
+	}
+	body.WriteString(comment.Body)
+	return m.smtp.sendPlain(ctx, recipient.Email, subject, body.String())
+}
@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type smtpSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	useTLS   bool
+}
+
+func newSMTPSender(host, port, username, password, from string, useTLS bool) smtpSender {
+	return smtpSender{
+		host:     strings.TrimSpace(host),
+		port:     strings.TrimSpace(port),
+		username: username,
+		password: password,
+		from:     strings.TrimSpace(from),
+		useTLS:   useTLS,
+	}
+}
+
+func (m smtpSender) sendPlain(ctx context.Context, to, subject, body string) error {
+	if m.host == "" || m.port == "" || m.from == "" {
+		return errors.New("mailer missing configuration")
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	message := strings.Builder{}
+	message.WriteString(fmt.Sprintf("From: %s\r\n", m.from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", to))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 7bit\r\n\r\n")
+	message.WriteString(body)
+	message.WriteString("\r\n")
+
+	addr := net.JoinHostPort(m.host, m.port)
+	var auth smtp.Auth
+	if m.username != "" || m.password != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	return smtp.SendMail(addr, auth, m.from, []string{to}, []byte(message.String()))
+}
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE destination_version
+    ADD COLUMN IF NOT EXISTS source_version BIGINT;
+
+COMMIT;
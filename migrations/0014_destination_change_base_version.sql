@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE destination_change_request
+    ADD COLUMN IF NOT EXISTS base_version BIGINT;
+
+UPDATE destination_change_request c
+SET base_version = d.version
+FROM travel_destination d
+WHERE c.destination_id = d.id
+  AND c.base_version IS NULL
+  AND c.status IN ('draft', 'pending_review', 'rejected');
+
+COMMIT;
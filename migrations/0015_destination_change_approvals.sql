@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS destination_change_approval (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    change_request_id UUID NOT NULL REFERENCES destination_change_request(id) ON DELETE CASCADE,
+    reviewer_id UUID NOT NULL REFERENCES user_account(id),
+    decision TEXT NOT NULL,
+    comment TEXT,
+    draft_version INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT destination_change_approval_decision_check CHECK (decision IN ('approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS destination_change_approval_change_idx
+    ON destination_change_approval(change_request_id, created_at);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS destination_change_comment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    change_request_id UUID NOT NULL REFERENCES destination_change_request(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES destination_change_comment(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES user_account(id),
+    body TEXT NOT NULL,
+    field TEXT,
+    resolved_by UUID REFERENCES user_account(id),
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS destination_change_comment_change_idx
+    ON destination_change_comment(change_request_id, created_at);
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE destination_change_request
+    DROP CONSTRAINT IF EXISTS destination_change_request_action_check;
+
+ALTER TABLE destination_change_request
+    ADD CONSTRAINT destination_change_request_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore'));
+
+COMMIT;
@@ -0,0 +1,63 @@
+BEGIN;
+
+ALTER TABLE travel_destination
+    ADD COLUMN IF NOT EXISTS timezone TEXT,
+    ADD COLUMN IF NOT EXISTS opening_hours JSONB;
+
+-- Carry the single daily opening/closing time over as every-day weekly hours.
+UPDATE travel_destination
+SET opening_hours = jsonb_build_object(
+        'weekly',
+        (SELECT jsonb_agg(jsonb_build_object('day', day, 'open', opening_time, 'close', closing_time))
+         FROM unnest(ARRAY['mon', 'tue', 'wed', 'thu', 'fri', 'sat', 'sun']) AS day)
+    )
+WHERE opening_hours IS NULL
+  AND opening_time ~ '^[0-2][0-9]:[0-5][0-9]$'
+  AND closing_time ~ '^[0-2][0-9]:[0-5][0-9]$'
+  AND opening_time <> closing_time;
+
+-- Periods starting on a local date: that date's exception if present,
+-- otherwise the weekly periods for its weekday.
+CREATE OR REPLACE FUNCTION destination_periods_on(hours JSONB, day DATE)
+RETURNS SETOF JSONB
+LANGUAGE sql STABLE AS $$
+    SELECT period
+    FROM jsonb_array_elements(
+        COALESCE(
+            (SELECT CASE WHEN COALESCE((e->>'closed')::boolean, false) THEN '[]'::jsonb
+                         ELSE COALESCE(e->'periods', '[]'::jsonb) END
+             FROM jsonb_array_elements(COALESCE(hours->'exceptions', '[]'::jsonb)) AS e
+             WHERE e->>'date' = to_char(day, 'YYYY-MM-DD')
+             LIMIT 1),
+            (SELECT COALESCE(jsonb_agg(w), '[]'::jsonb)
+             FROM jsonb_array_elements(COALESCE(hours->'weekly', '[]'::jsonb)) AS w
+             WHERE lower(w->>'day') = (ARRAY['sun', 'mon', 'tue', 'wed', 'thu', 'fri', 'sat'])[EXTRACT(DOW FROM day)::int + 1])
+        )
+    ) AS period
+$$;
+
+-- Mirrors domain.OpeningHours.IsOpenAt: a close at or before the open time
+-- runs past midnight, so the previous day's periods are checked as well.
+CREATE OR REPLACE FUNCTION destination_is_open(hours JSONB, tz TEXT, at_time TIMESTAMPTZ)
+RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+    WITH local_time AS (
+        SELECT (at_time AT TIME ZONE COALESCE(NULLIF(tz, ''), 'UTC')) AS ts
+    ), clock AS (
+        SELECT ts::date AS today, to_char(ts, 'HH24:MI') AS now_hm FROM local_time
+    )
+    SELECT hours IS NOT NULL AND (
+        EXISTS (
+            SELECT 1 FROM clock, destination_periods_on(hours, clock.today) AS p
+            WHERE clock.now_hm >= p->>'open'
+              AND (p->>'close' <= p->>'open' OR clock.now_hm < p->>'close')
+        )
+        OR EXISTS (
+            SELECT 1 FROM clock, destination_periods_on(hours, clock.today - 1) AS p
+            WHERE p->>'close' <= p->>'open'
+              AND clock.now_hm < p->>'close'
+        )
+    )
+$$;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Localized name, description and gallery captions keyed by locale, e.g.
+-- {"th": {"name": "...", "description": "...", "captions": {"<url>": "..."}}}.
+ALTER TABLE travel_destination
+    ADD COLUMN IF NOT EXISTS translations JSONB;
+
+COMMIT;
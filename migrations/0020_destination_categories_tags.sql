@@ -0,0 +1,39 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS destination_category (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    parent_id UUID REFERENCES destination_category(id) ON DELETE RESTRICT,
+    icon TEXT,
+    display_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS destination_category_parent_idx
+    ON destination_category(parent_id, display_order);
+
+-- Seed the taxonomy from the categories destinations already use so existing
+-- rows stay valid once categories are managed.
+INSERT INTO destination_category (slug, name)
+SELECT DISTINCT ON (slug) slug, name
+FROM (
+    SELECT trim(both '-' FROM regexp_replace(lower(trim(category)), '[^a-z0-9]+', '-', 'g')) AS slug,
+           trim(category) AS name
+    FROM travel_destination
+    WHERE category IS NOT NULL AND trim(category) <> ''
+) existing
+WHERE slug <> ''
+ORDER BY slug, name
+ON CONFLICT (slug) DO NOTHING;
+
+-- Free-form tags stored as a JSON array of strings, e.g. ["parking", "showers"].
+ALTER TABLE travel_destination
+    ADD COLUMN IF NOT EXISTS tags JSONB;
+
+CREATE INDEX IF NOT EXISTS travel_destination_tags_idx
+    ON travel_destination USING GIN (tags);
+
+COMMIT;
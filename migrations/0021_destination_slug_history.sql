@@ -0,0 +1,42 @@
+BEGIN;
+
+-- Slugs a destination no longer uses. They keep resolving to the destination
+-- and stay reserved so shared links never point somewhere else.
+CREATE TABLE IF NOT EXISTS destination_slug_history (
+    slug           TEXT PRIMARY KEY,
+    destination_id UUID NOT NULL REFERENCES travel_destination(id) ON DELETE CASCADE,
+    retired_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS destination_slug_history_destination_idx
+    ON destination_slug_history(destination_id);
+
+-- Records the previous slug whenever it changes, whether through an update or
+-- a restore. Taking an old slug back removes it from the history.
+CREATE OR REPLACE FUNCTION destination_record_slug_history()
+RETURNS TRIGGER
+LANGUAGE plpgsql AS $$
+BEGIN
+    IF NEW.slug IS NOT NULL THEN
+        DELETE FROM destination_slug_history
+        WHERE slug = NEW.slug AND destination_id = NEW.id;
+    END IF;
+    IF OLD.slug IS NOT NULL THEN
+        INSERT INTO destination_slug_history (slug, destination_id)
+        VALUES (OLD.slug, OLD.id)
+        ON CONFLICT (slug) DO UPDATE
+            SET destination_id = EXCLUDED.destination_id,
+                retired_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS travel_destination_slug_history ON travel_destination;
+CREATE TRIGGER travel_destination_slug_history
+    AFTER UPDATE OF slug ON travel_destination
+    FOR EACH ROW
+    WHEN (OLD.slug IS DISTINCT FROM NEW.slug)
+    EXECUTE FUNCTION destination_record_slug_history();
+
+COMMIT;
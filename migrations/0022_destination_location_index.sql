@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Bounding-box and map queries match point(longitude, latitude) against a box,
+-- which a GiST index can answer; the composite B-tree could only narrow one
+-- axis at a time.
+DROP INDEX IF EXISTS idx__destination_lat_lng;
+
+CREATE INDEX IF NOT EXISTS idx_destination_location_gist
+    ON travel_destination
+    USING GIST (point(longitude, latitude))
+    WHERE status = 'published' AND deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Weighted full-text document for search ranking: name (A) outranks city and
+-- country (B), category (C) and description (D). The 'simple' configuration
+-- keeps matching language-neutral, as before.
+ALTER TABLE travel_destination
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(city, '') || ' ' || COALESCE(country, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(category, '')), 'C') ||
+        setweight(to_tsvector('simple', COALESCE(description, '')), 'D')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_destination_search_vector
+    ON travel_destination
+    USING GIN (search_vector);
+
+COMMIT;
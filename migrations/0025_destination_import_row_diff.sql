@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Import rows can update or archive existing destinations; each row keeps the
+-- diff against the live destination so dry runs can be reviewed.
+ALTER TABLE destination_import_row
+    ADD COLUMN IF NOT EXISTS diff JSONB;
+
+COMMIT;
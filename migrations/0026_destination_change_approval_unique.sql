@@ -0,0 +1,16 @@
+BEGIN;
+
+-- A reviewer decides once per draft version. Duplicates recorded by racing
+-- requests, or by re-reviewing an unchanged resubmission, keep only the
+-- latest decision.
+DELETE FROM destination_change_approval a
+USING destination_change_approval b
+WHERE a.change_request_id = b.change_request_id
+  AND a.reviewer_id = b.reviewer_id
+  AND a.draft_version = b.draft_version
+  AND (a.created_at, a.id) < (b.created_at, b.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS destination_change_approval_reviewer_uidx
+    ON destination_change_approval(change_request_id, reviewer_id, draft_version);
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+-- Compare opening hours as times rather than text, so hours stored without a
+-- leading zero ("9:00") are checked correctly. "24:00" casts to the end of
+-- the day.
+CREATE OR REPLACE FUNCTION destination_is_open(hours JSONB, tz TEXT, at_time TIMESTAMPTZ)
+RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+    WITH local_time AS (
+        SELECT (at_time AT TIME ZONE COALESCE(NULLIF(tz, ''), 'UTC')) AS ts
+    ), clock AS (
+        SELECT ts::date AS today, date_trunc('minute', ts)::time AS now_hm FROM local_time
+    )
+    SELECT hours IS NOT NULL AND (
+        EXISTS (
+            SELECT 1 FROM clock, destination_periods_on(hours, clock.today) AS p
+            WHERE clock.now_hm >= (p->>'open')::time
+              AND ((p->>'close')::time <= (p->>'open')::time OR clock.now_hm < (p->>'close')::time)
+        )
+        OR EXISTS (
+            SELECT 1 FROM clock, destination_periods_on(hours, clock.today - 1) AS p
+            WHERE (p->>'close')::time <= (p->>'open')::time
+              AND clock.now_hm < (p->>'close')::time
+        )
+    )
+$$;
+
+COMMIT;